@@ -8,7 +8,7 @@ import (
 
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00[t\xeeX\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00index.tmplUT\x05\x00\x01\x0e\xe2\x93f\xe4[\xefV\xe3Hv\xff\xceS\xdc\xd5\x90\x0cd\x90\x05\x0d\xb3;\xeb\xb1\x9d0@O3\xa1\x81ihv:\xd3\x13\xb6,][\x05\xa5*uU\xc9\xe0\xfe\xf3\x0c9'_\xf7\xc3\x9e\xbcE\xde*\xfb\x089U\x92,\xc9\x96-\x1b\xe8\xc9\xec\x89>\xd0\xee\xfas\xeb\xfe\xfd\xdd[%U\xe7w\x87g\x07\x97o\xce\x8f \xd4\x11\xeb\xadu\xcc?\xc0\x08\x1fv\x1d\xe4\x8ei@\x12\xf4\xd6\x00\x00:\x11j\x02~H\xa4B\xddu^_>w\xbfq\xbcr\x1f'\x11v\x9d\x11\xc5\xbbXH\xed\x80/\xb8F\xae\xbb\xce\x1d\x0dt\xd8\x0dpD}t\xed\x7f\xb6\x80r\xaa)a\xae\xf2	\xc3\xeeNk{\x0bT()\xbfu\xb5p\x07Tw\xb9\x98P\xd7T3\xec}\xf8\xf9\x03\xb4\xecO\xf8\xf4\xcb\xa7\x8e\x97\xb6\xce\xac\x7f\x88\xca\x974\xd6T\xf0\x12\x0bvrPt\x19\x12\x0e\xd4\xb0\x7f\x8b\xe3;!\x03U\x9a;\"L\xc4([Tl\x81/T$\x94\xab\x82\xdb\xfc\xf7\x16h\xe4\x01\xca\x88r\xbd\x05v\x1d?$\x94\x9f\x92\x08kV\x89\xa5\xa1\xa5\xc7]G\x0c\xdbz\x1cci\xa1>\x13\xfe\xad\x9d\xbcp\x92\x91{Z\xb4\x89^\x16\xcdTT\xe3\xb5\x11sz\xb6\xe9X\x86\xdf\xe0\xa1\xba\xadP\xa1\x11\x19\xce\xb0\xc0\xc4P,5\xb1\x9dH\xf6\xf0\xc9\x84\xe9\x15\x84O]B\xdfQ\xadQ\xb6}\"\x83\xd5\xc4\xae\xce\xaf5\xdcR\xaa\xcf)<\x81\xfesR\xab\x18!\x15C\x89\x81\xbe#\x12\xdd\x11JU\xc3A\x9fr\"\xc7Wig\x95\n\xa3\xfc\x16$\xb2\xae\xa3B!\xb5\x9fh\xa0\xbe\xa1\x10J\x1cd\xd3\x07dd\xdaj&\xa6\x83B\xadc\xd5\xf6<?\xe0\xad\x1b\x15 \xa3#\xd9\xe2\xa8=\x1eG^_\x08\xad\xb4$\xf1\xbf|\xdd\xdan=\xf3\x02\xaa\xb4\xe7+Ut\xb4\"\xca[\xbeRN\xc6\x87\x1e3T!\xa2v\x80r\x8dCI\x8dvTHv\xbf\xd9s\x8f\xae..\x7f<\xdd\xf5\xc8\xfbX~\xbf\xb3\xcf\xa3\xdd\x1f\x0f\x87\xf1\x0f'\xc7\xd1\x1fO\x89\xd8~\xf3~\xe7\xbd\xf6\x7f\xbc\xbc{\xae\xe2`w|\xf8\xfb\xaf\xafD\x18\xc6Irp\x16\x9d\xec_\xdc\x1c8\xe0K\xa1\x94\x90tHy\xd7!\\\xf0q$\x12\xe5\xcc\xea\xa3\xc4G*\xa7'Q\x89D\xfa\xa8<\xe3\x1b\x96\xe7\\\x1dv4\x18\xd4\xe8:\x1a\xef\xad\x88\x19M\xf3|qG4\xca\x88\xc8[7\xc3,\xf80\xe94O,\x145\x1e\xd2\x86\x01\xbd\xc7\xe0\xdbJ\xa7\x16q\x1b\xb6\xabm\x0c\x07z\xa6\xd1\"x\x1bv\xb6\xb7\xff\xa1\xda\x11\"\x1d\x86\xba\xae\xe7\xbdKy\x80\xf7mpw\xbe\x9d\xe2\xc8h_\xba8B\xaeU\x1b\xb8\xe0X\x1d\xd1'\xfe\xedP\x8a\x84\x07n\x1a\xc2\x90H\xb6\xe1\x14Z\xb2\xad\xca3\xbe\xeb\xee\xb4\xd4h\xe8l\xce\xa5 1F\xa2\xdb\x90\xfe;w\x98\xa2\xef\xb1\x0d_o\xc7\xf7\xf6Ou\x9c\x88\x89O\xf5\xb8\x0d\xad\xed\xbd\xa2\xe7\xd3Za\x06Fx@\xf9\xd0\xb5\xe1\xf4\x1b\xb1\xc0*\x8a^F\xc5\xc0E\xa6M\xf0\xd1P\x9ec\xf0?\xceU\xdeN\xad\xf2Z}Ixpb\x02\xa4\xaa9\xe3\xefn\x80\xbe\x90$U\xe0\xac\x04\xbe`B\xb6\x81\xf2\x10%\xd5\xf3\x16\xde-\xafk\xfev<\x1bW\xbd\xb5\x8e\x97\x96;\x9d\xbe\x08\xc6Y\xc8\x05t\x044\xe8:3\xa1\xe5\xf4:^@GS\xc3\xca\xa6w\xc0gD\xa9\xae\x13\xb8\x86\xd7\xfa	\x91\xcd\xf6\xd9@\x03\xa8\x84r\x94\x10\x8d\xdd\xddRdw\xc2\xdd^\x1d\xccw\xbcp\xb7\x18eF\xd0A\x01\xe3\xc5t\xb3Z\xb6FT!l{i4\x9c\xf4RN][\x82d\"(\xe9\xcf$\x07\xc2t\xd7I\xbb\xbd\x12\x8f\x85t93\xc8\x83*\x1f\xe1^o\xb6B\xeax\xe1^u\x9e$|\x88\xb0\xce\xa0\xdd\xcd\xc6\x9a\xaa\xee\x84rTUz*&\xbc\xd0\xb2\xad\x9f\x1c\xbb\xc2:K)\x9b\x01\x0d<\x95u\xa3\xdd\xbdi\xe5\xc4\xbd\x03\xc3\x00\x1c\x1f\xb6K\xd5\xddq\x90\xd2\x8fgF\xbf:?hC\x87\x94S\x9b\x8c\xfd\xd7\x92\xa5\xba\xd3D\x0eM\xfd|\xddg\x84g\xcc\x96\x06t<\xd2\xab\xa3\xba\x7f~<C\x15\x95n [\x8c\xa8\xa5\x9b\xfb\xcb\xb0X\xbf\xd2\x9f\xad\xfd}\x8dH\xc3F\x99\x86\x0dB\xd5\xda\xa2\xeaB\x93 !\xbe/d@\x05\x7fY\x8e\x96I\xeb\xb4\xc9J\x16\x9d\x8cq\xa9\xc6hj \xa4\x915;\xd6\xc0\x00J\xc7\xaem~S><\xa1#<G\x94\xaa\x86\x06L\xbbbA\xa9\x9fh-8\xf8\x89TB\xba\x19\x08;\x10\x10M\xdc\xber\xb5\x18\x0e\x19\x9a\xc0g\x8c\xc4\nK=\x99N\xbf\xc8\xbb\n\x06\x80HJ\\\xbc\x8f	\x0f0\xe8:Z&\x985\x1a\x00\x91\x82\xa9\x82b\x13\xdf\xe61c\xc0\x0e\xaa\x17m*\x8a\x8a\xf62\xf8LZs\xa3\xd50>\xa3\x9e|\x0cL~\xa8P\xdce\xc20\xd2G\xc60\xe8\x8fk\xac0QTL\xa4\xadA\xbf\xa8:\xc9\x1c+\x95\\\xc3\x94\xf3`\xfe\xb8\x06\xee\x17\xa8'\x0b\x93\xa1\x86\x16\xcb\xd7?\x10	\xd7\xb0]\x1b2\xf9c\x16Kcaj\xda\xa7_>\x81i\x82\xd8\xb4\xb5\xa7\xbc\xbe\x8eL\xce\xb3M\x82\n}\xc1\x03\"\xc7N\xafC{\x12	s5\x8d\xd0j\x04\x06RD \x12	\\\x04\xd8\xf1h\xaf\x89z,1\xa7\xde\x172@	\xb1\xfb\xcc\xe9u|\x11\xe0\x14\xf7i,\xdb\x8e\x8e\x17K\\\xac2d\n\x17\xeb\xa7\x08\xbd4\xb9\x1b\xd4\x97N\xefd\xa2\x1a`Ti\xa0\n4F\xb1\x90D\x8e\x81\x0b\x0ddD(#}\x86\xf5\x1e\x98?u\x08S~\xe6h\xa6\xa6\xb9\xae\xe9s\x80\xcc~\x10\xc8\xbe\x10\xb7\x0f\xc3\x98,\x84\x82\xa7@\x9b	'\xd3`3 L\xcdG\x9b\x06\x01\xcc\x93\x0f\xf9\x1cPSp\xdd\x8c4\xf3A\xa6\xa0\xf2w\x801q\xefP\xdcq&HPJ\xd1^\x90\xb5y$\x93\xa5u\xa3L\x9e\xac\xfc\xb76-W\x89/\xc2\x86\xbb!jp\xcf\xa0B\x13\xf2m\xbaE\x8eP(\x8bvs\xf8Y\x8bFS\xb3\xd7_\x9c\xbd<J\xe7\x0e\x91\xa3$\xecT\x04\xf8BD\x933\x12\xcf\x17|@\x87\xbf\x02\x0c\xe5n\xf0\xff\x05|.8\x89U(\xf4\xc3\xc0\xe7\xf1\x903Y\x7f\xa5\xfa\xa6\x81k\xf3\xe4C>\x07\xe2\x14L7#NCmS\x90\xfa\xd5a\xa7\xa5\xb2\xb5[GR\n\xd9\x808\xb5\xd1\x92s\x0f\x94\x0f\x84\x8c\xec\x1e}~\xe4,\x00\x9d\xe5bvaM\x03)0>\xa7\x0c!=\xca\xb1\x882\x11\xf2\x82\xbe\xc7\xda\xed[\x0d\x91\xd7q@4\x06\xd3$^\x8a\xe0\x92\xa6\x88\x04d(\x96\xa1T\x83\xd3U\x9a\xf9\x00\xc3\xf79\xd1\xa1\xdd\\\xf5\xaacL_\xb1on\xc0o\xbbp(\xbd\xa6\x11{\xbd\x17\xe2\x0e\xb4\x80X\n\x1f\x95\xaay\x8b\x019\x07\xd5\xcdz-\xbd\xb8w\xcc\x95&\x8c\x01{\xbfg\xaa7\x89\xef\x12*1\xd8\x02\x9au\xd0\x81\xf5\x881\xea\xbc	\x83fQ\x16\xa6#\x95\x04\x02H\xac!\xb1\x16\x83\x7f$Q\xfc\xad\xfd\x03\x93\xae|y#L\x00\xeexf\x90\xe9\x98\x8cb\xef\xf7\x96K3P50\xe8\x10K\xfaz\x94Ty\x92\x9d\xef\x05\xd0\xecD+Iq\xa1El%H7\x10\x8f\xe2>\xd5\xe9Xi\x8c|\xcd@\x19\xd2\xe5\xe4\xfe\x9d}eP\xf8\xf3\nlv\xfa\xbds\x89\x06!\x8d\xe3JT\xa8;^\xbf\x07\xe3|\xef\xd3\x82\xcb\x90*\xb8\xa3\x8c\x01J\xa2\xb0\xe8\xb2\x00\xdb'\n[K\xc8\xd7;\x1e\x98\x99`V\x92	\xe7\x94\x0f\x81@\xa7_\x8b\x82#\xc2h@\xb4\x90\x86\x97-;\xaf\xd3\xef\xbd\xd8\xbf:\x82\xcb3{\xc2\x9a\xc4\x05\x9b\x9d~\xef\xcf\xb1\xa4\xa3\xeb\xc9\xb4\xeb[\x1c\xdbJ\xe8\xcff\xd42\xec-R\xbf\x1f/SP\x19exSl(M4.]\x92\xcd\x9d\xbc\x8aE\xe7mq\xafP\xd2\xc1\xb8QW@x\x1ax\xa9\x92\xad\xb9\xf0]B\x98z\xa4\x12\x15C\x8cag\xbb\x8c\x15>\xd1\xcb\xe8\xa5A\xb3\xab\x13|\x12E\xf7^\x99`IO\x0b\xc4\xe0\xa9B}~X\x97\xde\x8fC\xc2\x15\x19\xa0k\xc3\xd55\x18\xeb\xba\xa1\x88p	\xd9\xc1uo\x11c\xd7\xec\x17\\S\x96\xaf$\xf2!\xfa\"\x8a\xa5\xc9mep6\xd0a#1\x07\x04`\xc2\xb7\xa5K\x0b\xde\xd4\xb6Cbd\xb1\xde\xd8h\xfa\xd4/\x85\x04%\"\xd4\xa1\x01\x0eFo\x11tH\xf4\x16\x04\x18\xa3}W\x00\xa6P\xca\x8c\x004\x8a\x19F\xc8u\xca\xc5#\x8db\xf2\xaf\xeb\x83\x1b,\xca\x1f\x1fA\x13	\xee=\xb8\x07K\xd8a%\xb5w\xfa\xbd\xe3\x97\xe7g\xaf.\xf7O/\xdbV\x1b\x0f\x06S\xab\x9e-\xa3\xf8>\x82J&\xb0\xaf\x85D\xdboTX\x03\x12E\x8c\xa4\xe6\x18\x98\xa2\xd0X\xc0\xf2\x11\x92Q\x8a\x18\x18@\x12?V\xdd\xd1h	\x05>\x0ej\x17C\xca*\xc6y,\xde\xde\x11\x95\xeb\xff\xb1\xa5\xdb\x92\xe8\xf7t\xb2\x1b\x08\xd4D\xea\xa7\x02\xbf\xa9:Gf\xd4\x9f\xa8\xd4y\x85\x91\x18\xa1\xe55?G\xc1 sd\x01\x03\x89\xe8&1\x04T\xdd\x82\x8a\x89\xffXad\x04\xeeh\x01`\xac\xc4\xfbA\x88\xfe-01|\xc2Tc\xb5}#\x12\xc9	3\xeav\x07\xc9\xd3\xa8\xba\xfa&\xf0\xd9\x82}\xf3dJ\x83 \xf9\xd3\xe9\xf7\xf6\x0f\xaf\xf6O\x0f\x8e\x0e\xe1\xd5\xd9\xeb\xcb\xa3v\xf5<\xa5\x82{\x1bfk$M\xc6\x8aL\xe6\x0c` $\\\xe5.o5\xb8\x99\x1d[\xd8H\xbc\x0c\x11H\xdf\xb8\x88\x12,\xb1Y*\xdbq)\x0brZL\xfc\xc6\x9a O\x85\x99\x13mA\xc2KMT\xe7\xa5\x14\x87\x00\x19\xea\xd4\xf3\xd2oY\x08\xb3SZK	m\xeb\xef	'x\xaf%1[\x00I\x86\x98:\xaa\xc9|6\xfd*\x94#\x94\xcbQ}#\x12\xf0	7\xe9\xc326\x10\x8c\x89;\x93H|\x11\xf5\x85\xf9\x1bY\x01\x04(-\x91D\xd5\x8cO\xf9\xfc\x9c\xdf\xe97\xed\x95\xf3\xa7\x93\xf4\x9e\x0b	$\x18\x11\xee\x9b\xdc\xa1P*\x10\x9c\x8d\xdb\x1d/i\xa6\xd1\x18\x01\xb0\\\x14,\xbd\xb7\xaa\x14\x9b\xff\xd7\xdb\x82V\xae\xb7k)\x12\x8d\xad>\xb9-\xf3\xf7\x1b.%\x9b\xcd\xe2'\x92\x81+\xc0\x05\xf7d\x89\xad9|\x84\xa2Hs?[-\x06Y=F+\x80\x13%\x1a\x03\xa7\x97eqXP:\xd9\x03\x1b\xc4\x00\x83-\x08D\xd2g\x08\xbeA\xf6-\xf0\x89\xc4A\xc2\xb8)\xad\xa9\x02\x8e#\x94 1Hx@\xb8N_@6\xa1}\xc3\x1b\xca\xc5\xdd\x1f\xfe\xfe\x0e\xda\x8d^/\xc6\xdc\x7f\xd8I\xfbS\xbe\xe6+XY\xed=_\x93\x08\xe6\xb1c@\x8d\xb9?\xc7.\x8f;x/8o>y_p\xe8^\x90\xf9\xf5N\xdd\xa7\x8a\xef;\"\xcdF\xc8\xe9\x1d\n{*j\xe3\xce5\x8a\x03\x02\xa3J\xdeOOP\x91\x04\xd5A\x83\x841\xd7\xee\x1fM\xe6\xb3\xd5\xa2\xdd\xdek15\xbf\x05\xdfe\xbb\xa7\xfc\x98\x84\x07y\x15\xdfX\xf27\xbc+\xec\x9d\x99L^\xb0\xf5\xea\xfc K\xec\xb6\x80\xa99S\xa6\x8b\xcff\x968p(\x7fc\xb4\x0c\x16.\xf1\xde\xc0\x8c\xc8O\x16	HL\x141p\x97~vgude\xbc62\xb6T\x98m\x86\xa8\x0eg\xaa\x91\x00[\x0d\xd8\x05\xb0\xf8\xf3\x8efL\xa7\xbdK[\xd1\x05\x98\x9et\x0eP\xfb!\xd0(\x16R\x13^\xf1\xa5\xf2\x8b\x99\x0d\x95\xf8!\x10\x05\xf6\x93\xb9\xec\xb3\xd1\xb4\xee\x93\x89\xd2\x10\x12\x15n6\xaen\x1e\xfb\xbdI\xc9\xd4\x86Fv\x02?9\xe3L_\xd9\xb6\xb4\x88Xi\x13\x9e\x85\x17\x1f\xb2q\xcb\xe4\x89\xcf\x98#\x16{\xd2\x17\xbf\xf3\xfa\x94{}\xa2\xc2\xb5\xb5\x8b\xd3\xfd\xf3\xebW\xe7\x07\xb3\x9f\xee\xad\xad\x9d\xec_\x1e]\\^\xbf8:\xfe\xfe\xc5ew}#\xcd\xf5\n\xd6\xf3I^\xaa\xce\x8fp\xf3\x0e\\i?\xc0K\x98n\xd9\xd6V\x9a	Z\xa9\xae7\xbf\x85\xb7k\xdf\x9d\x9c\x1d\xfckAn\xa3B\x1f\\x\xb6\xbd\xbd\xbdiG^\xbezm:\xf6/^\x94\x96u\xa6\xd6\xfd\xe7\x94tw\xbdL\xd7\xa9\xe7\xe6\x9a\x06\xad\xd4\xc8k&\x95\xb8\xd4\x16a\xee\x118\xea\xe3\xbfo 7>\xff\xf3\xcfm[\xa6\xb7\x7f\xf9\xe5\xabn\xf9?\x9b\xad\x7fZ\xff\xf8vG\xcb\x04?\x82a\xcf\xcc\x91\xb1\x7f\x9d\xfa\x80j\x9a\xf8\xb6\xe0|k\xf2\xeb\xadS\xd0\xb2Nx\x9d\x8a\xd3D\xac\"\xed\x0c	\xa2\xc2&\x02o\x9d\xf5B\xbdo\x9d\x8f\xce\x12\x95W\xf6\x15\x82W\xf2\xec\xe5\x00\xe8\xc3\x03\xbf\xf3\x80l;-\xb8\xa2\xc6}I`\x8f\x0e-\x8fs\xbe.s\xd3O\xa8\xd2\x9d\xa1\x16)\xbc\xa3TTi\xe4\xfa\xda\xf6\xa6\xe1Hy}\x98\x9aX\x9e\xec\x1c\xcd\x88\xca\xc7\x1a\xcd9\xa1\xa94\x03X\xf1\x8d\xd3S\xee\x88\x96\xb3\xd7\xc3\x8e\xcb\x97\xc8\\\xbf\xbd\xfdM'\xee\x1d\xdd\xa3\x9fd\x1b\xfe4\xe1=BL\x15B%S.\xcd\xc5J\x87s+\xb9\xc4S\x1c\xcc=x?Q\xfe\xfa\xbf\xf4s \x84F9\xa9!\x8b\x1b\x00}w\x17\x02w\xc0\xf0\x1e\x08\xa3\xc3t\xdb\xa1\xdc\xf4\xb6\x05\xdc$J\xd3\xc1\xd8\xcd\xee`e\xcd\xa5\x92!C\x1a|\x97]\xa9\x00\xe7jr\x8f\xd1\x81\xe9/\xe0{\x1d2\xd1b~\x01\xa3r=\xab\xb8\x96a\xbfJ8\x8bQ\x12m \xa8?\x86b\x80u?[\x0c\xfd\xed\xaf\x7f\xf9/\x0b \x7f\xfb\xeb\x7f\xfe\xe5\x7f\xfe\xfb?\xd2\x8f\x15fo\x08L\x7f\xe9\xf1\x10f\xce\xc5\x1dJ\x0cfY\x99\xb7h	\x93:^j\x80\xcc\x1aY\x99go=\xac~\xe3\xec\xa6|\xe1\xac\x9f\xf0\x80\xa1\xbdwv\xa3\xean\x99\xbd\x94\xfe\x9f~\xffo/\x9f\xbfa\xef\xfd\x93\xfdoN\xd9W\xa7\xfa\xf5\xd5\xf3m\xb5\xff\x87\x97\xea'u\xbe\xf3z\xfc\x83x\x19\xef\xbd99JN/\x06\xfb\xe7_\xfd\xe0\xff\xc4=\xfd'}L\xee\xaf~z9\xff\x96Y\xc7K\xe5X \x14\xb9!\xf7\xad\xa1\x10C\x86$\xa6\xaa\xe5\x8b\xc8\xb6y\x8c\xf6\x95w\xf3.A9\xf6v[\x7fh\xedd\xff\xc9\x05\x99C\xbct)\xed\x86\x8cH\xdaZrG\x86:\xbf\x83\x03](\xdd\xfda\xf6\x1b\x10\x1f\xba\xa6\xf4,]\xe8Y\xdf\x08\x84\x9fD\xc8\xf5fK\"	\xc6\x1b\x83\x84\xfb\xa6z\xdd\xd8\x9c\xba\x1a\xe4\x0b\xae4dWoN\xc4P@\x17\xd67\xbe\xac\xdc\xc3\xfar\xeaR\x18\x1d\xc0Fi\xc64I\xf3\x94\xba[\xd2\x9e\xc1\x1f\x18\xa7\xdc\xf82\xbd\xcf3M\x11&\x9c\xd8\xfd\xf8\x88\xb0\x8c\x15\x85\xfa8k\xd9\xd8\xd8\x84n\xaff-\xc8X\xa2\xdc\xafc%\x7fr\x0d~UQ\xe1\xf4c\x08\xe5#{]\xd8\xd9\xde^D\xd3\xce\xb0\x06\xb0{\xfe\xf9d\xeb\x93\xf8\xa74\x86\x9byv\xbb\xf0l9\x9e;\xb0\xd7\xc4\xf0\xacm6j\xccQ~|\x86DN\xecP6Q\xc3D\x89:\x91|U\xad\xd4\xb6\x96\xb9\xf6\x8d'e\x12\x7f\xb9\x95k\xc93\xc6\x9a\xe5\xe7\xd3\x16\xecL\xb7\x17K|\xda\xcc\xa0,\x0f\xcc\x8e\x97\xde`\xebx\xf6^\xff\xff\x06\x00\x00\xff\xffPK\x07\x08\xcf\xedA\xf2\xb8\x0e\x00\x00\xe7?\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xab\x92\xcdX\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1c\x00	\x00resources/images/favicon.pngUT\x05\x00\x01\xa28kf\x00\xb2\x02M\xfd\x89PNG\x0d\n\x1a\n\x00\x00\x00\x0dIHDR\x00\x00\x00 \x00\x00\x00 \x08\x06\x00\x00\x00szz\xf4\x00\x00\x00	pHYs\x00\x00\x0b\x12\x00\x00\x0b\x12\x01\xd2\xdd~\xfc\x00\x00\x02dIDATX\x85\xc5\x97\xc1\xad\xea0\x10E\xcdo\x00:\x08\x1d\x04\x89%\x8b\xb0f\x03\x12\x05@\x07\xd0\x01t\x00\x1d@\x07\xd0\x01P\x01t\x00T\x00\x1d\xf8\xe9D\x0c\xf2\xb3\x1d\xdb\xe4}\x89+E\x81\xd8\x99\xb9\x9e\xb93v\x1aZk\xad\xbe\x88\x7f\xdft\x0ej\x11\xd8n\xb7\xaa\xdf\xef\xabF\xa3\xa1\xda\xed\xb6Z\xaf\xd7\xea\xf9|\xd6c\xa0\x13\xf1x<\xf4b\xb1\xd0\xcdf\x93\x949\x17\xcf'\x93\x89\xbe^\xaf\xa9&KD	`\x10\xc3\xb6\xc3<\xcf\xf5j\xb5\xd2EQ8c\xc3\xe1P\x1f\x0e\x87\xbf\x11\xc0\x00\x86l\xe3\x909\x9f\xcf\x0e\xc9\xd9l\xe6D\x07r1\"\x0e\x01\x8c\xd9\xab\xc20\xe1'\x0d!0\xbe\xd9lt\x96e\xc9D~\x11`\x15\xe6\x8b\x18\xc2`\x1d\xecv;g!\xfc\xaf$@X\xff\x87c\x1b\xac\xdc$bG\xe2M\x80\x01\x99$ \xdf\xbc\xcc\x85\x1e>U\xb8	!a\x13\x08\xf6\x01\xea\xbd\xd3\xe9\xa8\xd3\xe9T\xd695\x1f\xeb\x0d\\\xd3\xe9T\x1d\x8f\xc7\xcf\xfb\x80\x1d\x01\xfd\x12\xa5\x081$B\xcaRJ\xd07\xaf*\x02Q\x02\xfa\x95\n\x9eS\xf7>\x88~B$\x93SP\x14\x85\x13%B\nh\xb9U\xe1\x97y\xadV+-\xf4/$\xed\x05\xe45\xcb2u\xbf\xdf\xbd\xb9\xdd\xef\xf7\xe5}>\x9f\x7f\xe4\x1c$oF\xcb\xe5\xb2\xbc\xcbjM\xe7\x10\xcb\xf3<(\xd2J\x98\xf9\x90V\xea\x03\xb9\x95q\xb3\x1cc\xfa\xb05`\x97\xb2\xf2M\xaa\x828\xa3-\xdb\xa4c=B\xaa\xc4\x86\xb7\x15\xdb\x9b\x8d@\xd4N\xa7\x04tK)\xbd\x18\xaaZ\xf1/\x0d 6p\xb9\\\xbc\x19\xa3)Q%\xe4\x1c-\x88\xf8F\xa3\x913\xf7v\xbb\xbd\x7f\x8bpy\xdf\x81\xc9\x86<\xc7V$\xab\x1e\x0c\x06\x95\xb5O\xa4\xcc\xbd$\x14Y')\x92\xe7P\xd7\xc3i\xaf\xd7{\x9f\x0fl\xe7\x8c\x9b\x9a e\x92\xb6(\x01i\xbd\xa6\xd0l0\xd6\xedv\xdf'#VH~\xc7\xe3q\xe9\x9cK\xc0\xb6\xcc\xbc\xaa\xdd\xd5+yV\x15\xeb\xfd\xa9`\xe5\x90\xac\x82\x97\x80\xd4|\x8a\xbaC R\xa1\xaa\xaa\x8c\x806J\xae\xee\xc1$\xf5\xfd\xe0\xa9X\x14O\x1e?uN\x04\xd1F\x0c\xd1c9\xce1\x96\x1a	q\x9e:?\xe9\xc3DN\xca\xa1\xca\xd0\xaf\x88!\xb8\xd4o\x82d\x02\xa6\x03\x84\xe9\xfb.\xa0rR\x8e\xee\x7f\" \xf0\x11\xa8[\xb2\xdf\xfd<WJ\xfd\x00a\xb4\xef\xdeo\xe4L\xd4\x00\x00\x00\x00IEND\xaeB`\x82\x01\x00\x00\xff\xffPK\x07\x08\x9f\x19H\x89\xbc\x02\x00\x00\xb2\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xab\x92\xcdX\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1b\x00	\x00resources/images/logo-1.svgUT\x05\x00\x01\xa28kf\x94U\xdbn\x1b\xc9\x11}\xb6\x01\xff\xc3\x84~\x89\x81\xeeR\xd7\xa5\xbb\xaah+\x8bx\x93u\x0c8@\x80\x05\xfcj\xc8\xa3Y\x89\xf0\x98\x12\xc8\xd1\xc5	\xf2\xefA5\xc5\xac\x85]=,\x01\x0e\x8b}\xa9\xeb9g\xde\xfcp\xffu\x1en\xa7\xdd~s\xb5=]!\x94\xd50m\xc7\xab\xf3\xcd\xf6\xe2tu\xb3\xfc\x92m\xf5\xc3_^<\x7f\xf3\xa7\x9c\x87w\xd3v\xda\x9d-W\xbb\xf5\xf0\xd7\xf3\xab\xcf\xd3\xf0~\x9eo\xf6K_\x1aH\x81\x01\xd3\xf0\xf3\xc7w\xc3\xdf\xef\xaf\xafv\xcb\xf0\xaf\xf9\xe6\"\xbf\xdf\x0e\xd0\x17?\x1e\x82\xac\x87\x06\xa5\x0coo6\xf3\xf9P^\x0dC\xce\xe1\x7f\x7f{\xf1}\x1a\xb8\x1a6\xe7\xa7\xab\x0fg\xdf\xa6\xdd'\\\x0d\xf7_\xe7\xed\xfetu\xb9,\xd7\xeb\x93\x93\xbb\xbb;\xb8c\xb8\xda]\x9cP)\xe5d\x7f{\xf1pd}?o\xb6_~\xef \xba\xfbI\xdf]\x0d\xf7\xa7\xabr}\xbf\x1a\xbe\x1d~_<\x7f6\xdcn\xa6\xbb\xb7W\xb11\x94\x01\x8b\x1d\x1e\xaba\xbf|\x9b\xa7\xd3\xd5\xb4=\xfb<O\xf9\xf3\xd9\xf8\xe5bwu\xb3=_o\xa7\xbb\xe1\xd1\xd9\xd7=\x85\xf5\xfe\xfal\x9cNW\xd7\xbbi?\xedn\xa7U/.\x9c\x0c\xcb\xb7\xeb\xe9t\xb5L\xf7\xcb\xc9\xb8\xdf\xc7\xc63\xd8/\xe5?\xbfl\xe6y\xfd\xf2\xa7\xfey\xfd\xdf\xc3*\x1eVov\xf3\x9f_\xfe\xfc\xf1\xdd\xfb\xbf}\xc2O\xaf^\xef\x97\xdd\xd5\x97i\xfd\xf2'}k*\x0f\x7f\xf3\xd7\xcd2\xed\xe6\xcd\xd7\xcd\xb2\xc6\xd2\x1d\xbcx\xfe,\x9c\xd0o\x9c\x94\xfei\xce\xc6\xae\x95\xcdUD\xc8M\xfb:V)\x8cU\x1aKuj\xd4J\xadOF\xbd\xdb\x9c/\x97\xeb\x02Z\x9f\xce\x03\xf6\x0b?\x91\x032\xaa\x97\xa2\x05\xc9\x98L	\x0f\xeb\xc5[a!\"BA5d\x17\xfd\x03\x95\xc3~\x91\xa7\xaa.Jb\xa5\x94\xdaC\xd5Z\xed\x98\x8a\"5\xc4&\xadqu4\xa4O\xaf\x8e\xde\xea\xef{C4v\xf2&\xca\x84\\\x1c\x99\xe9\xa1\x80\xd6T\x98\xac\x96f\xe6NB\xfe\xab\xb7\xf6Tn\xd6\xb82sS\x96\xd6\x88\n\xd9C\x18)\xceU[\x131B&V;x{s\xd2A\x15\xe8\xba\x08$\xbd\xb9>[.\x87\xf3\xd3\xd5?\x9b\x10(&\xa9\x15\xd8\xe6l\x04\xa6\x89\xb8\x00\xe9\x98\x11\xb8\xa5\x06n\x19A5a\x83\xd6\x92\x80\xb5D\x08(\x97\x8aP\xea\xad\x00\xe2?ji\xe0>#\x88\xe6X\x98\x19\xd0\xb2\x81\xd9\x87\x86\n\x9a\xa4 H\x0b\xac\xcd\x0cL\xd9\x81\xe5\xd2\x1b\xb0\xdd2\x10\xfe\xa8\xa8 \x98\xd8+0\xa7\xa6\x02,\x89\x9d\x00)=J\xf2\xdf\xab\x93\xc7%(VpJ\x8d\x14*\xcd\x19\x1d\x10\x93:\x90\xce\xb9\x80[\x8a|.s8\xf6\xdb\x9e\xdc\xa5\x10\xb8\xfc\xd8\xdc\xa1Xj\x16\xb8L\xe1F85r@N\x8f\x9c\xfe&d\xc5\x16}j\xd5\xc0jDAL\x05\x18\xe7\x8c\nE\x92(4\x9e3\x82\xf4\xe8t\x99\xa5\xb7\xb7D5\x05X\xba)\xf9`\"(E^\x91<5\xc0\xcc\x85\x818z5\x96T\xc2M\xae\x805\x1e\x19\x11p\xcc\x05J\x0b\x17\xf1\xe5\\\x80\xc2\xf0\xf0\xd77]2B\xa3L\x80-3\xb0d\x06\xed\xf7\x0fw\xe9\xff\x8f\xb8\\\xea\xd1_\xd1\x1e4W\xb0\x9a\x1bT\xcc\xd8\xa0\xd6\x8c\x0c\xb5enq\x14\x0d\x08/\xb1T0;\xa4\xc7`\x9c\n\x08F\xf5$	\x19t\xcc\x0cM\x12\"\xb8\xa7\x1a8\xe2\x80\xccw\xe6\xec\x1e\xa0\"vp\x8c\xb0\x1f\x1eu\xf5\xd8\xf3\x8e\xd7\xefZo\x15\xa1\xb4\xc4\xc4\xc0<fb0\xea)`\x16\x07\xc6\x1c\x13\xb3\xac\x0c\xce\x99\x1dT\xc6\xd8\xc0\x96I\xa2\x08,\x05\xb8\xe5\x98\x90e\xac\xa5W\xea@\x16a\x9e\x8d\x99*\xb0f,`\x96+\x82I&\x8c3\xda/0\x01Z\xf4\xa7j& \x8eq\xa8\x87IQ<\xd7\x14\x8d\x1d\xb3\x16PNdP)\xa3 `\xaa\x06\xde2\x15\x07\xa5\xe4q\xe1! #PKX\x83\x14\x8d\xa0w\xa7\xd6\xec\xd4\xa1\xeb\x80>f\x05N\x02$=\x9c&$(\x1av\xab\x89\nT\x1c\x0bTO\x82P1a\x98VA(\x11\x90\xa7\x80T\xc5C\xb0\x02\xd2\x12IL\xa6\x80\xb7\x14\xbd\xe4\xc4`\x9e\xb4\x82\xd8X\x83u\xa2\x81\xe7@rK\xce`\x89[xCV@\x19\x85\x83\x1fn\xc1H\xa4\xa06j\x0b\x02Q\xbc\xd45\x116\xc0z\x88\x87`\x81w\xa5\xc4\x015\x8c\xf1\"0g\x02\xa31c\x110\xcd\xd5\x0e\xb8b\x10\xef#\xa9\x96	)\xa6E\xcd\xc0y\x8cZ\x0bf	\x16e\x94P$/@-\x06\x1fc\xe4\n.\xc7\x12\xdd\xb20P\xc2\x80\xbc9\x88&\x02\x89\xb3\xa1I\xa3\x05\xf1\xba\xba`\x03\xc7l\xe0\x92\x02F\x92\x91\x80iT\x8be\xf6@*\xb6\x12\x7f\x94A-\x91P\xa7D\xd1\xd0\x16\x07\xc2\xa0\xd5!l\xefi\xaf\xcf\x81\xa2\\\xc5_\xadpY9\x05xb\xd2\x1aci\x15\xa4&b\x8e\xfc\xb0\x10\xa8\x8e\x18\xb2\x9b*`\xe8\xabY\xb0\xa7\xf4kF\x91\xac\xd1\xb1Dn\x911\x87\xe8\xa8$i\xd0b\xee\xe8\xa998\xc5\x81^\x92[\xea\x12\xd4J\x88R7\xads\xb3\x00%)\x013	\x953\x04\xc4\x8c\xb5\xa7G\xe1\xfeH\x86\x16},\x08*\xd1I\xa7\x84\x81,\xce\xa8\x1aRF\xd1h\xed\xe3	\xcd\x05\x91L\xd4A(\xc1\"\x16\xa0\x00\xa8I\xbcK4ua*\x91\x06G?\x02	\x89\xc0\x1e\xc0y\x98x\x0c7 j\x1e$l\x18\xe0s\n\x1d\xd2:\xa2\xd4`\x81Jt\x96H\x02T\x84]i\xe2\x9dU0\xb3JP>\x98\x90+\x07\x15(\xe8\x8eX\x80c\x18\xe8\x19[\x08\xd6\\R9\xb6\x139[`\xaf'\x8d\xad\x83\xac\xd7\x1c\xe4\xf2\x0f\x8f$\xe7\xa8K'!L\xc7\xe7\xfe6~\xff\x17\x00\x00\xff\xffPK\x07\x08\xc5\xca\x9a\x86\xb3\x05\x00\x00\x1f\x0b\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xa2\xb6\xb6X\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x12\x00	\x00resources/site.cssUT\x05\x00\x01PwNf<\xcbA\n\xc2@\x0cF\xe1\xfd\x9c\xe2\xbf@\x86n\xa40\x9e\xa6\xd4\xd8	8II#\x16\xc5\xbb\x8btt\xfb\xf1^n\xa2Bs\x9dD\xe9f\x8b\xe1\x95\x00\xa0M;=\xe4\x12\xb5\xe04\xac\xfb\xf9\x8f\x95e\xa9\xf1\xd3wJ\xd9\xb9\x0dc\xdf\xae\xa6A\x9b<\xb9 \x8f\xce\xed(\xe6\xbbo\xe6\xb4\x9ah\xb0\xf7\xf4\xc0\x82\xae\xdf\xf2\x13\x00\x00\xff\xffPK\x07\x08n\xd7r\xa6h\x00\x00\x00\x8c\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00[t\xeeX\xcf\xedA\xf2\xb8\x0e\x00\x00\xe7?\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00index.tmplUT\x05\x00\x01\x0e\xe2\x93fPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xab\x92\xcdX\x9f\x19H\x89\xbc\x02\x00\x00\xb2\x02\x00\x00\x1c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xf9\x0e\x00\x00resources/images/favicon.pngUT\x05\x00\x01\xa28kfPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xab\x92\xcdX\xc5\xca\x9a\x86\xb3\x05\x00\x00\x1f\x0b\x00\x00\x1b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x08\x12\x00\x00resources/images/logo-1.svgUT\x05\x00\x01\xa28kfPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xa2\xb6\xb6Xn\xd7r\xa6h\x00\x00\x00\x8c\x00\x00\x00\x12\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x0d\x18\x00\x00resources/site.cssUT\x05\x00\x01PwNfPK\x05\x06\x00\x00\x00\x00\x04\x00\x04\x00/\x01\x00\x00\xbe\x18\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\xb69Q]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0b\x00	\x00chains.tmplUT\x05\x00\x01) \xd3j\x84\x93\xdfO\xe38\x10\xc7\xdf\xfbW\xf8,\x1e\xee$\x1c\xc3\x05N\x1cJ\xa2C\x14N\x9c\x10\x1c\xa2\x8b\x84V\xfb\xe0:\xd3d\xc0?\"{\xdanA\xfc\xef+\xa7-\xdbR\xa4\x9d\x97\xda\x9d\x99\xcf\xf7k{R\xfc6\xbc=\x1f=\xfe\x7f\xc1Z\xb2\xa6\x1a\x14\xe9\x87\x19\xe5\x9a\x92\x83\xe3\xe9\x0fPu5`\x8c\xb1\xc2\x02)\xa6[\x15\"P\xc9\xbf\x8c.\xc5	\x97\x9b9\xa7,\x94|\x860\xef| \xce\xb4w\x04\x8eJ>\xc7\x9a\xda\xb2\x86\x19j\x10\xfdf\x9f\xa1CBeD\xd4\xca@y\x98\x1d\xec\xb3\xd8\x06t\xcf\x82\xbc\x98 \x95\xce\xbf\xd3	\xc9@\xf5\xfa\xf5\x95e\xfd\x92\xbd}{+d\xbf\xdc\xd5\x1fB\xd4\x01;B\xef6,l7s\xb6Fo4F?\xa1\xb9\n f\x10\xe2'\xddct*,\x1e\x96\xc9m\x8aA\xf7\xcc\xda\x00\x93\x92\xb7D]<\x95R\xd7.{\x8a5\x18\x9c\x85\xcc\x01I\xd7Y9\xf6\x9e\"\x05\xd5\xfds\x9c\x1dd\x7f\xca\x1a#I\x1d\xe3\xcfDf\xd1e:F\xce\x02\x98\x92GZ\x18\x88-\x00q\x86\x8e\xa0	H\x8b\x92\xc7V\xe5'G\xe2\xe2\xe1~tw\x93K\xf5\xd2\x85\x7f\x0f\xcf\x9c\xcd\xef\x86M\xf7\xdf\xf5\x95\xfd\xfbF\xf9\x83\xc7\x97\xc3\x17\xd2w\xa3\xf9e\xec\xea|1\xfc\xeb\xf8\xc1\xb7m7\x9d\x9e\xdf\xda\xeb\xb3\xfb\xa7s\xcet\xf01\xfa\x80\x0d\xba\x92+\xe7\xdd\xc2\xfai\xe4\xab\xcb\xe9\x8f\xb5\xe3cyN\x19 \xfai\xd0\x10eD\x82\xde\xb3\xac\x06\x85\\NL1\xf6\xf5b\x85\xa9q\xc6\xb0.\xb9U\x98\x9e\xc4\xa8\x18K\x9e\x86C\xa1\x83\xc0\xecB\xe4|Y\x9a\xa2h\xf3\x9d\xa7n\xf3\x8d|\xc2\xad \x06#\x89&\xf8i\xc7,\x89\xa3\x0dJ\x8aD	\xca5\xc0\xf64;-Y\xa6[\x85.\xa6\xa7\xdb\xaa+\xd4.O \x81e\x1f\xf6B\xe94V\xac\x16\x13\x03\xdf\x992\xd8\xb8\xbe0\n\x0d\x8e \xac/')\xef\xe9\xacS\xd4&\xb5\x0f\xbe\xd6\xdep\x92\x8a\x8co\xfc\x8e\xa5\x14\x05\xdafm\xcc\xa2C\xd1\xdb\x17}\xbd\x05\x91s\x16\x83~\xd7Zc8S\x86J\x9e\xb6\xefS\xbe\x19\xc9\x1a\xb8\xfas\xc5\xd8)W\x15\xe3j\xc5\xec\x05o\x94]}p\xe3\x8a\xfd\xbe\x99\xb9\xea)\x7f\x14\xb2o\xdb\xbeR\xa9\xaa\xc1\xafd\x0bY\xe3l5#\xcbe!\x97cS\xc8\x96\xac\xa9\x06?\x06\x00PK\x07\x08\xa5\x1eP\xf2]\x02\x00\x00\xa0\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe0:Q]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00index.tmplUT\x05\x00\x01T\"\xd3j\xe4<mv\xe36\x92\xff}\x8a\x1a\xc6\xbb\xb17&i\xb7\xbb3\x19\x85\xd2\xaec\xbb\xd3\xce\xb8m\xa7\xfd1\xe9M\xb2\x0eDB\"l\x12`\x03\xa0lu\xba\xcf\xb0\xef\xed\xdf\xf91oo\xb1\xb7\xda9\xc2\xbe\x02I\x89\x94(\x91\xb2\xdd\x99\xd97\xf8aS@\xa1P\xa8*T\x15\n \xbd\xdf\x1d\x9c\xee_\xbc=;\x84P\xc7Qo\xcd\xc3\x7f\x10\x11>\xecZ\x94[XAI\xd0[\x03\x00\xf0b\xaa	\xf8!\x91\x8a\xea\xaeuy\xf1\xd2\xfe\xcar\xcbm\x9c\xc4\xb4k\x8d\x18\xbdK\x84\xd4\x16\xf8\x82k\xcau\xd7\xbac\x81\x0e\xbb\x01\x1d1\x9f\xda\xe6\xc7\x160\xce4#\x91\xad|\x12\xd1\xee\x8e\xb3\xbd\x05*\x94\x8c\xdf\xdaZ\xd8\x03\xa6\xbb\\L\xb0k\xa6#\xda\xfb\xf5\xc7_\xc11\x8f\xf0\xf1\xe7\x8f\x9ek\x1e\xe7\xc7?\xa0\xca\x97,\xd1L\xf0\x12	\xa6s0mB\x14\x16\x14\x03\x94\xba\xdf\xd2\xf1\x9d\x90\x81*\xf5\x1d\x91H$T:Ll\x81/T,\x94\xad\x82\xdb\xe2y\x0b4\xe5\x01\x951\xe3z\x0b\xcc8~H\x18?!1\xad\x19%\x91\x88K\x8f\xbb\x96\x18v\xf48\xa1\xa5\x81\xfa\x91\xf0oM\xe7\xa5\x9dp\xde\xa5^U\xbe,\xeb\xa9\x98\xa6\xd7\xc8\xa5\xd9\xde\xd8\xd0\x86\xde\x12\x03gQ,\xe7m\x05\x0b\x8b\xc9p\x8e\x84H\x0cE\xab\x8e\x9dTF\x0f\xefL\"\xbd\xc2\xe4\x91W]K\xdf1\xad\xa9\xec\xf8D\x06\xabM\xbb\xda\xbfVp\xadX_P\xf0\x04\xfc/P\xad\"\x84l\x1aJ\x0c\xf4\x1d\x91\xd4\x1eQ\xa9j(\xe83N\xe4\xf8*k\xac\xd2\x101~\x0b\x92F]K\x85Bj?\xd5\xc0|\xc4\x10J:\xc8\xc7\x1e\x90\x11\xf3k;f@\xa1\xd6\x89\xea\xb8\xae\x1fp\xe7F\x054b#\xe9p\xaa]\x9e\xc4n_\x08\xad\xb4$\xc9\xbf\xbdp\xb6\x9dgn\xc0\x94v}\xa5\xa6\x0dN\xcc\xb8\xe3+e\xe5t\xe8qDUH\xa9\xb6\x80qM\x87\x92!wTHv\xbfzn\x1f^\x9d_|\x7f\xb2\xeb\x92\xf7\x89\xfcvg\x8f\xc7\xbb\xdf\x1f\x0c\x93\xef\x8e\x8f\xe2?\x9c\x10\xb1\xfd\xf6\xfd\xce{\xed\x7f\x7fq\xf7R%\xc1\xee\xf8\xe0\xcb\x17W\"\x0c\x934\xdd?\x8d\x8f\xf7\xceo\xf6-\xf0\xa5PJH6d\xbck\x11.\xf88\x16\xa9\xb2\xe6\xf9Q\xa2#\x9b\xa7+\xa9\x12\xa9\xf4\xa9rQ7\x0c\xcd\x05;\x0c4\xa0\xd5\xe8Z\x9a\xde\x9b)ZY\x1b\x96\xcf\xee\x88\xa62&\xf2\xd6\xcem\x16\xfc:i\xc4\x92\x08\xc5\xd0\xfau`\xc0\xeei\xf0u\xa5Q\x8b\xa4\x03\xdb\xd5\xba\x88\x0e\xf4\\\xa5\xb1\xe0\x1d\xd8\xd9\xde\xfe\xa7*tH\xd90\xd4u-\xefm\xc6\x03z\xdf\x01{\xa7\xda%\x11\xc8}i\xd3\x11\xe5Zu\x80\x0bN\xab\x10}\xe2\xdf\x0e\xa5Hy`\x1b\x9d\xed@*\xa3\x0dk\xca%S\xab\\\xd4]{\xc7Q\xa3\xa1\xb5\xb9\x10\x83\xa4	%\xba\x03\xd9\xff\x85`\x8a\xbd\xa7\x1dx\xb1\x9d\xdc\x9b?U8\x91\x10\x9f\xe9q\x07\x9c\xed\xe7\xd3\x96\x8fk\x93\xc7\xcf\"\xc2\x03\xc6\x876\x92\xf4\xf7\"\x81U\x18\xdd\x86\xc5\xc0E\xceM\xf0)b^ \xf0?,d\xdeN-\xf3\x9c\xbe$<8F\x83Q\xe5\x1c\xea\xbb\x1dP_H\x92\xa9\xf0\xbc\xaa\xf8\"\x12\xb2\x03\x8c\x87T2\xbdh\xe0\xdd\xf2\xb8\xf8\xd7s\xcd\xba\xea\xadyn\x16\xeex}\x11\x8c\xf3%\x17\xb0\x11\xb0\xa0k\xcd--\xab\xe7\xb9\x01\x1b\xcd\x80\x95Eo\x81\x1f\x11\xa5\xbaV`#\xad\xf5\x1db\xc2\xf8\x04\x10]\"a\x9cJ\x88\xc7\xf6nie{\xe1n\xaf\xce\xdfxn\xb8;\x85B\x086\x98\xba\xc3I\x83!/\x1f#\xae \xc6\xe2\xb1x8ie\x9c\xd9&\x041\xdak\x81\x92\xfe\x9cs \x91\xeeZYsn\x99\xb0\x94f\x87\x05\x89\xa1<\xa8\xd2\x11>\xef\xcdGH\x9e\x1b>\xaf\xf6\x93\x84\x0f)\xacG\xd0\xe9\xe6\xb0\x18\xd5\x1d3NU\x15\x9fJ\x08/H\x0fl\x13?Yf\x84\xf5\x08\x01=\x17\x01\x1ah*\xf3F\xdb\xcfg\x99\x93\xf4\xf6\x91\x008:\xe8\x94\xa2\xbb#31\xcfM\xe6\xa0\xdf\x9c\xedw\xc0#\xb957=d\xe2_JC\x90\x05\x9a\xc8!\xc6\xcf\xd7\xfd\x88\xf0\x9c\xd8\x12\x80\xe7\x92^\x1d\xd6\xbd\xb3\xa39\xacT\xe9\x06\xb4S\x88Z\xbc\x85\xbe\x0c\xa7\xe3W\xda\xf3\xf9\x7f[3\xa5a\xe3\x9cJ\x10\x0b\x07\x9f\x95E\x99(.\x02z\xae\x89N\xab\"/\x8a\x97\xf4ND@A\x19\x90\xce\\{=*g\x9fh?d|x\x99 \xd6\x8a\xfe\x18\x03sG$g|h\xf5\xfc\x1c\x10\xd2$\xd7\"Cp\xa4h}G\x95\xfa>Eo\xac\xc6\xdc\xa7A\xb9O6\xc9\xadZ\x12#\xa2\xa9\xd2`4\xd7\xd0[&\xf6\xd84\xbe26\x1dG\x05\xa2\x17\xc0|\x83\xfd/XL\x9d\xcb\x8b}\xe7\xa5\x901\xd1`=\xdb\xde\xfe\xd2\xde\xde\xb1\xb7\x9f\xc1\xce\x8b\xce\xf6\xf3\xce\xf6\x0b\xcb\xe0\xb9\xbc\xd8\x9f\xa3fN\xebr\xe9\xef%	\xe4\x11_g\x8e\xc2\xbd$)\x05|s(\xca,k\x92 \xcc\x0b#@3 \xad\x9e\xa6q\"$\x91c\xe0B\x03\x19\x11\x16\x91~Ds\x1e\xb7\xd2\xac\x19\xe341\xeb\xc4\xf7\x85\x0c\x98\xe0\xaf\xcbvxR;k\x0cJ\xb6b\x02c3M\xe3\x19@,^\xb8;\x0f\x8b\x0e\x86J\xcb\xb8\x14|f|x\xccF\xf4\x8cR\xa9jp`\xa9\xf0e\x8a\xa9\x9fj-8\xf8\xa9TB\xda\xb9{\xb7  \x9a\xd8}ek1\x1cF\xb4k\xf9\"\x8aH\xa2h\xa9%\xb7@\x9f\x15MS\x02\x80HFlz\x9f\x10\x1e\xd0\xa0ki\x99\xd2\xbc\x12]\x93\x14\x91\xeaZ\xf3\xdd\xea\xe9\xc6\x82\xa8\xc1\x00\xd5\x82\xe4\x12\x9ck\xab\xba\xb59\xa1\xd5\x10>\xc7\xe8\x02\x06&\x0f*\x14w\xf9d\"\xd2\xa7QD\x83\xfe\xb8F\n\x13F%D\x9a\xdd\xcdg\x13\xa6\x1b%\xa9\x9fmY5p\xa3\x08\xf8\xc7\xc6@b	{r\x03<\xd4\xe0D\x85\x10\xf6E\xca5l\xd7.\x99\xa2\xe0`\xc6\xb6\xccv\xc3\xc5\x8dU\x90`]gF\xeb\x97\xd1l\xac\x9f\xa2\xbe\xe0\x01\x91c\xab\xe7\xb1\x9e\xa4$\xb25\x8b\xa9\xe1\x08\x0c\xa4\x88A\xa4\x12p\xfd{.+\x873u\xc5K$-\xb0\xf7\x85\x0c\xa8\x84\xc4~f\xf5<_\x04t\x86z$\xdcsM\x83\xe7&2O\xeb\xd4\x95\xa5&\xa5f\xe9U\x0c\xc9\xf1\x845\x101\xa5\x81)Xh[\xea4p\x99\x85)\x97\x05\x9c\xa9\xa9\xae\xab*I\xe5\xc9\x8c\xcc^\x10\xc8\xbe\x10\xb7\x0f\xb31\xf9\x12\n\x9e\xc2\xdaL(\x9956\x03\x12\xa9\xc5\xd6\xa6a\x02X\n\x90Oaj&\xc3\xcfs{b`\x8a\x87\xc5Ff\x8a\xe5\xff\x81\x8dIz\x07\xe2\x8eG\x82\x04\xb3\x91g\x9f(zFt\x88\xfa\xef\x069\x90K\xf2\xc997Jp\xabW\xf9Y\x1b\x01\xb67\x16wC\xaa\xc1>\x85\nN(2Bh\x12\x9cP(\x13#\xb5%p-\x1e\xcd\xa0[\x7fu\xfa\xfa0C6\xa4\x9cJ\x12a\x80\xf9J\xc4\x93\xd4\xa8\xeb\x0b>`\xc3\xdf\xc0P\x15\x8a\xf2\x8fb\x9e\xce9IT(\xf4\xc3\xcc\xd3\xe3\x8d\xd2d\xfc\x95\"\xa0\x06\xaa\xb1\x14 \x9f\"\xfc\x99\x0c\xdf\xc2&5D?ST\xbf\xb9arT>\xb6s(\xa5\x90\xcb\xfdzR\xbbZ\n\xea\x81\xf1\x81\xd9\xfa0\xc1\x17\xaf\x9c\x99\xdd\xc2\xeakv\xcec\xcf\x16/\xe9\xbdd\x11\x85,\x8d\x88H\xa7\x93<g\xef\xf3\xf3\xab%t\xe4\xf6\xf72	\x88\xa6\xc1,\x8a\xd7\"\xc0\xad\x1eb\x012\x14\x8d\x98\xf2(sJ\xc3tG\xd9D\xc1\xab<\xa3[%`\xda\xbf\xd5\xd8u\xbb\xfcF\x1a\xcfC\xf2\xec\xc5\x97K\x05\x91s\xe9\xfc\xd5\xde\xb3\x17_v\xc0\xb8\x8a\x8a~\xf4%%E\x8a\xa5\x06\xf1\xd4\x8e/\x97DS\xb4\xd7\xc2cNF/\x00P?\n\x17:K!\xb6\x15.\xa7\xd1q\x16\x04f.~#\xa2|\x8ai\x9fh\x12\x89\xe1&\xec\xb4\xe1\xe3^\x14A\xd1S\xc1\xc6\x12\x97O\x12\xe6N \xad\xdew\xe7\xa7'H\xe7f\xa7Q\x1d\xca\x86B\xe3\x1e\xde\x96T%\x82+6\xa2K\xachQ<\xd3g\"c\xf3\xc3\xfc\xb5U\x9c?d\xc1\x03\x0dZ`\xc3\xe2\xe9\xe9	w\x9b\xe2i\xd9\x0eqQ<\x1d\xf6\xfe\xc8x\xe0\xb9:\\\xbdg\xb6\xd2\x1e\xd6\xb7H[>\xb0\xb7\x88\x13I\x15\xa6v\x1e\x86\x00\x0d\xdd\x03\x87\x96\x14\xcd\xde\x03\x875\xd6\xe0a}\x8b\xc5\xb9Zo\xcfm\xab\x13\x9e\xbb\x82\xb6yzz\n\xd1\xa6\x94\xf2\xe6\xca\xe4\xcdg\xac@\xa3	x\xa4\x9a\x07\xbd\xdc\xcf\xac+\x07\x15\xbe\x08\xc6g~\x16\x19T\xbbdU=W\xe7\x97L\xda\x96\xeapS\x7f\x84H\xe7*\x9ev\xc8u\xe5\x98\x95ut\xf0x<\xd35\xf6h\\\xd3\xb8\xe2\xa1\xbc\\W\xce\xde\xb0\x14U<N&S\xff\xbd\xd4/W!\xddIj\xe8\xe9dV\xf1c\xebj\xe2\x81/\xdf\x1cO\x9d\xef\xba\xaa\xf1\xba\xab0\xa0\xbd\x11X%**\x8a\xe7\xb64\x06\x9ek\xbc\xe0r\xc0\x05\xdb\xbfri\x1d\xf2\x84\xb2t\x02XW\xf0\xc8\xef\x95\xb8\x03-\xf0.\x0f\x1e\x91\xd4\xdc\x91\x9a\xc4\x1d\xd5\xa3\xc0\xbaR\x04\xb3\x8c+M\xa2\xe8\x80\xfa\xf9\"jd\xa6\x97\xf4\x8e\xb2N3\xe1\xec\xcc2\xc4\xac\xa0\xa4\xefR&i\x80\xf7\xd4\xb2.l`\x12\x84c\xaa\x8b*\x1a4\x87;\xcd)\xd0\xday\xb4K\x85\xae$\xa8i6\x07tHK\x0c\x7f\xd4\x14\x8a\xc4L\x95\xa1\xe5\xa54\xd3T\x17\xff\xae6\xdb\x07\xee\x12\xae\xa8d\x83\xb1\x99{\x91\xb1\xa2\x01\x0cX\xc3\x8e\xb0Y\x8a\xd4\x0f\x05\xccD\xf9S\xe3\x07KXc\xc1\x07P\x06R\xa51\xd8>\xd8\x9fD\xee\xe7Z$f\xdeY\xde\xfeQ\xf2Vi @\x8d\x95\xa6\xb1\xaf#P\x88\xba\x9c1\xfb\xc6\xdc\x01\x9b\x9a\xd1\xb6\xd3\xf1\x92\x9e\xd7\xef\x9dI\x8ag.h+$UT{n\xbf\x07\xe3\xe2\xc8\xc1\x81\x8b\x90)\xb8cQ\x04T\x12E\xa7M&k\x81YI\xa7\x850{G\x03\xec	8\x92L9\x1e\xf7\x02\x01\xaf_\x9bZ\x18\x91\x88\x05D\x0b\x89\xb4l\x99~^\xbf\xf7j\xef\xea\x10.N\xcd\x95\x994\x99\x92\xe9\xf5{\xbf$\x92\x8d\xae'\xdd\xaeo\xe9\xd8\xa4\x17\x7fA\x04m\xc8[f1\xfc\xa4M\x96\x12\x99\xe1\xce\x90\x81\xc7\xe4\xd4\x10\xd2\x06\xc3\xc2\xce\xabHt\xd1\xc9R\xbe\x12\x9bx\x05\x84g\xa6*c\xb2\x11\x17}\x97\x92H=\x92\x89*\xa24\x81\x9dm\xf8g\x12'_\x9b?\xe0\x13\xfd\x04\x9c]\x1d\xe1\x930\xba\xf7\x06\x17\x8bY\x03 \x06O\xb5\xd4\x17/\xeb\xd2\x85gH\xb9\"\x03\xb3\x8b\xa7\xdaF\xd7j\xdb\xa1\x88i\x8b\xb9\x83m\xdfR\x9a\xd8\x98\x84\xb7\xf1\xdc`%kQ\xf2\xfbew\x86\xa6\xc3X\x85\xc2 @$|\x93\x0ft\xe0mm=\xa48\x17\xa3\x8dKS\xff\x880\xd3K!A\x89\x98js;$b\xb7\x14tH\xf4\x16\x044\xa1\xe6\xde\x1f\x08>\x11\x02\xb08\x89hL\xb9\xce\xa8x\xa4\xee\xe6w\xc0\x8a\xb9\xef\xc7A\x8d\x8f\xad\xb8\xdf\x0fx\xe3\x08\xec{\xb0\xf7[\x08e%\x19x\xfd\xde\xd1\xeb\xb3\xd37\x17{'\x17\x1d\xc3\x9a\x07[Vc\xe0\xb7P\n}\n*\x9d\xf8\x00-$5\x98\x91\x9f5\x16c\xba\xf82\xd9\xa0?7\xe2@-\x80\x90\x8c2\xf3A\x03H\x93\xc7\xf2>\x1e\xb5`\xe0\xe2\x15\xdd\xa6\xf3r\xcb\xbd\x8ap\x16\x1d\xeb\xb75\xbewD\x15\xfc\x7fl\x98\xfb$\xb6u%\xc5|C\x95&R\x7f\xa2\xa0G\xe6\xd8\x17\x1b\xc8\x15\x89\x8d\xc5\x88\xd6\x05\xa6h\xcc\x06\x92R;M `\xea\x16TB\xfc\xc7NF\xc6`\x8f\x96\x04\xa5+\xd1\xbe\x1fR\xff\x160\xb9\xf4t~\xc7p\xfbF\xa4\x92\x93\x08cL{\x90>\x0d\xabK\xa9\xe7X#C\x96r1\x9fa#\x0c\x16\xaf\xdf\xdb;\xb8\xda;\xd9?<\x807\xa7\x97\x17\x87\xcb.\xb3m\xe06R\xa2	\x8f\xf1\xbd\xa1\x00\x06B\xc2U\xa1\xf2\x86\x83\x9b\x93\x9bm\xfd\x1e\\\x84\x14H\x1fUD\x89(E'R\xecN\x951rZL64F\x89\n\xdf\x90\xefn\xb6 \xe5\xa5*\xa6\x8b\xb8\x8aC@#\xaa3\xcd\xcb\xdeT \x91\xd1;\xa7\xd5\xa4M0>\xa1\x84\xdekIp? \xc9\x90f\x8a\x8an\xd0\xf8bE\xe5\x88\xcavX\xdf\x8a\x14|\xc2107\x84\x0dD\x14\x89;t$\xbe\x88\xfb\x02\xff\xc6f\x02\x02\x94\x96\x94\xc4U\xf7\xcf\xf8\xe2\x00\xc0\xeb7\xe5*\x8a\xe2\xa5\xbd\x97B\x02	F\x04\xefrB\xaa\xf0\xc6\x90\xe0\xd1\xb8\xe3\xb9i3\x8e\xc6\x15\xb0\xb2\xcdi\xd8hU\"\xcf\xbf\xf5\x1e\xc1)\xf8v-E\xaa\xa9\xd3'\xb7e\xfa\x96\xcc\xe2o\x1dW6\x8b\xc5Oe\x04\xb6\x00\x1b\xec\xe3\x16\x99\x0d\xf8\x00\x0b\"6\xfb\x93\x05f\xb9\xe1\xf2X1\x0bc}\xe2T\xe3\x99X\xee\xd2\xe1\x97\x85\x8e\xf6\x17L\xb0pJ\x03L~\x05\"\xc5#6\x1f\xcd\xfc\x16\xf8D\xd2A\x1aq\xcc\xe11\x05\x9c\x8e\xa8\x04I\x83\x94\x07\x84\xeb\xecF`\x83\xe27\xa4\x1e\x1b\x9a\x9b\xb2\x92\x0b\xba\xd7T\xd7U\x95\xfc\xc3\x93\xdd\xed\xc5\x0b\xec\xf4|\xcc\xfd\x05\xde\xa6\xe1b\xcbS\xde\xbb\x9b\x92\xb2\xda\xc5\xbb\xa6)`10\x807\xdf?\xc55\xdf\x12\xe5s</\xa8\x9c\xf0j\xc9\x1d\x97)\x9a\xdf\xee\x92\xcb\xcc\x8d\x95\xc9\xeb\x05\x07\xc2\xa4\x93\xcd\xba\xb3\x91q@`\xb2\x1c\xf3\xdd\x10\xa6\x86)	\xaa@\x834\x8all7~\xdc\x84\x8e8\x1bt\x88\xd5\xfe\x0e|\x93o\xa5\x8a\x04\n\x0f\x8a\x90\xbe1\xf9\xb2\xd4\x83yI\xef4\x95e\xb2\xde\x9c\xed\xe7^\xdeD35	~\xb6<k\xd3\"Q^~\x9d\xa4M\xcc\xd7\xe2\x9a\x0eB\x149G\x02\x92\xa6\n\x0fO {\xa3\xd7\xf0\xc8\xcc\xf1\x1a\xe5\xe3\xa80\xdbb\xde1\x1d\xce\x85&\x01f\x1e[\x0d\xb8\xe8\xbe\xf5RJ\xb1x\xac\x87\xa1 \x8e\x95\xe5@\x07T\xfb!\xe6\x17\x84\xd4\x84Wt\xa9|\x0fjC\xa5~\x08D\xe5\xef\x98d\xefh\x1ae\xd02U\x1aB\xa2\xc2\xcd\xc6\xd1\xb1\x98\x0b\xe0%Q#\x8e\xd4\\Q\x9af?\xb3\x1b\x92\x8e\x16qT\xda\x91\xe7\xcb\x8b\x0f\xa3\xb1\x83~b\xe9p\x0d\x8clj^\xaaI\x9f\xfd\xce\xed3\xee\xf6\x89\n\xd7\xd6\xceO\xf6\xce\xae\xdf\x9c\xed\xcf\xbf\xa5\xb5\xb6v\xbcwqx~q\xfd\xea\xf0\xe8\xdbW\x17\xdd\xf5\x8d\xcc\xf1+X/:\xb9\x19;?\xc0\xcd;\xb0\xa5y\x1b+\x8d\xb4cj\x1d\xb4\xfeT:\x19\xaf7\xbf\x86\x9f\xd6\xbe9>\xdd\xff\xe3\x14\xddF\x05?\xd8\xf0l{{{\xd3@^\xbc\xb9\xc4\x81\xf7\xce_\x95\x86\xb5f\xc6\xfd\xd7\x0cuw\xbd\x8c\xd7\xaa\xa7\xe6\x9a\x05N&\xe45\xdc\x14\xd8\xccDd\xf6!X\xea\xc3\x7flP\x8e:\xff\xe3\x8f\x1d\xb3\xb9\xec\xfc\xfc\xf3\x17\xdd\xf2\x8fM\xe7_\xd6?\xfc\xb4\x83/\x82|\x00$\x0f\xfb\xc8\xc4\xbf\xce\x96\xbbj\xea\xf8\xd3\x94\xf2\xad\xc9\xd3O\xd6\x14\x97Q\xc2\xebl:M\xc8*\xb3\x9dCAT\xd8\x82\x9a){\x7f\xb2>XmB\xe5L\xa5\xf3\x7fF\xb3\xdb\x05c\x0f\xbdx\x9d\x07p\xfbx+\n\x0d!	\x02\xdc\xff,{\xdd\xc36\xaf{\xe4\xdbD-\x8c\xe9J\xf0\xbd,\x85_X\xb86\xad\xd9rd\xbc~\x99\xe2Z\x9el#\x11\xa2r7\xba\xd9'\xb490^\xe9,j\xf9:\xfe\x14\xe7P\x0fK\xa4/\xa7\xf3\xeft\xb3\xe3%\xbd\xc3{\xea\xa7\xf9\xee?sx\x8fp\xd0*\x84\x8a\xa7lM\xc5J\x99\xba\x06\x1a\x9e>K\xb7\xc0\xd5\xd4T\xcfT\x95~\x96\x1f\x07Bh*\x8b\x08\xa0\xf4\xb2w\xdf\xde\x85\xc0\x1eD\xf4\x1eH\xc4\x86\xd9+\x85\xca\xce^\xac\x87\x9bTi6\x18\xdb\xf9\xe76\xf2\xea\xd2\x8e\"\xb74\xf4]\xfe\xf6<XW\x93O\xd6X\x95\xab\x01\x18\x81\xe0\x8d\x98\x9c\x84\xc9\xbb\xf6\x95/qL\xdf\xc0\xc7C\xea\xdeiB\xf1\x95{>\x84\xfe\x18\xa6\x00\xd8\x98\x05C\x7f\xfd\xcb\x9f\xff\xdb\x18\x90\xbf\xfe\xe5\xbf\xfe\xfc\xbf\xff\xf3\x9fx\x17\xb44\xed\xc9qu~\xa5\xe7Q\xc4\x9c\x89;*i0O\xca\xa2AK6\xc9s3\x01\xe4\xd2\xc8\xc3<\xf3\x82\xfb\xea\x1f\x17\xb9)\x7f[\xa4\x9f\xf2 \xa2\xe6\x13#7\xaa\xee\x83\"\xaf\xa5\xff\xa7/\xff\xfd\xf5\xcb\xb7\xd1{\xffx\xef\xab\x93\xe8\x8b\x13}y\xf5r[\xed\xfd\xfe\xb5\xfaA\x9d\xed\\\x8e\xbf\x13\xaf\x93\xe7o\x8f\x0f\xd3\x93\xf3\xc1\xde\xd9\x17\xdf\xf9?pW\xffI\x1f\x91\xfb\xab\x1f^/\xfe\xa0\x88\xe7f\xf3X2)rC\xee\x9d\xa1\x10\xc3\x88\x92\x84)\xc7\x17\xb1\xa9s#\xd6W\xee\xcd\xbb\x94\xca\xb1\xbb\xeb\xfc\xde\xd9\xc9\x7f\x14\x13Y\x80\xbc\xf4\xfd\x91\x1b2\"\x19HI\x1d#\xaa\x8b\x8fd@\x17J\x9fy\xc0\x06\xc6}\xe8b\xe8Y\xfa\xcc\xc7\xfaF \xfc\x14\x0f\xc96\x1dII0\xde\x18\xa4\xdc\xc7\xa4\xdd\xc6\xe6\xccW |\xc1\x95\x86\xfc+\x0b\xc7b(\xa0\x0b\xeb\x1b\x9fW>\xb9\xf1\xf9\xcc\xf7?\xd8\x006\xf2v\xec1\x8b\x12K\xa9\xd9\x91&!\xbf\x8fJ\xb9\xf1y\xf6\xe9\x86Y\x8cSJ\xcc~|D\xa2\x9c\x14E\xf5Q^\xb3\xb1\xb1	\xdd^\xcdX\x05I\x8c\xfbu\xa4\x14\xa5\xe0\xe0\x17\x15\x16\xce\x16\x9c[\x01\xd9\xeb\xe2w?\x96\xe1\xc4\x92	\xc0\xbcl\xf7\xf5\xda\"\xa8\xfa\xcbD\x1f\xb3ky\xcd4\xdb]x\xd6\x8ef\x0f\x9e7\x11</\x9b\x8d\x1aq\x94\x8b\x1fQ\"'r(\x8b\xa8\xa1\xa3\xa4:\x95|U\xae\xac5Q\xed+\xb5\xf1y\xce\x9b\xcf\xb7\n\xc9\xba(\xacyz>n\xc1\xcel\xfdt\x88\x8f\x9b\xc5'K\xf2U\xef\xb9\x98\x81\xe8\xadyn\xa8\xe3\xa8\xf7\x7f\x03\x00PK\x07\x08\x81\xb6W2Z\x11\x00\x00\xd2M\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x0077\xbdZ\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1c\x00	\x00resources/images/favicon.pngUT\x05\x00\x01k\x058h\x00\xb2\x02M\xfd\x89PNG\x0d\n\x1a\n\x00\x00\x00\x0dIHDR\x00\x00\x00 \x00\x00\x00 \x08\x06\x00\x00\x00szz\xf4\x00\x00\x00	pHYs\x00\x00\x0b\x12\x00\x00\x0b\x12\x01\xd2\xdd~\xfc\x00\x00\x02dIDATX\x85\xc5\x97\xc1\xad\xea0\x10E\xcdo\x00:\x08\x1d\x04\x89%\x8b\xb0f\x03\x12\x05@\x07\xd0\x01t\x00\x1d@\x07\xd0\x01P\x01t\x00T\x00\x1d\xf8\xe9D\x0c\xf2\xb3\x1d\xdb\xe4}\x89+E\x81\xd8\x99\xb9\x9e\xb93v\x1aZk\xad\xbe\x88\x7f\xdft\x0ej\x11\xd8n\xb7\xaa\xdf\xef\xabF\xa3\xa1\xda\xed\xb6Z\xaf\xd7\xea\xf9|\xd6c\xa0\x13\xf1x<\xf4b\xb1\xd0\xcdf\x93\x949\x17\xcf'\x93\x89\xbe^\xaf\xa9&KD	`\x10\xc3\xb6\xc3<\xcf\xf5j\xb5\xd2EQ8c\xc3\xe1P\x1f\x0e\x87\xbf\x11\xc0\x00\x86l\xe3\x909\x9f\xcf\x0e\xc9\xd9l\xe6D\x07r1\"\x0e\x01\x8c\xd9\xab\xc20\xe1'\x0d!0\xbe\xd9lt\x96e\xc9D~\x11`\x15\xe6\x8b\x18\xc2`\x1d\xecv;g!\xfc\xaf$@X\xff\x87c\x1b\xac\xdc$bG\xe2M\x80\x01\x99$ \xdf\xbc\xcc\x85\x1e>U\xb8	!a\x13\x08\xf6\x01\xea\xbd\xd3\xe9\xa8\xd3\xe9T\xd695\x1f\xeb\x0d\\\xd3\xe9T\x1d\x8f\xc7\xcf\xfb\x80\x1d\x01\xfd\x12\xa5\x081$B\xcaRJ\xd07\xaf*\x02Q\x02\xfa\x95\n\x9eS\xf7>\x88~B$\x93SP\x14\x85\x13%B\nh\xb9U\xe1\x97y\xadV+-\xf4/$\xed\x05\xe45\xcb2u\xbf\xdf\xbd\xb9\xdd\xef\xf7\xe5}>\x9f\x7f\xe4\x1c$oF\xcb\xe5\xb2\xbc\xcbjM\xe7\x10\xcb\xf3<(\xd2J\x98\xf9\x90V\xea\x03\xb9\x95q\xb3\x1cc\xfa\xb05`\x97\xb2\xf2M\xaa\x828\xa3-\xdb\xa4c=B\xaa\xc4\x86\xb7\x15\xdb\x9b\x8d@\xd4N\xa7\x04tK)\xbd\x18\xaaZ\xf1/\x0d 6p\xb9\\\xbc\x19\xa3)Q%\xe4\x1c-\x88\xf8F\xa3\x913\xf7v\xbb\xbd\x7f\x8bpy\xdf\x81\xc9\x86<\xc7V$\xab\x1e\x0c\x06\x95\xb5O\xa4\xcc\xbd$\x14Y')\x92\xe7P\xd7\xc3i\xaf\xd7{\x9f\x0fl\xe7\x8c\x9b\x9a e\x92\xb6(\x01i\xbd\xa6\xd0l0\xd6\xedv\xdf'#VH~\xc7\xe3q\xe9\x9cK\xc0\xb6\xcc\xbc\xaa\xdd\xd5+yV\x15\xeb\xfd\xa9`\xe5\x90\xac\x82\x97\x80\xd4|\x8a\xbaC R\xa1\xaa\xaa\x8c\x806J\xae\xee\xc1$\xf5\xfd\xe0\xa9X\x14O\x1e?uN\x04\xd1F\x0c\xd1c9\xce1\x96\x1a	q\x9e:?\xe9\xc3DN\xca\xa1\xca\xd0\xaf\x88!\xb8\xd4o\x82d\x02\xa6\x03\x84\xe9\xfb.\xa0rR\x8e\xee\x7f\" \xf0\x11\xa8[\xb2\xdf\xfd<WJ\xfd\x00a\xb4\xef\xdeo\xe4L\xd4\x00\x00\x00\x00IEND\xaeB`\x82\x03\x00PK\x07\x08\x9f\x19H\x89\xb9\x02\x00\x00\xb2\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x0077\xbdZ\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x1b\x00	\x00resources/images/logo-1.svgUT\x05\x00\x01k\x058h\x94U\xdbn\x1b\xc9\x11}\x96\x01\xfd\xc3\x84~\x89\x81\xeeR\xdd\xba\xab\x8a\xb6v\x11o\xb2\x8e\x01\x07\x08\xb0\x80_\x0dy\xc4\x95\x08\x8f)\x81\xa4.N\x90\x7f\x0fj(e-\xec\xea!\x044\xaa\xe9\xee9u;\xa7\xfa\xcd\x8f\xf7_\xa7\xe1v\xb5\xdd\xad\xaf6\xa7\x0b\x02\\\x0c\xab\xcdxu\xbe\xde\\\x9c.n\xf6\xbfV_\xfc\xf8\xc3\xf1\x8b7\x7f\xaaux\xb7\xda\xac\xb6g\xfb\xab\xedr\xf8\xcb\xf9\xd5\xe7\xd5\xf0~\x9anv\xfbyi`\x03\x01*\xc3/\x1f\xdf\x0d\x7f\xbb\xbf\xbe\xda\xee\x87\x7fN7\x17\xf5\xfdf\x80y\xf1\xe3\xc1\xc9r\xe8\x808\xbc\xbdYO\xe7\x03\xbe\x1a\x86Z\x13\x7fw{\xf1}\x18\xb4\x18\xd6\xe7\xa7\x8b\x0fg\xdfV\xdbO\xb4\x18\xee\xbfN\x9b\xdd\xe9\xe2r\xbf\xbf^\x9e\x9c\xdc\xdd\xdd\xc1\x9d\xc0\xd5\xf6\xe2\x84\x11\xf1dw{\xf1pdy?\xad7_\xfe\xe8 E\xc4\xc9\xbc\xbb\x18\xeeO\x17x}\xbf\x18\xbe\x1d\xfe\x1f\xbf8\x1an\xd7\xab\xbb\xb7W\xb91\xe0@\xe8\x87\xc7b\xd8\xed\xbfM\xab\xd3\xc5js\xf6yZ\xd5\xcfg\xe3\x97\x8b\xed\xd5\xcd\xe6|\xb9Y\xdd\x0dO\xce\xbe\x9eCX\xee\xae\xcf\xc6\xd5\xe9\xe2z\xbb\xda\xad\xb6\xb7\xab\xc5\x9c\\\x82\x0c\xfbo\xd7\xab\xd3\xc5~u\xbf?\x19w\xbb\xdc8\x82\xdd\x1e\xff\xfd\xebz\x9a\x96/\x7f\x9e\x7f\xaf\xffsX\xa5\xc3\xea\xcdv\xfa\xf3\xcb_>\xbe{\xff\xd7O\xf4\xe9\xd5\xeb\xdd~{\xf5e\xb5|\xf9\xb3\xbdu\xd3\x87\xd7\xfau\xbd_m\xa7\xf5\xd7\xf5~I8\x03\x1c\xbf8J\x10\xfe\x1d\x08\xce\xbf\x1e\xe2\x12\xd6\xc4\xc3T\x95\xc3-\x97\x91\x9a\xa2P\xd3.\xda\x82;wl\xedY\xafw\xeb\xf3\xfd\xe5\x12\xc1\xda\xf3q\xc0n/\xcf\xc4@B\x16\x88\x86\xc4.\xec\xc64/#FGQffR2'	\xb5\xff#s\xd8\xed\xf5\xb9\xac\xd1X\x1d\x11\xdb\xec\xaa\xb5\xe6\x8f\xa1\x18q'\xea\xda\xbb\xb4 '\xfe\xf4\xea\x11\xad\xfd1\x1a\x91Kpt5a\x12\x0c\x12\xe1\x87\x04z7\x15\xf6\x86\xdd=\x82\x95\xe37\xb4\xfe\\l\xde\xa5\x89H7\xd1\xde\x99\x91\xfd\xc1\x8dbH\xb3\xdeU\x9dIX\xcc\x0fhoNfR%\xbb.\x92Io\xae\xcf\xf6\x97\xc3\xf9\xe9\xe2\x1f]\x19\x8c\x8a\xb6\x06\xe2Su\x06\xb7\xc2\x82\xc06V\x02\xe9\xa5Cx%0+\xd4\xa1\xf7\xa2\xe0\xbd0\x01\xe9\xa5\x11`\xbbU \xfa{\xc3\x0e\x11\x13\x81ZU \x9a\x04\xc8\xab\x83\xfb\x87N\x06V\x14	\xb4\x1f\xbf8:\x9a\x04\x84k\x80\xe8et\x10\xbf\x15`\xfa\xc9\xc8@\xa9H4\x10)\xdd\x14D\x8b\x04\x03qy\x12\xe4\xbf\x16'OS0j\x10\\:\x1b4\x9e*\x05\x10\x15\x0b`\x9b*Bx\xc9x.k\x02\xc7m\x9d_\x94!\xf4\xa7\x1e\x01\xe8\xa5{\xf2\xb2$\x8cJ\xe9\x1c@R\x9e\x80\xfe\xcee\xa3\x9eu\xea\xcd\xc1[z!*\x08BS%\x03\xd4\xa2\x06]\xa6J\xa0\xb3w\xbe\xac:\x97\x17\x818\x0f\xealj=\x98\x04\xc6\x19W\x06\xcf\x1d\xa8\n\n\xb0\xa4.G,\x980\xb5\x01\xb5|T\"\xa0\xb1\"`O\x88\xfc\x93\x8a\xc0iD\xe2\xcd\x9b\xa1\x95\xa0se\xa0^\x05D\xab\x80\xcd\xdf\xcf\xdb\xc8\xff{\xe4\xc7\xd8\x1e\xf1\xd0f\xa7\xb5\x81\xb7\xda\xa1Q\xa5\x0e\xadU\x12h\xbdJ\xcf\xa3\xe4\xc0tI\xd8\xc0\xfd\x10\x9e\x80KAP\xca\xecY\x0b	\xd8X\x05\xba\x16\"\x88(-y$I\x99\xef\xcc)\"I\xc5\x12\x10\x94n?<\xa9\xeac\xcdg\xbe~GXo\x04\xd8\x8b\xb0\x80\xc8XY\xc09\xe3s\xaa\x1a T\x93\x06^M \xa4J\x80\xe9\x98\x1b\xd4+k&A\x88 \xbdf\x87\xbcR\xc39\xd3\x00\xf6\x0c\xe1h\xac\xdc@\xac\x12\x82{m\x04\xae\x95)\xcf\xd8\xfc\x810\x90g}\x9aU\x06\x96l\x87E\x9a\x9c\xc9K+Y\xd8\xb1\x1a\x82Ia\x87\xc6\x95\x94\x80Js\x88^\x19\x03\x8cK\xe4\x07\x0f\x0e\x85\x80{\xa1\x06\xc2\xb53\xcc\x85j\xad\x06\xcf\xd4\x0d\xa0\x18\xab\x81\x14\x05\xd6\xd9\x9d\x15b@K\xbb\xb7\xc2\x08\x8dF\x84\x16E	\x1a\x15J\xd3\x1b(\x17\x06\x8e\x92\x94js\x81\x8fF\x04\xed\x855;\x83\x10\xbdd-\xa5\x08x\x14k\xa0>6\x90\x99\xbdD%\x99\xdcK\x08x\x91\x9eh$\x06\xa4\xa3J\xea#<\x15Il`\x85\xac\xa7\x808/u+L\x1d\xa8\x1d\xfc\x11x\xf2\xdd\xb8\x08\xa0T\xca\xf6\x12\x88T\x06\xe7\xb1\x12*\xb8\xd5\xe6\x07^	h\xcc-i^\x998\xbb\xc5\xdd!d\xcc\\\x91\xaa\xa6\x8a*iN\xa4@\xe0\x9e\x8d\xcf6J\x83\xd0\xc7\x14\xc3\xab\np\xa1\xa4\xbc\x07\xa8\x15\x06\xcd\xb3\x92\xcd\xf3\x14\x9ef\x9e\xd4!\xa8:\x84\x96\xa4\x91Vb\x10\x1e\xcdsY\"IK\x1d\xf3\xc5\x04\xcc\x0b+\xcf\x92@\xcb\xd9\x12\xc0\x94\xb2:\xb8\x9dk:\xe7\x17\xc0\x99\xae\xd1oVB6)I\x9e\xec\xb4e[z\x03m\x85E2>B\x06\xb3\x91r\xec\x96\x06\x94\xf3\xd5=\xd5\x93\xed\xc9be\xb0\xfe@\x99$pF,9tL\x8bv\xe8\xd9w\x8a\xd2\x03\x82\xf3\xc0\x9cRx\x99GP\xc7\x1cJ\xb3\xe9\xb36\x11\xb8(&\xcd4\xa7\x9c\x13\x10Ujsx\x9c\xf0\x8fb\xe8YG$0\xcdJ\x06\x17JfI%\xb3\x1ce\x9c\x85\xce\x8b\"\x01\x8a\x81j\xe5\xacQ!M\x15\x89\x02'A]\xf3.\xb1\xe4\\\xce<\xf5\"Y\x8fdBa\xf0\x07r\x1e:\x9e\xcdM\x8az\xa4\x08;%\xf9\x82s\x0eY\x1bI[\xaa\xc04k\xcc\xacI*N\x0ed\xba\x98\x14\x11\xd3\x94|*\xa16I)p\xca\x9d\x08A\xb2-\x14\x95z\x0e\xac	\x0b>\x96\x93\xa4zro\x0e\x9a\xf2NJ\xc2\x99\xd6\x14W|x2r\x1e\xe7\xd2I\x0e\xa6\xc7\xe7\xee\xf6\xe2\x87\xe3\x17\xff\x1d\x00PK\x07\x08\xc5\xca\x9a\x86\xbb\x05\x00\x00\x1f\x0b\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x0077\xbdZ\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x12\x00	\x00resources/site.cssUT\x05\x00\x01k\x058h<\xcbA\n\x83@\x0c@\xd1}N\x91\x0bdpS\x84\xf14bS'\xd0I\x86\x98Ri\xf1\xee]8u\xfb\xf8?UQ\xa1\xa5\xcc\xa2\xf4\xb4\xd5\xf0\x0b\x88\x88u\xde\xe9-\xf7(\x19oC\xdb\xa7\x0b\x0b\xcbZ\xe2\xaf\x07@r\xae\xc3\xd8\xb7\x87i\xd0&\x1f\xce\x98F\xe7z\x16\xcb\xcb7sj&\x1a\xec==1c3\xd1`\x9f\xe0\x80\xdf\x00PK\x07\x08n\xd7r\xa6h\x00\x00\x00\x8c\x00\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xb69Q]\xa5\x1eP\xf2]\x02\x00\x00\xa0\x04\x00\x00\x0b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00chains.tmplUT\x05\x00\x01) \xd3jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe0:Q]\x81\xb6W2Z\x11\x00\x00\xd2M\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81\x9f\x02\x00\x00index.tmplUT\x05\x00\x01T\"\xd3jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x0077\xbdZ\x9f\x19H\x89\xb9\x02\x00\x00\xb2\x02\x00\x00\x1c\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81:\x14\x00\x00resources/images/favicon.pngUT\x05\x00\x01k\x058hPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x0077\xbdZ\xc5\xca\x9a\x86\xbb\x05\x00\x00\x1f\x0b\x00\x00\x1b\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81F\x17\x00\x00resources/images/logo-1.svgUT\x05\x00\x01k\x058hPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x0077\xbdZn\xd7r\xa6h\x00\x00\x00\x8c\x00\x00\x00\x12\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xb4\x81S\x1d\x00\x00resources/site.cssUT\x05\x00\x01k\x058hPK\x05\x06\x00\x00\x00\x00\x05\x00\x05\x00q\x01\x00\x00\x04\x1e\x00\x00\x00\x00"
 		fs.Register(data)
 	}
 	
\ No newline at end of file
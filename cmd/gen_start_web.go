@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/services/snapshot_archive"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
 	"github.com/bcdevtools/node-management/validation"
@@ -10,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"os/user"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -121,8 +123,10 @@ func GetGenStartWebCmd() *cobra.Command {
 				utils.ExitWithErrorMsg("ERR: snapshot file path must be absolute")
 				return
 			}
-			if !strings.HasSuffix(snapshotFilePath, ".tar.lz4") {
-				utils.ExitWithErrorMsg("ERR: snapshot file path must be a .tar.lz4 file")
+			if !slices.ContainsFunc(snapshot_archive.AllFormats, func(format snapshot_archive.Format) bool {
+				return strings.HasSuffix(snapshotFilePath, format.FileExtension())
+			}) {
+				utils.ExitWithErrorMsg("ERR: snapshot file path must be a .tar.lz4, .tar.zst or .tar.gz file")
 				return
 			}
 			for {
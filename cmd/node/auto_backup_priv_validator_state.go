@@ -1,17 +1,26 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/services/alert"
+	"github.com/bcdevtools/node-management/services/node_process"
+	"github.com/bcdevtools/node-management/services/pvs_homes"
+	"github.com/bcdevtools/node-management/services/pvs_watcher"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
+	"github.com/bcdevtools/node-management/validation"
+	"github.com/pkg/errors"
 	"github.com/shirou/gopsutil/v3/process"
 	"github.com/spf13/cobra"
 	"os"
 	"os/exec"
 	"os/user"
 	"path"
+	"slices"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -19,6 +28,12 @@ const (
 	flagKeep                   = "keep"
 	flagBinaryKillByAutoBackup = "binary"
 	flagGenSetup               = "gen-setup"
+	flagStateFile              = "state-file"
+	flagPollInterval           = "poll-interval"
+	flagPidFile                = "pid-file"
+	flagDryRun                 = "dry-run"
+	flagAlertConfig            = "alert-config"
+	flagHomesConfig            = "config"
 )
 
 const (
@@ -28,6 +43,7 @@ const (
 const (
 	backupPrivValStateJsonPrefixFileName = "priv_validator_state"
 	latestBackupPrivValStateJsonFileName = backupPrivValStateJsonPrefixFileName + "_latest.json"
+	fatalReportFileNamePrefix            = "FATAL_REPORT_MISMATCH_PRIV_VALIDATOR_STATE"
 )
 
 func GetAutoBackupPrivValidatorStateCmd() *cobra.Command {
@@ -35,38 +51,108 @@ func GetAutoBackupPrivValidatorStateCmd() *cobra.Command {
 		Use:     commandAutoBackupPrivValidatorState + " [node_home]",
 		Aliases: []string{"auto-backup-pvs", "auto-backup-priv-validator-state"},
 		Short:   "Designed to be run as a service, it will automatically backup the `priv_validator_state.json`, and kill the node process if the content of the file is decreased",
-		Args:    cobra.ExactArgs(1),
+		Long: fmt.Sprintf(`Designed to be run as a service, it will automatically backup the priv_validator_state.json, and kill the node process if the content of the file is decreased.
+Multiple node homes can be protected by a single process via --%s, each home has its own backup directory, keep-recent and process targeting rule.
+A failure of a home is retried without affecting the protection of the others.`, flagHomesConfig),
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			utils.MustNotUserRoot()
 
-			nodeHomeDirectory := strings.TrimSuffix(strings.TrimSpace(args[0]), "/")
-			validateNodeHomeDirectory(nodeHomeDirectory)
-			if !strings.Contains(nodeHomeDirectory, "/") {
-				utils.ExitWithErrorMsg("ERR: node home directory must be absolute path, eg: /home/user/.nodeHome")
-				return
-			}
-
 			currentUser, err := user.Current()
 			if err != nil {
 				utils.ExitWithErrorMsg("ERR: failed to get current user:", err)
 				return
 			}
 			userHomeDir := currentUser.HomeDir
+			defaultBackupDstPath := path.Join(userHomeDir, fmt.Sprintf(".backup_priv_validator_state_%s", constants.BINARY_NAME))
 
-			if !strings.HasPrefix(nodeHomeDirectory, userHomeDir) {
-				utils.ExitWithErrorMsg("ERR: node home directory must be under user home directory:", userHomeDir)
+			pollInterval, _ := cmd.Flags().GetDuration(flagPollInterval)
+			if pollInterval < 50*time.Millisecond {
+				utils.ExitWithErrorMsg("ERR: --" + flagPollInterval + " must be at least 50ms")
 				return
 			}
+			dryRun, _ := cmd.Flags().GetBool(flagDryRun)
 
-			backupDstPath := path.Join(userHomeDir, fmt.Sprintf(".backup_priv_validator_state_%s", constants.BINARY_NAME))
-			createBackupDirIfNotExists(backupDstPath)
-			fmt.Println("INF: backup directory:", backupDstPath)
+			var notifier *alert.Notifier
+			alertConfigFilePath, _ := cmd.Flags().GetString(flagAlertConfig)
+			if alertConfigFilePath != "" {
+				alertConfig, err := alert.LoadConfig(alertConfigFilePath)
+				if err != nil {
+					utils.ExitWithErrorMsg("ERR: failed to load alert config:", err)
+					return
+				}
+				notifier, err = alert.NewNotifier(alertConfig)
+				if err != nil {
+					utils.ExitWithErrorMsg("ERR: invalid alert config:", err)
+					return
+				}
+				fmt.Println("INF: alert sinks:", notifier.Describe())
+			}
+
+			var extraFlags []string
+			if alertConfigFilePath != "" {
+				extraFlags = append(extraFlags, "--"+flagAlertConfig, alertConfigFilePath)
+			}
+
+			homesConfigFilePath, _ := cmd.Flags().GetString(flagHomesConfig)
+			if homesConfigFilePath != "" {
+				if len(args) > 0 {
+					utils.ExitWithErrorMsgf("ERR: node home can not be used together with --%s flag\n", flagHomesConfig)
+					return
+				}
+				for _, flag := range []string{flagBinaryKillByAutoBackup, flagKeep, flagStateFile, flagServiceName, flagPidFile} {
+					if cmd.Flags().Changed(flag) {
+						utils.ExitWithErrorMsgf("ERR: --%s flag can not be used together with --%s flag, configure it per home in the config file\n", flag, flagHomesConfig)
+						return
+					}
+				}
+
+				homesConfig, err := pvs_homes.LoadConfig(homesConfigFilePath, defaultBackupDstPath)
+				if err != nil {
+					utils.ExitWithErrorMsg("ERR: failed to load homes config:", err)
+					return
+				}
+
+				if cmd.Flags().Changed(flagGenSetup) {
+					genSetupThenExit(
+						fmt.Sprintf("%d node homes in %s", len(homesConfig.Homes), homesConfigFilePath),
+						strings.Join(append([]string{"--" + flagHomesConfig, homesConfigFilePath}, extraFlags...), " "),
+						currentUser,
+					)
+					return
+				}
+
+				if dryRun {
+					fmt.Println("WARN: dry-run, the node processes will not be killed")
+				}
+
+				var guards []*pvsGuard
+				for _, homeConfig := range homesConfig.Homes {
+					reportFilePath := path.Join(userHomeDir, fmt.Sprintf("%s_%s.txt", fatalReportFileNamePrefix, homeConfig.Name))
+					guards = append(guards, newPvsGuard(homeConfig, fmt.Sprintf(" [%s]", homeConfig.Name), reportFilePath, pollInterval, dryRun, notifier))
+				}
+				// sending is slow when the sinks are down, never delay the protection
+				go notifier.KeepFlushingSpool(context.Background())
+
+				runPvsGuards(guards)
+				return
+			}
+
+			if len(args) != 1 {
+				utils.ExitWithErrorMsgf("ERR: require node home or --%s flag\n", flagHomesConfig)
+				return
+			}
+
+			nodeHomeDirectory := strings.TrimSuffix(strings.TrimSpace(args[0]), "/")
+			if !strings.HasPrefix(nodeHomeDirectory, "/") {
+				utils.ExitWithErrorMsg("ERR: node home directory must be absolute path, eg: /home/user/.nodeHome")
+				return
+			}
 
 			keepRecent, _ := cmd.Flags().GetInt(flagKeep)
-			if keepRecent < 3 {
-				keepRecent = 3
+			if keepRecent < pvs_homes.MinKeepRecent {
+				keepRecent = pvs_homes.MinKeepRecent
 			}
-			fmt.Println("INF: keep backup of the last", keepRecent, "blocks")
 
 			binaryPathToKill, _ := cmd.Flags().GetString(flagBinaryKillByAutoBackup)
 			if binaryPathToKill == "" {
@@ -77,199 +163,484 @@ func GetAutoBackupPrivValidatorStateCmd() *cobra.Command {
 				utils.ExitWithErrorMsg("ERR: binary name must be absolute path, eg: /home/user/go/bin/" + binaryPathToKill)
 				return
 			}
-			_, exists, isDir, err := utils.FileInfo(binaryPathToKill)
-			if err != nil {
-				utils.ExitWithErrorMsg("ERR: failed to check binary file:", err)
+
+			stateFilePath, _ := cmd.Flags().GetString(flagStateFile)
+			if stateFilePath != "" && !strings.HasPrefix(stateFilePath, "/") {
+				utils.ExitWithErrorMsg("ERR: state file must be absolute path, eg: /home/user/.horcrux/state/chain-id_priv_validator_state.json")
 				return
 			}
-			if !exists {
-				utils.ExitWithErrorMsg("ERR: binary file does not exist:", binaryPathToKill)
+
+			serviceName, _ := cmd.Flags().GetString(flagServiceName)
+			pidFilePath, _ := cmd.Flags().GetString(flagPidFile)
+			if serviceName != "" && pidFilePath != "" {
+				utils.ExitWithErrorMsgf("ERR: only one of --%s and --%s can be used\n", flagServiceName, flagPidFile)
 				return
 			}
-			if isDir {
-				utils.ExitWithErrorMsg("ERR: specify binary path is a directory:", binaryPathToKill)
+			if pidFilePath != "" && !strings.HasPrefix(pidFilePath, "/") {
+				utils.ExitWithErrorMsg("ERR: pidfile must be absolute path")
 				return
 			}
-			_, binaryNameToKill := path.Split(binaryPathToKill)
-			fmt.Println("INF: binary to kill:", binaryNameToKill, "at", binaryPathToKill)
 
-			if cmd.Flags().Changed(flagGenSetup) {
-				genSetupThenExit(nodeHomeDirectory, binaryPathToKill, keepRecent, currentUser)
+			guard := newPvsGuard(pvs_homes.HomeConfig{
+				Home:        nodeHomeDirectory,
+				Binary:      binaryPathToKill,
+				ServiceName: serviceName,
+				PidFile:     pidFilePath,
+				StateFile:   stateFilePath,
+				BackupDir:   defaultBackupDstPath,
+				Keep:        keepRecent,
+			}, "", path.Join(userHomeDir, fatalReportFileNamePrefix+".txt"), pollInterval, dryRun, notifier)
+			if err := guard.prepare(); err != nil {
+				utils.ExitWithErrorMsg("ERR:", err)
 				return
 			}
+			if dryRun {
+				fmt.Println("WARN: dry-run, the node process will not be killed")
+			}
 
-			privValStateJsonFilePath := path.Join(nodeHomeDirectory, "data", "priv_validator_state.json")
-			fmt.Println("INF: priv_validator_state.json file path:", privValStateJsonFilePath)
+			if cmd.Flags().Changed(flagGenSetup) {
+				if stateFilePath != "" {
+					extraFlags = append(extraFlags, "--"+flagStateFile, stateFilePath)
+				}
+				if serviceName != "" {
+					extraFlags = append(extraFlags, "--"+flagServiceName, serviceName)
+				}
+				if pidFilePath != "" {
+					extraFlags = append(extraFlags, "--"+flagPidFile, pidFilePath)
+				}
 
-			latestBackupPvs := loadLatestBackupPrivValidatorStateOrExitWithErr(backupDstPath)
-			fmt.Println("INF: latest state from backup:")
-			fmt.Println(latestBackupPvs.Json())
+				fmt.Println("Input chain name (eg: Cosmos Hub):")
+				chainName := utils.ReadText(false)
+				fmt.Println("Mainnet or Testnet?")
+				networkType := utils.ReadText(false)
+				fmt.Println()
+
+				genSetupThenExit(
+					fmt.Sprintf("Validator on %s %s", chainName, networkType),
+					strings.Join(append([]string{
+						nodeHomeDirectory,
+						"--" + flagBinaryKillByAutoBackup, binaryPathToKill,
+						"--" + flagKeep, fmt.Sprintf("%d", keepRecent),
+					}, extraFlags...), " "),
+					currentUser,
+				)
+				return
+			}
 
-			const interval = 200 * time.Millisecond
-			var lastExecution time.Time
+			// sending is slow when the sinks are down, never delay the protection
+			go notifier.KeepFlushingSpool(context.Background())
 
-			type backupPerHeight struct {
-				heightStr string
-				files     []string
+			if err := guard.run(); err != nil {
+				utils.ExitWithErrorMsg("ERR:", err)
+				return
 			}
-			backupFilesByHeight := make([]*backupPerHeight, 0)
+		},
+	}
 
+	cmd.Flags().Int(flagKeep, pvs_homes.MinKeepRecent, "Keep backup of the last N blocks")
+	cmd.Flags().String(flagBinaryKillByAutoBackup, "", "Absolute path of the chain binary to be killed by process when priv_validator_state.json has problem")
+	cmd.Flags().Bool(flagGenSetup, false, "Display guide to setup instead of running business logic")
+	cmd.Flags().String(flagStateFile, "", "Absolute path of the state file to watch instead of data/priv_validator_state.json, eg: sign-state file of the remote signer horcrux ~/.horcrux/state/<chain-id>_priv_validator_state.json")
+	cmd.Flags().Duration(flagPollInterval, 2*time.Second, "Interval of polling the state file, as safety net of inotify or fallback when inotify is not available")
+	cmd.Flags().String(flagServiceName, "", "Systemd service name of the node, the MainPID of the service is killed. Default is to kill the process of the binary started with the node home")
+	cmd.Flags().String(flagPidFile, "", "Absolute path of the pidfile of the node, the PID in the file is killed")
+	cmd.Flags().Bool(flagDryRun, false, "Only print which processes would be killed and why, without killing")
+	cmd.Flags().String(flagAlertConfig, "", "Alert config file (TOML), to notify fatal, restore-snapshot and no-process-to-kill events via webhook, Telegram, Slack and Discord")
+	cmd.Flags().String(flagHomesConfig, "", "Config file (TOML) of multiple node homes to be protected, instead of the node home and flags of a single home")
+
+	return cmd
+}
+
+// pvsGuard protects a node home: backups the state file and kills the node process when the state is decreased.
+type pvsGuard struct {
+	home           string
+	stateFilePath  string
+	backupDstPath  string
+	keepRecent     int
+	reportFilePath string
+	pollInterval   time.Duration
+	killer         nodeKiller
+	notifier       *alert.Notifier
+	// logTag identifies the home in log lines in multi-home mode, eg: " [gaia-mainnet]"
+	logTag string
+}
+
+func newPvsGuard(homeConfig pvs_homes.HomeConfig, logTag, reportFilePath string, pollInterval time.Duration, dryRun bool, notifier *alert.Notifier) *pvsGuard {
+	return &pvsGuard{
+		home:           homeConfig.Home,
+		stateFilePath:  homeConfig.StateFilePath(),
+		backupDstPath:  homeConfig.BackupDir,
+		keepRecent:     homeConfig.Keep,
+		reportFilePath: reportFilePath,
+		pollInterval:   pollInterval,
+		killer: nodeKiller{
+			target: node_process.Target{
+				SystemdUnit: homeConfig.ServiceName,
+				PidFile:     homeConfig.PidFile,
+				BinaryPath:  homeConfig.Binary,
+				Home:        homeConfig.Home,
+			},
+			dryRun:   dryRun,
+			notifier: notifier,
+			logTag:   logTag,
+		},
+		notifier: notifier,
+		logTag:   logTag,
+	}
+}
+
+// prepare validates the node home, binary and state file, then creates the backup directory.
+func (g *pvsGuard) prepare() error {
+	if err := validation.PossibleNodeHome(g.home); err != nil {
+		return errors.Wrap(err, "invalid node home directory")
+	}
+
+	if binaryPath := g.killer.target.BinaryPath; binaryPath != "" {
+		if err := requireExistingFile(binaryPath, "binary"); err != nil {
+			return err
+		}
+	}
+	if g.stateFilePath != path.Join(g.home, "data", "priv_validator_state.json") {
+		// custom state file, eg: remote signer
+		if err := requireExistingFile(g.stateFilePath, "state"); err != nil {
+			return err
+		}
+	}
+
+	if err := g.killer.target.Validate(); err != nil {
+		return errors.Wrap(err, "invalid node process target")
+	}
+
+	if err := createBackupDirIfNotExists(g.backupDstPath); err != nil {
+		return err
+	}
+
+	fmt.Println("INF:"+g.logTag, "node home:", g.home)
+	fmt.Println("INF:"+g.logTag, "backup directory:", g.backupDstPath)
+	fmt.Println("INF:"+g.logTag, "keep backup of the last", g.keepRecent, "blocks")
+	fmt.Println("INF:"+g.logTag, "node process to kill:", g.killer.target.Describe())
+	return nil
+}
+
+func requireExistingFile(filePath, fileType string) error {
+	_, exists, isDir, err := utils.FileInfo(filePath)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check %s file", fileType)
+	}
+	if !exists {
+		return fmt.Errorf("%s file does not exist: %s", fileType, filePath)
+	}
+	if isDir {
+		return fmt.Errorf("specify %s file is a directory: %s", fileType, filePath)
+	}
+	return nil
+}
+
+// runPvsGuards runs the guard of every home independently and never returns.
+// A home failed to be prepared or stopped watching is retried after a delay, without affecting the others.
+func runPvsGuards(guards []*pvsGuard) {
+	const retryDelay = 10 * time.Second
+
+	var wg sync.WaitGroup
+	for _, guard := range guards {
+		wg.Add(1)
+		go func(g *pvsGuard) {
+			defer wg.Done()
 			for {
-				if time.Since(lastExecution) < interval {
-					time.Sleep(20 * time.Millisecond)
-					continue
-				}
+				err := g.prepareAndRunRecovered()
+				utils.PrintlnStdErr("ERR:"+g.logTag, "home", g.home, "is not protected:", err)
+				utils.PrintlnStdErr("ERR:"+g.logTag, "retry in", retryDelay)
+				time.Sleep(retryDelay)
+			}
+		}(guard)
+	}
+	wg.Wait()
+}
 
-				lastExecution = time.Now().UTC()
-
-				// Remove old backups
-				if numberOfBackupHeights := len(backupFilesByHeight); numberOfBackupHeights > keepRecent {
-					pruneSize := numberOfBackupHeights - keepRecent
-					for _, backupPerHeight := range backupFilesByHeight[:pruneSize] {
-						for _, file := range backupPerHeight.files {
-							if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
-								utils.PrintlnStdErr("ERR: failed to remove backup file", file, ":", err)
-								// ignore error
-							}
-						}
-					}
-					backupFilesByHeight = backupFilesByHeight[pruneSize:]
-				}
+// prepareAndRunRecovered converts panic into error, so a home can not crash the process protecting the others.
+func (g *pvsGuard) prepareAndRunRecovered() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if err := g.prepare(); err != nil {
+		return err
+	}
+	return g.run()
+}
+
+// run watches the state file, backups the recent state and kills the node process when the state is decreased.
+// It only returns when failed to start watching, the fatal case keeps killing the node process forever.
+func (g *pvsGuard) run() error {
+	// print the currently targeted processes, to verify targeting before anything goes wrong
+	candidates, err := g.killer.target.Find()
+	if err != nil {
+		utils.PrintlnStdErr("WARN:"+g.logTag, "failed to find node process:", err)
+	} else if len(candidates) == 0 {
+		fmt.Println("WARN:"+g.logTag, "node process is not found, is the node running?")
+	}
+	for _, candidate := range candidates {
+		if candidate.Matched {
+			fmt.Printf("INF:%s targeted PID %d: %s\n", g.logTag, candidate.Pid, candidate.Reason)
+		} else {
+			fmt.Printf("INF:%s skip PID %d: %s\n", g.logTag, candidate.Pid, candidate.Reason)
+		}
+	}
+
+	if !slices.ContainsFunc(candidates, func(candidate node_process.Candidate) bool { return candidate.Matched }) {
+		// eg: node run by cosmovisor from cosmovisor/upgrades/<name>/bin while the binary is ~/go/bin/<binary>,
+		// it would never be killed on fatal events
+		sameNameNodes, err := g.killer.target.FindSameNameNodes()
+		if err != nil {
+			utils.PrintlnStdErr("WARN:"+g.logTag, "failed to find node process with the same binary name:", err)
+		}
+		if len(sameNameNodes) > 0 {
+			for _, candidate := range sameNameNodes {
+				utils.PrintfStdErr("ERR:%s PID %d is the node of the home but not targeted: %s\n", g.logTag, candidate.Pid, candidate.Reason)
+			}
+			return fmt.Errorf(
+				"the node is running but would not be killed by the target: %s, target by the systemd service name or by the binary path under cosmovisor/current/bin when running by cosmovisor",
+				g.killer.target.Describe(),
+			)
+		}
+	}
 
-				// Load the recent state
+	fmt.Println("INF:"+g.logTag, "state file path:", g.stateFilePath)
 
-				loadRecentPrivateValidatorState := func() (types.PrivateValidatorState, error) {
-					pvs := &types.PrivateValidatorState{}
-					err := pvs.LoadFromJSONFile(privValStateJsonFilePath)
-					if err != nil {
-						return types.PrivateValidatorState{}, err
+	latestBackupPvs, err := loadLatestBackupPrivValidatorState(g.backupDstPath)
+	if err != nil {
+		return err
+	}
+	fmt.Println("INF:"+g.logTag, "latest state from backup:")
+	fmt.Println(latestBackupPvs.Json())
+
+	watcher, err := pvs_watcher.NewWatcher(g.stateFilePath, g.pollInterval)
+	if err != nil {
+		return errors.Wrap(err, "failed to watch state file")
+	}
+	defer watcher.Close()
+	if err := watcher.InotifyError(); err != nil {
+		fmt.Println("WARN:"+g.logTag, err)
+		fmt.Println("WARN:"+g.logTag, "fallback to polling the state file every", g.pollInterval)
+	} else {
+		fmt.Println("INF:"+g.logTag, "watching state file using inotify, safety polling every", g.pollInterval)
+	}
+
+	reaction := &reactionStats{logTag: g.logTag}
+
+	type backupPerHeight struct {
+		heightStr string
+		files     []string
+	}
+	backupFilesByHeight := make([]*backupPerHeight, 0)
+
+	for change := range watcher.Changes() {
+		reaction.printPeriodically()
+
+		// Remove old backups
+		if numberOfBackupHeights := len(backupFilesByHeight); numberOfBackupHeights > g.keepRecent {
+			pruneSize := numberOfBackupHeights - g.keepRecent
+			for _, backupPerHeight := range backupFilesByHeight[:pruneSize] {
+				for _, file := range backupPerHeight.files {
+					if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+						utils.PrintlnStdErr("ERR:"+g.logTag, "failed to remove backup file", file, ":", err)
+						// ignore error
 					}
-					return *pvs, nil
 				}
+			}
+			backupFilesByHeight = backupFilesByHeight[pruneSize:]
+		}
 
-				recentPvs, err := loadRecentPrivateValidatorState()
-				if err != nil {
-					utils.PrintlnStdErr("ERR: failed to load priv_validator_state.json file:", err)
-					continue
-				}
+		// Load the recent state
 
-				cmp, _ := latestBackupPvs.CompareState(recentPvs)
-				// TODO handle different signs flag, returned by CompareState
+		loadRecentPrivateValidatorState := func() (types.PrivateValidatorState, error) {
+			pvs := &types.PrivateValidatorState{}
+			err := pvs.LoadFromJSONFile(g.stateFilePath)
+			if err != nil {
+				return types.PrivateValidatorState{}, err
+			}
+			return *pvs, nil
+		}
 
-				stateNotChanged := cmp == 0 // latest equal to recent
-				stateIncreased := cmp < 0   // latest less than recent
-				// stateDecreased := cmp > 0   // latest greater than recent
+		recentPvs, err := loadRecentPrivateValidatorState()
+		if err != nil {
+			utils.PrintlnStdErr("ERR:"+g.logTag, "failed to load state file:", err)
+			continue
+		}
+		// taken right after the read, so the write of the loaded state is not later than this
+		var stateModTime time.Time
+		if fileInfo, err := os.Stat(g.stateFilePath); err != nil {
+			utils.PrintlnStdErr("ERR:"+g.logTag, "failed to stat state file, reaction time is not measured:", err)
+		} else {
+			stateModTime = fileInfo.ModTime()
+		}
 
-				if stateNotChanged {
-					// nothing changed
-					continue
-				}
+		cmp, differentSigns := latestBackupPvs.CompareState(recentPvs)
+		var reactionTime time.Duration // from the state file written to the change detected
+		if !stateModTime.IsZero() {
+			reactionTime = time.Since(stateModTime)
+		}
 
-				// backup the recent state to file, marked by time and height/round/step
-				backupFileNameMarkByTimeAndHrs := fmt.Sprintf(
-					"%s_%s_hrs_%s_%d_%d.json",
-					backupPrivValStateJsonPrefixFileName,
-					utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime),
-					recentPvs.Height, recentPvs.Round, recentPvs.Step,
-				)
-				backupMarkByTimeAndHrsFilePath := path.Join(backupDstPath, backupFileNameMarkByTimeAndHrs)
-				err = recentPvs.SaveToJSONFile(backupMarkByTimeAndHrsFilePath)
-				if err != nil {
-					utils.PrintlnStdErr("ERR: failed to save backup file", backupMarkByTimeAndHrsFilePath, err)
-				} else if size := len(backupFilesByHeight); size == 0 || backupFilesByHeight[size-1].heightStr != recentPvs.Height {
-					backupFilesByHeight = append(backupFilesByHeight, &backupPerHeight{
-						heightStr: recentPvs.Height,
-						files:     []string{backupMarkByTimeAndHrsFilePath},
-					})
-				} else {
-					backupFilesByHeight[size-1].files = append(backupFilesByHeight[size-1].files, backupMarkByTimeAndHrsFilePath)
-				}
+		if cmp == 0 && differentSigns && !latestBackupPvs.IsEmpty() {
+			// same height/round/step but signature/signbytes changed, something else signed at the same HRS
+			fmt.Println("INF:"+g.logTag, "detected by", change.Source, reactionTime, "after the state file written")
+			g.fatalKillNode(
+				"priv_validator_state.json signed differently at the same height/round/step, possibly double-sign",
+				latestBackupPvs, recentPvs,
+			)
+		}
 
-				if stateIncreased {
-					// backup the recent state to file, marked by latest
-					backupLatestFilePath := path.Join(backupDstPath, latestBackupPrivValStateJsonFileName)
-					err = recentPvs.SaveToJSONFile(backupLatestFilePath)
-					if err != nil {
-						utils.PrintlnStdErr("ERR: failed to save backup file", backupLatestFilePath, err)
-					}
+		stateNotChanged := cmp == 0 // latest equal to recent
+		stateIncreased := cmp < 0   // latest less than recent
+		// stateDecreased := cmp > 0   // latest greater than recent
 
-					latestBackupPvs = recentPvs
-					continue
-				}
+		if stateNotChanged {
+			// nothing changed
+			continue
+		}
+
+		// backup the recent state to file, marked by time and height/round/step
+		backupFileNameMarkByTimeAndHrs := fmt.Sprintf(
+			"%s_%s_hrs_%s_%d_%d.json",
+			backupPrivValStateJsonPrefixFileName,
+			utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime),
+			recentPvs.Height, recentPvs.Round, recentPvs.Step,
+		)
+		backupMarkByTimeAndHrsFilePath := path.Join(g.backupDstPath, backupFileNameMarkByTimeAndHrs)
+		err = recentPvs.SaveToJSONFile(backupMarkByTimeAndHrsFilePath)
+		if err != nil {
+			utils.PrintlnStdErr("ERR:"+g.logTag, "failed to save backup file", backupMarkByTimeAndHrsFilePath, err)
+		} else if size := len(backupFilesByHeight); size == 0 || backupFilesByHeight[size-1].heightStr != recentPvs.Height {
+			backupFilesByHeight = append(backupFilesByHeight, &backupPerHeight{
+				heightStr: recentPvs.Height,
+				files:     []string{backupMarkByTimeAndHrsFilePath},
+			})
+		} else {
+			backupFilesByHeight[size-1].files = append(backupFilesByHeight[size-1].files, backupMarkByTimeAndHrsFilePath)
+		}
+
+		if !stateModTime.IsZero() {
+			reaction.add(reactionTime)
+		}
 
-				// state decreased
+		if stateIncreased {
+			// backup the recent state to file, marked by latest
+			backupLatestFilePath := path.Join(g.backupDstPath, latestBackupPrivValStateJsonFileName)
+			err = recentPvs.SaveToJSONFile(backupLatestFilePath)
+			if err != nil {
+				utils.PrintlnStdErr("ERR:"+g.logTag, "failed to save backup file", backupLatestFilePath, err)
+			}
 
-				const slightlySleepDuration = 5 * time.Millisecond // prevent consuming all CPU
+			latestBackupPvs = recentPvs
+			continue
+		}
 
-				if recentPvs.IsEmpty() {
-					// mode restore snapshot
+		// state decreased
 
-					fmt.Println("WARN: detected state file is empty, possibly restoring snapshot")
-					fmt.Println("WARN: attempts to kill the node binary", binaryNameToKill, "while waiting content to be restored")
+		const slightlySleepDuration = 5 * time.Millisecond // prevent consuming all CPU
 
-					// possibly restoring snapshot progress
-					killedStatusOnSoftProtectRestoreSnapshot := &killedStatus{}
+		if recentPvs.IsEmpty() {
+			// mode restore snapshot
 
-					for {
-						shouldIgnoreSleep := killNodeOnLoop(binaryNameToKill, false, killedStatusOnSoftProtectRestoreSnapshot)
-						if shouldIgnoreSleep {
-							time.Sleep(slightlySleepDuration)
-						} else {
-							time.Sleep(100 * time.Millisecond)
-						}
+			fmt.Println("WARN:"+g.logTag, "detected state file is empty, possibly restoring snapshot")
+			fmt.Println("WARN:"+g.logTag, "attempts to kill the node process", g.killer.target.Describe(), "while waiting content to be restored")
+			g.notifier.Notify(alert.NewEvent(
+				alert.EventRestoreSnapshot, g.home,
+				"state file is empty, possibly restoring snapshot, killing the node process while waiting content to be restored",
+				"Previous state:\n"+latestBackupPvs.Json(),
+			))
 
-						recentPvs, err = loadRecentPrivateValidatorState()
-						if err != nil {
-							utils.PrintlnStdErr("ERR: failed to load priv_validator_state.json file after killing node:", err)
-							time.Sleep(slightlySleepDuration)
-							continue
-						}
+			// possibly restoring snapshot progress
+			killedStatusOnSoftProtectRestoreSnapshot := &killedStatus{}
 
-						if !recentPvs.IsEmpty() {
-							// recent state no longer empty, continue to check in next loop
-							break
-						}
-					}
+			for {
+				shouldIgnoreSleep := g.killer.killOnLoop(false, killedStatusOnSoftProtectRestoreSnapshot)
+				if shouldIgnoreSleep {
+					time.Sleep(slightlySleepDuration)
+				} else {
+					time.Sleep(100 * time.Millisecond)
+				}
 
-					lastExecution = time.Time{} // reset last execution time, move to next as fast as possible
+				recentPvs, err = loadRecentPrivateValidatorState()
+				if err != nil {
+					utils.PrintlnStdErr("ERR:"+g.logTag, "failed to load state file after killing node:", err)
+					time.Sleep(slightlySleepDuration)
 					continue
 				}
 
-				// mode fatal
+				if !recentPvs.IsEmpty() {
+					// recent state no longer empty, continue to check in next loop
+					break
+				}
+			}
+
+			watcher.Trigger() // move to next as fast as possible
+			continue
+		}
+
+		// mode fatal
+
+		fmt.Println("INF:"+g.logTag, "detected by", change.Source, reactionTime, "after the state file written")
+		g.fatalKillNode(
+			"priv_validator_state.json content decreased",
+			latestBackupPvs, recentPvs,
+		)
+	}
+
+	return fmt.Errorf("stopped watching state file %s", g.stateFilePath)
+}
+
+// fatalKillNode reports the mismatch between the previous and the recent state,
+// keeps a forensic copy of both states then kills the node forever.
+func (g *pvsGuard) fatalKillNode(fatalReason string, latestBackupPvs, recentPvs types.PrivateValidatorState) {
+	utils.PrintlnStdErr("FATAL:"+g.logTag, fatalReason)
+	utils.PrintlnStdErr("Previous state:")
+	utils.PrintlnStdErr(latestBackupPvs.Json())
+	utils.PrintlnStdErr("Recent state:")
+	utils.PrintlnStdErr(recentPvs.Json())
 
-				utils.PrintlnStdErr("FATAL: priv_validator_state.json content decreased")
-				utils.PrintlnStdErr("Previous state:")
-				utils.PrintlnStdErr(latestBackupPvs.Json())
-				utils.PrintlnStdErr("Recent state:")
-				utils.PrintlnStdErr(recentPvs.Json())
+	fatalTime := utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime)
 
-				go func(latestBackupPvs, recentPvs types.PrivateValidatorState) {
-					// launch another goroutine to go to kill process as fast as possible
-					reportMismatchFilePath := path.Join(backupDstPath, fmt.Sprintf("mismatch_%s_%s.json", backupPrivValStateJsonPrefixFileName, utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime)))
-					content := fmt.Sprintf(`Previous state:
+	g.notifier.Notify(alert.NewEvent(
+		alert.EventFatal, g.home,
+		fatalReason+", killing the node process",
+		fmt.Sprintf("Previous state:\n%s\n\nRecent state:\n%s", latestBackupPvs.Json(), recentPvs.Json()),
+	))
+
+	go func(latestBackupPvs, recentPvs types.PrivateValidatorState) {
+		// launch another goroutine to go to kill process as fast as possible
+		reportMismatchFilePath := path.Join(g.backupDstPath, fmt.Sprintf("mismatch_%s_%s.json", backupPrivValStateJsonPrefixFileName, fatalTime))
+		content := fmt.Sprintf(`%s
+
+Previous state:
 %s
 
 Recent state:
 %s
-`, latestBackupPvs.Json(), recentPvs.Json())
-					for {
-						// write the mismatch content to file
-						err := os.WriteFile(reportMismatchFilePath, []byte(content), 0o644)
-						if err != nil {
-							utils.PrintlnStdErr("ERR: failed to write mismatch file:", reportMismatchFilePath, ":", err)
-							time.Sleep(300 * time.Millisecond)
-							continue
-						}
-						break
-					}
-					fmt.Println("INF: mismatch content written to file:", reportMismatchFilePath)
-				}(latestBackupPvs, recentPvs)
+`, fatalReason, latestBackupPvs.Json(), recentPvs.Json())
+		writeFileUntilSuccess(reportMismatchFilePath, content, "mismatch")
+		fmt.Println("INF:"+g.logTag, "mismatch content written to file:", reportMismatchFilePath)
+
+		// forensic copies, kept as is, not pruned like the regular backups
+		for suffix, pvs := range map[string]types.PrivateValidatorState{
+			"previous": latestBackupPvs,
+			"recent":   recentPvs,
+		} {
+			forensicFilePath := path.Join(g.backupDstPath, fmt.Sprintf("forensic_%s_%s_%s.json", backupPrivValStateJsonPrefixFileName, fatalTime, suffix))
+			writeFileUntilSuccess(forensicFilePath, pvs.Json(), "forensic")
+			fmt.Println("INF:"+g.logTag, "forensic copy of the", suffix, "state written to file:", forensicFilePath)
+		}
+	}(latestBackupPvs, recentPvs)
 
-				go func(latestBackupPvs, recentPvs types.PrivateValidatorState, userHomeDir string) {
-					// launch another goroutine to go to kill process as fast as possible
-					urgentReportMismatchFilePath := path.Join(userHomeDir, "FATAL_REPORT_MISMATCH_PRIV_VALIDATOR_STATE.txt")
-					content := fmt.Sprintf(`
+	go func(latestBackupPvs, recentPvs types.PrivateValidatorState) {
+		// launch another goroutine to go to kill process as fast as possible
+		urgentReportMismatchFilePath := g.reportFilePath
+		content := fmt.Sprintf(`
 %s detected a mismatch in priv_validator_state.json content, currently executing killing the node!!!
+Node home: %s
+Reason: %s
 
 Previous state:
 %s
@@ -278,118 +649,131 @@ Recent state:
 %s
 
 How to recover:
-- Fix your problem in priv_validator_state.json, can check latest backup at %s
+- Fix your problem in priv_validator_state.json, can check latest backup and forensic copies at %s
+- Ensure no other node or signer is signing with the same validator key
 - Stop this auto-backup service
 - Restart the node
 - Restart this auto-backup service
-`, constants.BINARY_NAME, latestBackupPvs.Json(), recentPvs.Json(), backupDstPath)
-					for {
-						// write the report to file
-						err := os.WriteFile(urgentReportMismatchFilePath, []byte(content), 0o644)
-						if err != nil {
-							utils.PrintlnStdErr("ERR: failed to write report file:", urgentReportMismatchFilePath, ":", err)
-							time.Sleep(300 * time.Millisecond)
-							continue
-						}
-						break
-					}
-					fmt.Println("INF: report content written to file:", urgentReportMismatchFilePath)
-				}(latestBackupPvs, recentPvs, userHomeDir)
-
-				// Force-stop the node
-
-				killedStatusOnFatal := &killedStatus{}
-				fmt.Println("WARN: Killing the node binary:", binaryNameToKill)
-				for {
-					shouldIgnoreSleep := killNodeOnLoop(binaryNameToKill, true, killedStatusOnFatal)
-					if shouldIgnoreSleep {
-						time.Sleep(slightlySleepDuration)
-					} else {
-						time.Sleep(300 * time.Millisecond)
-					}
-				}
-			}
-		},
+`, constants.BINARY_NAME, g.home, fatalReason, latestBackupPvs.Json(), recentPvs.Json(), g.backupDstPath)
+		writeFileUntilSuccess(urgentReportMismatchFilePath, content, "report")
+		fmt.Println("INF:"+g.logTag, "report content written to file:", urgentReportMismatchFilePath)
+	}(latestBackupPvs, recentPvs)
+
+	// Force-stop the node
+
+	const slightlySleepDuration = 5 * time.Millisecond // prevent consuming all CPU
+
+	killedStatusOnFatal := &killedStatus{}
+	fmt.Println("WARN:"+g.logTag, "Killing the node process:", g.killer.target.Describe())
+	for {
+		shouldIgnoreSleep := g.killer.killOnLoop(true, killedStatusOnFatal)
+		if shouldIgnoreSleep {
+			time.Sleep(slightlySleepDuration)
+		} else {
+			time.Sleep(300 * time.Millisecond)
+		}
 	}
+}
 
-	cmd.Flags().Int(flagKeep, 3, "Keep backup of the last N blocks")
-	cmd.Flags().String(flagBinaryKillByAutoBackup, "", "Absolute path of the chain binary to be killed by process when priv_validator_state.json has problem")
-	cmd.Flags().Bool(flagGenSetup, false, "Display guide to setup instead of running business logic")
+func writeFileUntilSuccess(filePath, content, fileType string) {
+	for {
+		err := os.WriteFile(filePath, []byte(content), 0o644)
+		if err != nil {
+			utils.PrintlnStdErr("ERR: failed to write", fileType, "file:", filePath, ":", err)
+			time.Sleep(300 * time.Millisecond)
+			continue
+		}
+		return
+	}
+}
 
-	return cmd
+// reactionStats measures the time from the state file written (mtime) to the change detected.
+type reactionStats struct {
+	count     int
+	total     time.Duration
+	max       time.Duration
+	lastPrint time.Time
+	logTag    string
+}
+
+func (r *reactionStats) add(reactionTime time.Duration) {
+	r.count++
+	r.total += reactionTime
+	if reactionTime > r.max {
+		r.max = reactionTime
+	}
+}
+
+// printPeriodically prints the reaction time of the changes since the last print, then resets.
+func (r *reactionStats) printPeriodically() {
+	const printInterval = 10 * time.Minute
+	if r.lastPrint.IsZero() {
+		r.lastPrint = time.Now()
+		return
+	}
+	if time.Since(r.lastPrint) < printInterval {
+		return
+	}
+	if r.count > 0 {
+		fmt.Println("INF:"+r.logTag, "reaction time of", r.count, "changes: avg", r.total/time.Duration(r.count), ", max", r.max)
+	}
+	*r = reactionStats{lastPrint: time.Now(), logTag: r.logTag}
 }
 
 type killedStatus struct {
-	killedCount uint
+	killedCount      uint
+	lastLogged       string
+	alertedNoProcess bool
+}
+
+// nodeKiller kills the node process found by the target, only logs in dry-run mode.
+type nodeKiller struct {
+	target   node_process.Target
+	dryRun   bool
+	notifier *alert.Notifier
+	logTag   string
 }
 
-func killNodeOnLoop(binaryNameToKill string, fatalCase bool, killedStatus *killedStatus) (shouldIgnoreSleep bool) {
-	processes, err := process.Processes()
+func (k nodeKiller) killOnLoop(fatalCase bool, killedStatus *killedStatus) (shouldIgnoreSleep bool) {
+	candidates, err := k.target.Find()
 	if err != nil {
-		utils.PrintlnStdErr("ERR: failed to get processes:", err)
+		utils.PrintlnStdErr("ERR:"+k.logTag, "failed to find node process:", err)
 		shouldIgnoreSleep = true
 		return
 	}
 
 	var processesToKill []*process.Process
-	for _, p := range processes {
-		func(p *process.Process) {
-			var includeToBeKilled bool
-			defer func() {
-				if !includeToBeKilled {
-					return
-				}
-				processesToKill = append(processesToKill, p)
-			}()
-
-			var sameName, hasStart bool
-
-			cmdLine, _ := p.Cmdline()
-			name, _ := p.Name()
-
-			if strings.Contains(cmdLine, binaryNameToKill) && strings.Contains(cmdLine, " start") {
-				sameName = true
-				hasStart = true
-			}
-
-			if !sameName && name == binaryNameToKill {
-				sameName = true
-			}
-
-			if !hasStart && strings.Contains(cmdLine, " start") {
-				hasStart = true
-			}
-
-			if !sameName || !hasStart {
-				cmdLineSlice, _ := p.CmdlineSlice()
-				for i := 0; i < len(cmdLineSlice); i++ {
-					arg := cmdLineSlice[i]
-					if strings.Contains(arg, binaryNameToKill) && strings.Contains(arg, " start") {
-						sameName = true
-						hasStart = true
-					}
-
-					if !sameName && strings.Contains(arg, binaryNameToKill) {
-						sameName = true
-					}
-
-					if !hasStart && strings.Contains(arg, "start") {
-						hasStart = true
-					}
-
-					if sameName && hasStart {
-						break
-					}
-				}
-			}
+	var sbLog strings.Builder
+	for _, candidate := range candidates {
+		if !candidate.Matched {
+			sbLog.WriteString(fmt.Sprintf("INF:%s skip PID %d: %s\n", k.logTag, candidate.Pid, candidate.Reason))
+			continue
+		}
+		if k.dryRun {
+			sbLog.WriteString(fmt.Sprintf("WARN:%s [dry-run] would kill PID %d: %s\n", k.logTag, candidate.Pid, candidate.Reason))
+			continue
+		}
+		sbLog.WriteString(fmt.Sprintf("WARN:%s kill PID %d: %s\n", k.logTag, candidate.Pid, candidate.Reason))
+		processesToKill = append(processesToKill, &process.Process{Pid: candidate.Pid})
+	}
 
-			includeToBeKilled = sameName && hasStart
-		}(p)
+	if logContent := sbLog.String(); logContent != killedStatus.lastLogged {
+		// prevent flooding the log while looping
+		fmt.Print(logContent)
+		killedStatus.lastLogged = logContent
 	}
 
 	if len(processesToKill) < 1 {
-		if fatalCase && killedStatus.killedCount < 1 {
-			utils.PrintlnStdErr("ERR: no process found to be killed")
+		if fatalCase && killedStatus.killedCount < 1 && !k.dryRun {
+			utils.PrintlnStdErr("ERR:"+k.logTag, "no process found to be killed, target:", k.target.Describe())
+			if !killedStatus.alertedNoProcess {
+				killedStatus.alertedNoProcess = true
+				k.notifier.Notify(alert.NewEvent(
+					alert.EventNoProcessToKill, k.target.Home,
+					"no process found to be killed, target: "+k.target.Describe(),
+					"",
+				))
+			}
 		}
 		shouldIgnoreSleep = true
 		return
@@ -413,15 +797,15 @@ func killNodeOnLoop(binaryNameToKill string, fatalCase bool, killedStatus *kille
 	errKill9 := cmd.Start()
 	if errKill9 != nil {
 		anyError = true
-		utils.PrintlnStdErr("ERR: failed to start command kill -9")
+		utils.PrintlnStdErr("ERR:"+k.logTag, "failed to start command kill -9")
 	}
 
 	for _, p := range processesToKill {
-		fmt.Println("WARN: killing", p.Pid)
+		fmt.Println("WARN:"+k.logTag, "killing", p.Pid)
 		errLibKill := p.Kill()
 		if errLibKill != nil {
 			anyError = true
-			utils.PrintlnStdErr("ERR: failed to kill", p.Pid, ":", err)
+			utils.PrintlnStdErr("ERR:"+k.logTag, "failed to kill", p.Pid, ":", errLibKill)
 		}
 	}
 
@@ -433,85 +817,75 @@ func killNodeOnLoop(binaryNameToKill string, fatalCase bool, killedStatus *kille
 		killedStatus.killedCount += uint(len(processesToKill))
 	}
 
-	fmt.Println("INF: total killed", killedStatus.killedCount, "processes")
+	fmt.Println("INF:"+k.logTag, "total killed", killedStatus.killedCount, "processes")
 
 	return
 }
 
-func createBackupDirIfNotExists(backupDstPath string) {
+func createBackupDirIfNotExists(backupDstPath string) error {
 	_, exists, isDir, err := utils.FileInfo(backupDstPath)
 	if err != nil {
-		utils.ExitWithErrorMsg("ERR: failed to check backup directory:", err)
-		return
+		return errors.Wrap(err, "failed to check backup directory")
 	}
 	if !exists {
-		err = os.Mkdir(backupDstPath, 0o700)
+		err = os.MkdirAll(backupDstPath, 0o700)
 		if err != nil {
-			utils.ExitWithErrorMsg("ERR: failed to create backup directory at", backupDstPath, ":", err)
-			return
+			return errors.Wrapf(err, "failed to create backup directory at %s", backupDstPath)
 		}
 		_, exists, isDir, err = utils.FileInfo(backupDstPath)
 		if err != nil {
-			utils.ExitWithErrorMsg("ERR: failed to check backup directory after created:", err)
-			return
+			return errors.Wrap(err, "failed to check backup directory after created")
 		}
 		if !exists {
-			utils.ExitWithErrorMsg("ERR: backup directory does not exists after create:", backupDstPath)
-			return
+			return fmt.Errorf("backup directory does not exists after create: %s", backupDstPath)
 		}
 	}
 	if !isDir {
-		utils.ExitWithErrorMsg("ERR: backup directory is not a directory:", backupDstPath)
-		return
+		return fmt.Errorf("backup directory is not a directory: %s", backupDstPath)
 	}
+	return nil
 }
 
-func loadLatestBackupPrivValidatorStateOrExitWithErr(backupDstPath string) types.PrivateValidatorState {
+func loadLatestBackupPrivValidatorState(backupDstPath string) (types.PrivateValidatorState, error) {
 	filePath := path.Join(backupDstPath, latestBackupPrivValStateJsonFileName)
 	_, exists, _, err := utils.FileInfo(filePath)
 	if err != nil {
-		utils.ExitWithErrorMsg("ERR: failed to check latest backup file", latestBackupPrivValStateJsonFileName, ":", err)
-		return types.PrivateValidatorState{}
+		return types.PrivateValidatorState{}, errors.Wrapf(err, "failed to check latest backup file %s", filePath)
 	}
 	if !exists {
-		return types.NewEmptyPrivateValidatorState()
+		return types.NewEmptyPrivateValidatorState(), nil
 	}
 
 	pvs := &types.PrivateValidatorState{}
 	err = pvs.LoadFromJSONFile(filePath)
 	if err != nil {
-		utils.ExitWithErrorMsg("ERR: failed to load latest backup file", latestBackupPrivValStateJsonFileName, ":", err)
-		return types.PrivateValidatorState{}
+		return types.PrivateValidatorState{}, errors.Wrapf(err, "failed to load latest backup file %s", filePath)
 	}
 
-	return *pvs
+	return *pvs, nil
 }
 
-func genSetupThenExit(nodeHomeDirectory, binaryPathToKill string, keepRecent int, currentUser *user.User) {
+// genSetupThenExit prints the guide to setup the service, commandArgs are the arguments and flags of the auto-backup command.
+func genSetupThenExit(description, commandArgs string, currentUser *user.User) {
 	const serviceFileName = "auto-backup-pvs"
-	fmt.Println("Input chain name (eg: Cosmos Hub):")
-	chainName := utils.ReadText(false)
-	fmt.Println("Mainnet or Testnet?")
-	networkType := utils.ReadText(false)
-	fmt.Println()
 	fmt.Println("INF: setup guide:")
 	fmt.Println()
 	fmt.Println("1. Create service file")
 	fmt.Println("> sudo vi /etc/systemd/system/" + serviceFileName + ".service")
 	fmt.Printf(`[Unit]
-Description=Auto backup priv_validator_state.json for Validator on %s %s
+Description=Auto backup priv_validator_state.json for %s
 After=network.target
 #
 [Service]
 User=%s
-ExecStart=/usr/local/bin/%s node %s %s --%s %s --%s %d
+ExecStart=/usr/local/bin/%s node %s %s
 RestartSec=1
 Restart=on-failure
 LimitNOFILE=1024
 #
 [Install]
 WantedBy=multi-user.target
-`, chainName, networkType, currentUser.Username, constants.BINARY_NAME, commandAutoBackupPrivValidatorState, nodeHomeDirectory, flagBinaryKillByAutoBackup, binaryPathToKill, flagKeep, keepRecent)
+`, description, currentUser.Username, constants.BINARY_NAME, commandAutoBackupPrivValidatorState, commandArgs)
 	fmt.Println()
 	fmt.Println("2. Setup visudo")
 	fmt.Println()
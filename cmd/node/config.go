@@ -0,0 +1,145 @@
+package node
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/services/toml_editor"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/spf13/cobra"
+	"path"
+	"slices"
+	"strings"
+)
+
+var configFileNames = []string{"config.toml", "app.toml", "client.toml"}
+
+func GetConfigCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "config",
+		Short: "Get/set values in config.toml, app.toml and client.toml, comments and ordering are kept",
+	}
+
+	cmd.AddCommand(
+		getConfigGetCmd(),
+		getConfigSetCmd(),
+	)
+
+	return cmd
+}
+
+func getConfigGetCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "get [node_home] [section.key]",
+		Short: "Print the raw TOML value of the key, eg: `statesync.enable`, `p2p.seeds`, `moniker` (top-level)",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			nodeHomeDirectory := strings.TrimSpace(args[0])
+			section, key := toml_editor.SplitSectionKey(args[1])
+			fileName, _ := cmd.Flags().GetString(flagFile)
+
+			validateNodeHomeDirectory(nodeHomeDirectory)
+
+			filePath, doc := mustFindConfigFileOfKey(nodeHomeDirectory, fileName, section, key)
+
+			value, _ := doc.Get(section, key)
+			if fileName == "" {
+				utils.PrintlnStdErr("INF: from", filePath)
+			}
+			fmt.Println(value)
+		},
+	}
+
+	cmd.Flags().String(flagFile, "", fmt.Sprintf("config file name, one of %v, default is the only file containing the key", configFileNames))
+
+	return cmd
+}
+
+func getConfigSetCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "set [node_home] [section.key] [value]",
+		Short: "Set value of an existing key, a timestamped backup of the file is created",
+		Long: "Set value of an existing key, a timestamped backup of the file is created.\n" +
+			"If the current value is a string, the value is quoted automatically, otherwise it must be a valid TOML value, eg: true, 100, [\"a\", \"b\"].",
+		Args: cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			utils.MustNotUserRoot()
+
+			nodeHomeDirectory := strings.TrimSpace(args[0])
+			section, key := toml_editor.SplitSectionKey(args[1])
+			value := args[2]
+			fileName, _ := cmd.Flags().GetString(flagFile)
+
+			validateNodeHomeDirectory(nodeHomeDirectory)
+
+			filePath, doc := mustFindConfigFileOfKey(nodeHomeDirectory, fileName, section, key)
+
+			var err error
+			if doc.IsString(section, key) {
+				err = doc.SetString(section, key, value)
+			} else {
+				err = doc.Set(section, key, value)
+			}
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to set value:", err)
+				return
+			}
+
+			backupFilePath, err := doc.SaveWithBackup(filePath)
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to save", filePath, ":", err)
+				return
+			}
+
+			newValue, _ := doc.Get(section, key)
+			fmt.Println("INF: updated", args[1], "=", newValue, "in", filePath)
+			fmt.Println("INF: backup file:", backupFilePath)
+		},
+	}
+
+	cmd.Flags().String(flagFile, "", fmt.Sprintf("config file name, one of %v, default is the only file containing the key", configFileNames))
+
+	return cmd
+}
+
+// mustFindConfigFileOfKey loads the config file containing the key, exit if not found or found in multiple files.
+func mustFindConfigFileOfKey(nodeHomeDirectory, fileName, section, key string) (string, *toml_editor.Document) {
+	fileNames := configFileNames
+	if fileName != "" {
+		fileName = path.Base(fileName)
+		if !slices.Contains(configFileNames, fileName) {
+			utils.ExitWithErrorMsgf("ERR: --%s must be one of %v\n", flagFile, configFileNames)
+		}
+		fileNames = []string{fileName}
+	}
+
+	var foundFilePaths []string
+	var foundDoc *toml_editor.Document
+	for _, name := range fileNames {
+		filePath := path.Join(nodeHomeDirectory, "config", name)
+		_, exists, _, err := utils.FileInfo(filePath)
+		if err != nil {
+			utils.ExitWithErrorMsg("ERR: failed to check", filePath, ":", err)
+		}
+		if !exists {
+			continue
+		}
+
+		doc, err := toml_editor.Load(filePath)
+		if err != nil {
+			utils.ExitWithErrorMsg("ERR:", err)
+		}
+		if doc.Has(section, key) {
+			foundFilePaths = append(foundFilePaths, filePath)
+			foundDoc = doc
+		}
+	}
+
+	switch len(foundFilePaths) {
+	case 0:
+		utils.ExitWithErrorMsg("ERR: key", strings.TrimPrefix(section+"."+key, "."), "not found in", strings.Join(fileNames, ", "))
+	case 1:
+	default:
+		utils.ExitWithErrorMsgf("ERR: key found in multiple files %v, specify the file via flag --%s\n", foundFilePaths, flagFile)
+	}
+
+	return foundFilePaths[0], foundDoc
+}
@@ -0,0 +1,28 @@
+package dump_snapshot
+
+import (
+	"github.com/bcdevtools/node-management/services/toml_editor"
+	"strings"
+)
+
+// updateStateSyncConfig enables state sync in the config.toml, trusting the block at the given height.
+func updateStateSyncConfig(configFilePath string, rpcServers []string, trustHeight int64, trustHash string) error {
+	configToml, err := toml_editor.Load(configFilePath)
+	if err != nil {
+		return err
+	}
+
+	for _, err := range []error{
+		configToml.SetBool("statesync", "enable", true),
+		configToml.SetString("statesync", "rpc_servers", strings.Join(rpcServers, ",")),
+		configToml.SetInt64("statesync", "trust_height", trustHeight),
+		configToml.SetString("statesync", "trust_hash", trustHash),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = configToml.SaveWithBackup(configFilePath)
+	return err
+}
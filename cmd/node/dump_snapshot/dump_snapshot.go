@@ -1,11 +1,13 @@
 package dump_snapshot
 
 import (
+	"context"
 	"fmt"
+	"github.com/bcdevtools/node-management/services/genesis"
+	"github.com/bcdevtools/node-management/services/rpc_client"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
 	"github.com/spf13/cobra"
-	"net/http"
 	"os"
 	"os/exec"
 	"path"
@@ -157,9 +159,16 @@ func GetDumpSnapshotCmd() *cobra.Command {
 			if cmd.Flags().Changed(flagFixGenesis) {
 				fmt.Println("INF: fixing genesis initial_height")
 				genesisFilePath := path.Join(dumpHomeDir, "config", "genesis.json")
-				_ = utils.LaunchApp("/bin/bash", []string{
-					"-c", fmt.Sprintf(`jq '.initial_height = "1"' %s > %s.tmp && mv %s.tmp %s`, genesisFilePath, genesisFilePath, genesisFilePath, genesisFilePath),
-				})
+				result, err := genesis.Fix(genesisFilePath, 1)
+				if err != nil {
+					utils.PrintlnStdErr("ERR: failed to fix genesis:", err)
+					exitWithError = true
+					return
+				}
+				if result.BackupFilePath != "" {
+					// dump home directory is a copy, backup is not needed
+					_ = os.Remove(result.BackupFilePath)
+				}
 			}
 
 			fmt.Println("INF: force reset dump home directory")
@@ -333,9 +342,12 @@ func GetDumpSnapshotCmd() *cobra.Command {
 				rpcEps = append(rpcEps, rpc)
 			}
 
+			nodeRpcClient := rpc_client.NewRpcClient(rpc, 10*time.Second)
 			for {
-				resp, err := http.Get(fmt.Sprintf("%s/status", strings.TrimSuffix(rpc, "/")))
-				if err == nil && resp.StatusCode == http.StatusOK {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				_, err := nodeRpcClient.Status(ctx)
+				cancel()
+				if err == nil {
 					break
 				}
 				fmt.Println("INF: waiting node up")
@@ -354,23 +366,16 @@ func GetDumpSnapshotCmd() *cobra.Command {
 						chanTrustHash <- trustHash
 					}()
 
-					output, ec := utils.LaunchAppAndGetOutput(
-						"/bin/bash",
-						[]string{
-							"-c", fmt.Sprintf(
-								`curl -m 30 -s "%s/block?height=%d" | jq -r .result.block_id.hash`,
-								rpc,
-								mostRecentSnapshot.height,
-							),
-						},
-					)
-					if ec != 0 {
-						utils.PrintlnStdErr(output)
-						utils.PrintlnStdErr("ERR: failed to get block hash from rpc:", rpc)
+					ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+					defer cancel()
+
+					block, err := rpc_client.NewRpcClient(rpc, 30*time.Second).WithRetry(3, 5*time.Second).Block(ctx, mostRecentSnapshot.height)
+					if err != nil {
+						utils.PrintlnStdErr("ERR: failed to get block hash from rpc:", rpc, ":", err)
 						return
 					}
 
-					trustHash = strings.TrimSpace(output)
+					trustHash = strings.TrimSpace(block.BlockID.Hash)
 					if !regexp.MustCompile(`^[A-F\d]{64}$`).MatchString(trustHash) {
 						utils.PrintlnStdErr("ERR: invalid block hash", trustHash, "from rpc", rpc)
 						trustHash = ""
@@ -400,20 +405,9 @@ func GetDumpSnapshotCmd() *cobra.Command {
 			if len(rpcEps) == 1 {
 				rpcEps = append(rpcEps, rpcEps[0])
 			}
-			sedArgs := []string{
-				"-i.bak",
-				"-E", `s|^(enable[[:space:]]+=[[:space:]]+).*$|\1true| ; s|^(rpc_servers[[:space:]]+=[[:space:]]+).*$|\1\"` + strings.Join(func() []string {
-					if len(rpcEps) == 1 {
-						return []string{rpcEps[0], rpcEps[0]}
-					}
-					return rpcEps
-				}(), ",") + `\"| ; s|^(trust_height[[:space:]]+=[[:space:]]+).*$|\1` + mostRecentSnapshot.HeightStr() + `| ; s|^(trust_hash[[:space:]]+=[[:space:]]+).*$|\1"` + trustHash + `"|`,
-				path.Join(dumpHomeDir, "config", "config.toml"),
-			}
-			ec = utils.LaunchApp("sed", sedArgs)
-			if ec != 0 {
-				utils.PrintlnStdErr("ERR: failed to launch sed to update config file")
-				utils.PrintlnStdErr("> sed " + strings.Join(sedArgs, " "))
+			dumpConfigFilePath := path.Join(dumpHomeDir, "config", "config.toml")
+			if err := updateStateSyncConfig(dumpConfigFilePath, rpcEps, mostRecentSnapshot.height, trustHash); err != nil {
+				utils.PrintlnStdErr("ERR: failed to update state sync config:", err)
 				exitWithError = true
 				return
 			}
@@ -0,0 +1,503 @@
+package node
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bcdevtools/node-management/services/snapshot_archive"
+	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/bcdevtools/node-management/validation"
+	"github.com/pkg/errors"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/spf13/cobra"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	flagOutputDir   = "output-dir"
+	flagConnections = "connections"
+	flagFile        = "file"
+	flagSha256      = "sha256"
+	flagRestore     = "restore"
+)
+
+func GetFetchSnapshotCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "fetch-snapshot [url]",
+		Short: "Download snapshot using aria2c, verify checksum and optionally restore",
+		Long: "Download snapshot using aria2c multi-connection with resume support.\n" +
+			"The URL can be a snapshot file (.tar.lz4, .tar.zst, .tar.gz) or a web server started by `start-web`,\n" +
+			"in which case the snapshot is selected from its catalog `/api/snapshots`, newest by default.\n" +
+			"Checksum is verified against the snapshot manifest, if available.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			utils.MustNotUserRoot()
+
+			input := strings.TrimSpace(args[0])
+			outputDir, _ := cmd.Flags().GetString(flagOutputDir)
+			connections, _ := cmd.Flags().GetInt(flagConnections)
+			fileName, _ := cmd.Flags().GetString(flagFile)
+			expectedSha256, _ := cmd.Flags().GetString(flagSha256)
+			restoreNodeHome, _ := cmd.Flags().GetString(flagRestore)
+			backupPrivValStateJson, _ := cmd.Flags().GetString(flagBackupPrivValStateJson)
+			serviceName, _ := cmd.Flags().GetString(flagServiceName)
+
+			if connections < 1 || connections > 16 {
+				utils.ExitWithErrorMsgf("ERR: --%s must be in range [1, 16]\n", flagConnections)
+				return
+			}
+
+			restoreNodeHome = strings.TrimSpace(restoreNodeHome)
+			if restoreNodeHome != "" {
+				if err := precheckRestoreSnapshot(restoreNodeHome, backupPrivValStateJson, serviceName); err != nil {
+					utils.ExitWithErrorMsg("ERR:", err)
+					return
+				}
+			} else if backupPrivValStateJson != "" || serviceName != "" {
+				utils.ExitWithErrorMsgf("ERR: --%s and --%s are only used with --%s\n", flagBackupPrivValStateJson, flagServiceName, flagRestore)
+				return
+			}
+
+			if err := os.MkdirAll(outputDir, 0o755); err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to create output directory:", err)
+				return
+			}
+
+			source, err := resolveSnapshotSource(input, fileName)
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to resolve snapshot:", err)
+				return
+			}
+			if expectedSha256 != "" {
+				source.sha256 = strings.ToLower(strings.TrimSpace(expectedSha256))
+			}
+
+			fmt.Println("INF: snapshot:", source.downloadURL)
+			if source.size > 0 {
+				fmt.Println("INF: size:", source.size, "bytes")
+			}
+			if source.height > 0 {
+				fmt.Println("INF: height:", source.height)
+			}
+			if source.sha256 == "" {
+				utils.PrintlnStdErr("WARN: sha256 is not available, checksum will not be verified, provide it via flag --" + flagSha256)
+			}
+
+			if restoreNodeHome != "" {
+				if format, _ := snapshot_archive.FormatFromFileName(source.fileName); format == "" {
+					utils.ExitWithErrorMsg("ERR: snapshot", source.fileName, "is not a compressed archive, can not be restored")
+					return
+				}
+			}
+
+			outputFilePath := path.Join(outputDir, source.fileName)
+			if err := checkFreeDiskSpace(outputFilePath, source, restoreNodeHome); err != nil {
+				utils.ExitWithErrorMsg("ERR:", err)
+				return
+			}
+
+			aria2cArgs := []string{
+				"--continue=true",
+				"--auto-file-renaming=false",
+				"--max-connection-per-server", fmt.Sprint(connections),
+				"--split", fmt.Sprint(connections),
+				"--min-split-size", "64M",
+				"--max-tries", "0",
+				"--retry-wait", "10",
+				"--dir", outputDir,
+				"--out", source.fileName,
+				source.downloadURL,
+			}
+			fmt.Println("INF: downloading")
+			fmt.Println("> aria2c", strings.Join(aria2cArgs, " "))
+			ec := utils.LaunchApp("aria2c", aria2cArgs)
+			if ec != 0 {
+				utils.ExitWithErrorMsgf("ERR: aria2c exited with code %d, re-run the command to resume\n", ec)
+				return
+			}
+
+			if source.size > 0 {
+				if fi, err := os.Stat(outputFilePath); err != nil {
+					utils.ExitWithErrorMsg("ERR: failed to check downloaded file:", err)
+					return
+				} else if fi.Size() != source.size {
+					utils.ExitWithErrorMsgf("ERR: downloaded file size %d does not match expected size %d\n", fi.Size(), source.size)
+					return
+				}
+			}
+
+			if source.sha256 != "" {
+				fmt.Println("INF: verifying sha256")
+				checksum, err := sha256OfFile(outputFilePath)
+				if err != nil {
+					utils.ExitWithErrorMsg("ERR: failed to calculate sha256:", err)
+					return
+				}
+				if checksum != source.sha256 {
+					utils.PrintlnStdErr("ERR: sha256 mismatch, expected", source.sha256, "but got", checksum)
+					utils.ExitWithErrorMsg("ERR: remove the corrupted file and retry:", outputFilePath)
+					return
+				}
+				fmt.Println("INF: sha256 verified:", checksum)
+			}
+
+			fmt.Println("INF: snapshot file:", outputFilePath)
+
+			if restoreNodeHome == "" {
+				return
+			}
+
+			if exitWithError := restoreSnapshot(restoreNodeHome, outputFilePath, backupPrivValStateJson, serviceName); exitWithError {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().String(flagOutputDir, ".", "directory to save the snapshot file")
+	cmd.Flags().Int(flagConnections, 8, "number of connections used to download, max 16")
+	cmd.Flags().String(flagFile, "", "file name of the snapshot to download from the catalog, default is the newest")
+	cmd.Flags().String(flagSha256, "", "expected sha256 of the snapshot file, override the one from manifest")
+	cmd.Flags().String(flagRestore, "", "node home to restore the snapshot into after downloaded, same as `restore-snapshot`")
+	cmd.Flags().String(flagBackupPrivValStateJson, "", "used with --"+flagRestore+", backup "+fileNamePrivValState+" file path to prove you already backup it, required if the file in data is not empty")
+	cmd.Flags().String(flagServiceName, "", "used with --"+flagRestore+", the service will be stopped before restoring and started after")
+
+	return cmd
+}
+
+type snapshotSource struct {
+	downloadURL      string
+	fileName         string
+	sha256           string
+	height           int64
+	size             int64 // zero if unknown
+	uncompressedSize int64 // zero if unknown
+}
+
+// resolveSnapshotSource resolves the input URL, which is either a snapshot file or a web server serving the catalog.
+func resolveSnapshotSource(input, fileName string) (*snapshotSource, error) {
+	inputURL, err := url.Parse(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid URL")
+	}
+	if inputURL.Scheme != "http" && inputURL.Scheme != "https" {
+		return nil, fmt.Errorf("URL must start with http:// or https://")
+	}
+
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	if _, isTar := snapshot_archive.FormatFromFileName(path.Base(inputURL.Path)); isTar {
+		if fileName != "" {
+			return nil, fmt.Errorf("flag --%s is only used with catalog", flagFile)
+		}
+		return resolveSnapshotFileSource(httpClient, input, path.Base(inputURL.Path))
+	}
+
+	catalogURL := strings.TrimSuffix(input, "/")
+	if !strings.HasSuffix(inputURL.Path, "/api/snapshots") {
+		catalogURL += "/api/snapshots"
+	}
+	return resolveSnapshotCatalogSource(httpClient, catalogURL, fileName)
+}
+
+// resolveSnapshotFileSource reads the size and the sidecar manifest of the snapshot file, if available.
+func resolveSnapshotFileSource(httpClient *http.Client, fileURL, fileName string) (*snapshotSource, error) {
+	source := &snapshotSource{
+		downloadURL: fileURL,
+		fileName:    fileName,
+	}
+
+	resp, err := httpClient.Head(fileURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to request snapshot file")
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, fileURL)
+	}
+	if resp.ContentLength > 0 {
+		source.size = resp.ContentLength
+	}
+
+	manifestURL, err := snapshotManifestURL(fileURL)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &types.SnapshotManifest{}
+	if found, err := getJSON(httpClient, manifestURL, manifest); err != nil {
+		utils.PrintlnStdErr("WARN: failed to get snapshot manifest:", err)
+	} else if found {
+		fmt.Println("INF: snapshot manifest:", manifestURL)
+		source.sha256 = manifest.Sha256
+		source.height = manifest.Height
+		source.uncompressedSize = manifest.UncompressedSize
+		if source.size == 0 {
+			source.size = manifest.CompressedSize
+		}
+	}
+
+	return source, nil
+}
+
+// resolveSnapshotCatalogSource selects the snapshot from the catalog, by file name or the newest one.
+func resolveSnapshotCatalogSource(httpClient *http.Client, catalogURL, fileName string) (*snapshotSource, error) {
+	var catalogResponse struct {
+		Status string                          `json:"status"`
+		Result []webtypes.SnapshotCatalogEntry `json:"result"`
+	}
+	if found, err := getJSON(httpClient, catalogURL, &catalogResponse); err != nil {
+		return nil, errors.Wrap(err, "failed to get snapshot catalog")
+	} else if !found {
+		return nil, fmt.Errorf("snapshot catalog not found at %s", catalogURL)
+	}
+	if catalogResponse.Status != "1" {
+		return nil, fmt.Errorf("failed to get snapshot catalog from %s", catalogURL)
+	}
+	if len(catalogResponse.Result) == 0 {
+		return nil, fmt.Errorf("no snapshot available at %s", catalogURL)
+	}
+
+	var entry *webtypes.SnapshotCatalogEntry
+	if fileName == "" {
+		entry = &catalogResponse.Result[0] // newest first
+	} else {
+		for i, e := range catalogResponse.Result {
+			if e.FileName == fileName {
+				entry = &catalogResponse.Result[i]
+				break
+			}
+		}
+		if entry == nil {
+			return nil, fmt.Errorf("snapshot %s is not in the catalog", fileName)
+		}
+	}
+	if entry.DownloadURL == "" {
+		return nil, fmt.Errorf("download URL of snapshot %s is not available", entry.FileName)
+	}
+
+	if name := path.Base(entry.FileName); name == "." || name == ".." || name == "/" {
+		return nil, fmt.Errorf("invalid snapshot file name %s", entry.FileName)
+	}
+
+	fmt.Println("INF: selected", entry.FileName, "from catalog of", entry.ChainID)
+
+	return &snapshotSource{
+		downloadURL:      entry.DownloadURL,
+		fileName:         path.Base(entry.FileName),
+		sha256:           entry.Sha256,
+		height:           entry.Height,
+		size:             entry.SizeBytes,
+		uncompressedSize: entry.UncompressedSizeBytes,
+	}, nil
+}
+
+// getJSON returns false without error if the resource is not found.
+func getJSON(httpClient *http.Client, url string, result any) (found bool, err error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to request %s", url)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return false, errors.Wrapf(err, "failed to decode response of %s", url)
+	}
+	return true, nil
+}
+
+// snapshotManifestURL returns URL of the sidecar manifest of the snapshot file URL, query and fragment are kept.
+func snapshotManifestURL(fileURL string) (string, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return "", errors.Wrap(err, "invalid URL")
+	}
+	u.Path = types.GetSnapshotManifestFilePath(u.Path)
+	if u.RawPath != "" {
+		u.RawPath = types.GetSnapshotManifestFilePath(u.RawPath)
+	}
+	return u.String(), nil
+}
+
+// precheckRestoreSnapshot fails fast, before downloading, if the snapshot can not be restored into the node home.
+func precheckRestoreSnapshot(nodeHomeDirectory, backupPrivValStateJson, serviceName string) error {
+	if err := validation.PossibleNodeHome(nodeHomeDirectory); err != nil {
+		return errors.Wrap(err, "invalid node home directory")
+	}
+	if serviceName != "" {
+		if err := validateServiceName(serviceName); err != nil {
+			return err
+		}
+	} else if err := requireNodeStopped(nodeHomeDirectory); err != nil {
+		return err
+	}
+
+	filePathPrivValState := path.Join(nodeHomeDirectory, "data", fileNamePrivValState)
+	_, exists, _, err := utils.FileInfo(filePathPrivValState)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check %s", filePathPrivValState)
+	}
+	if !exists {
+		return nil
+	}
+
+	pvs := &types.PrivateValidatorState{}
+	if err := pvs.LoadFromJSONFile(filePathPrivValState); err != nil {
+		return errors.Wrapf(err, "failed to load %s", filePathPrivValState)
+	}
+	if !pvs.IsEmpty() && backupPrivValStateJson == "" {
+		return fmt.Errorf("%s is not empty, require backup file via flag --%s, to prove you already backup the file", filePathPrivValState, flagBackupPrivValStateJson)
+	}
+	return nil
+}
+
+// checkFreeDiskSpace ensures there is enough space for the remaining download,
+// and for the extracted data if restoring, existing data is removed before extracting.
+func checkFreeDiskSpace(outputFilePath string, source *snapshotSource, restoreNodeHome string) error {
+	outputDir := filepath.Dir(outputFilePath)
+
+	var requiredForDownload int64
+	if source.size > 0 {
+		requiredForDownload = source.size
+		if fi, err := os.Stat(outputFilePath); err == nil {
+			requiredForDownload -= fi.Size() // resume
+		}
+		if requiredForDownload < 0 {
+			requiredForDownload = 0
+		}
+	} else {
+		utils.PrintlnStdErr("WARN: snapshot size is unknown, free disk space is not checked")
+	}
+
+	usage, err := disk.Usage(outputDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check free disk space of %s", outputDir)
+	}
+	available := int64(usage.Free)
+
+	if restoreNodeHome != "" && source.uncompressedSize > 0 {
+		dataDir := path.Join(restoreNodeHome, "data")
+		existingDataSize, err := dirSize(dataDir)
+		if err != nil {
+			return errors.Wrapf(err, "failed to calculate size of %s", dataDir)
+		}
+		requiredForRestore := source.uncompressedSize - existingDataSize
+		if requiredForRestore < 0 {
+			requiredForRestore = 0
+		}
+
+		if sameDevice(outputDir, dataDir) {
+			requiredForDownload += requiredForRestore
+		} else {
+			dataUsage, err := disk.Usage(dataDir)
+			if err != nil {
+				return errors.Wrapf(err, "failed to check free disk space of %s", dataDir)
+			}
+			if int64(dataUsage.Free) < requiredForRestore {
+				return fmt.Errorf("not enough free disk space at %s to restore, required %d bytes, available %d bytes", dataDir, requiredForRestore, dataUsage.Free)
+			}
+		}
+	} else if restoreNodeHome != "" {
+		utils.PrintlnStdErr("WARN: uncompressed size is unknown, free disk space for restoring is not checked")
+	}
+
+	if available < requiredForDownload {
+		return fmt.Errorf("not enough free disk space at %s, required %d bytes, available %d bytes", outputDir, requiredForDownload, available)
+	}
+	return nil
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			fi, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += fi.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// sameDevice returns true if both paths are on the same device, or unable to determine.
+func sameDevice(path1, path2 string) bool {
+	fi1, err1 := os.Stat(path1)
+	fi2, err2 := os.Stat(path2)
+	if err1 != nil || err2 != nil {
+		return true
+	}
+	stat1, ok1 := fi1.Sys().(*syscall.Stat_t)
+	stat2, ok2 := fi2.Sys().(*syscall.Stat_t)
+	if !ok1 || !ok2 {
+		return true
+	}
+	return stat1.Dev == stat2.Dev
+}
+
+func sha256OfFile(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	fi, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	progressPrinter := snapshot_archive.NewProgressPrinter(os.Stderr)
+	defer progressPrinter.Finish()
+
+	hasher := sha256.New()
+	var processed int64
+	buf := make([]byte, 4*1024*1024)
+	for {
+		n, err := file.Read(buf)
+		if n > 0 {
+			hasher.Write(buf[:n])
+			processed += int64(n)
+			progressPrinter.Update(processed, fi.Size())
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
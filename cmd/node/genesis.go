@@ -0,0 +1,183 @@
+package node
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/services/genesis"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	flagChainRegistry = "chain-registry"
+	flagInitialHeight = "initial-height"
+)
+
+func GetGenesisCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "genesis",
+		Short: "Verify and fix genesis.json of the node",
+	}
+
+	cmd.AddCommand(
+		getGenesisVerifyCmd(),
+		getGenesisFixCmd(),
+	)
+
+	return cmd
+}
+
+func getGenesisVerifyCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "verify [node_home]",
+		Short: "Verify genesis.json: checksum, chain-id matches client.toml, not gzip-compressed and initial_height",
+		Long: `Verify genesis.json: checksum, chain-id matches client.toml, not gzip-compressed and initial_height.
+The expected sha256 matches either the file, the decompressed content or the canonical JSON (keys sorted, no whitespace).`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			nodeHomeDirectory := strings.TrimSpace(args[0])
+			validateNodeHomeDirectory(nodeHomeDirectory)
+
+			genesisFilePath := path.Join(nodeHomeDirectory, "config", "genesis.json")
+			info, err := genesis.Inspect(genesisFilePath)
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to inspect genesis:", err)
+				return
+			}
+
+			fmt.Println("File:", genesisFilePath)
+			fmt.Println("Size:", info.Size)
+			fmt.Println("Gzip-compressed:", info.Compressed)
+			fmt.Println("Sha256:", info.Sha256)
+			if info.Compressed {
+				fmt.Println("Sha256 (decompressed):", info.ContentSha256)
+			}
+			fmt.Println("Sha256 (canonical):", info.CanonicalSha256)
+			fmt.Println("Chain ID:", info.ChainId)
+			fmt.Println("Initial height:", info.InitialHeight)
+
+			var anyError bool
+			printError := func(a ...any) {
+				anyError = true
+				utils.PrintlnStdErr(append([]any{"ERR:"}, a...)...)
+			}
+
+			if info.Compressed {
+				printError("genesis.json is gzip-compressed, node can not load it, decompress using: genesis fix")
+			}
+
+			if info.InitialHeight == "" {
+				fmt.Println("WARN: initial_height is not set")
+			} else if _, err := info.InitialHeightValue(); err != nil {
+				printError("invalid initial_height", info.InitialHeight, ":", err)
+			} else if !strings.HasPrefix(info.InitialHeight, `"`) {
+				printError("initial_height must be encoded as string, fix using: genesis fix")
+			}
+
+			clientChainId, err := readChainIdFromClientToml(path.Join(nodeHomeDirectory, "config", "client.toml"))
+			if err != nil {
+				utils.PrintlnStdErr("WARN: failed to read chain-id from client.toml:", err)
+			} else if clientChainId == "" {
+				utils.PrintlnStdErr("WARN: chain-id is empty in client.toml")
+			} else if clientChainId != info.ChainId {
+				printError(fmt.Sprintf("chain_id \"%s\" does not match chain-id \"%s\" in client.toml", info.ChainId, clientChainId))
+			}
+
+			expectedSha256, _ := cmd.Flags().GetString(flagSha256)
+			chainRegistryFilePath, _ := cmd.Flags().GetString(flagChainRegistry)
+			if chainRegistryFilePath != "" {
+				registryChainId, registrySha256, err := genesis.ReadChainRegistry(chainRegistryFilePath)
+				if err != nil {
+					utils.ExitWithErrorMsg("ERR: failed to read chain-registry file:", err)
+					return
+				}
+				if registryChainId != info.ChainId {
+					printError(fmt.Sprintf("chain_id \"%s\" does not match chain_id \"%s\" in chain-registry file", info.ChainId, registryChainId))
+				}
+				if expectedSha256 == "" {
+					if registrySha256 == "" {
+						utils.PrintlnStdErr("WARN: genesis sha256 is not available in chain-registry file")
+					}
+					expectedSha256 = registrySha256
+				}
+			}
+			if expectedSha256 != "" {
+				if info.MatchesSha256(expectedSha256) {
+					fmt.Println("INF: sha256 matches", expectedSha256)
+				} else {
+					printError("sha256 does not match, expected", expectedSha256)
+				}
+			}
+
+			if anyError {
+				os.Exit(1)
+			}
+			fmt.Println("INF: genesis verified")
+		},
+	}
+
+	cmd.Flags().String(flagSha256, "", "expected sha256 of genesis.json, override the one from chain-registry file")
+	cmd.Flags().String(flagChainRegistry, "", "local chain.json file of the chain-registry, to verify chain_id and sha256 (codebase.genesis.sha256)")
+
+	return cmd
+}
+
+func getGenesisFixCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "fix [node_home]",
+		Short: "Decompress gzip-compressed genesis.json and fix initial_height, a timestamped backup of the file is created",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			utils.MustNotUserRoot()
+
+			nodeHomeDirectory := strings.TrimSpace(args[0])
+			validateNodeHomeDirectory(nodeHomeDirectory)
+
+			initialHeight, _ := cmd.Flags().GetInt64(flagInitialHeight)
+			if initialHeight < 0 {
+				utils.ExitWithErrorMsg("ERR: --" + flagInitialHeight + " must be positive")
+				return
+			}
+
+			genesisFilePath := path.Join(nodeHomeDirectory, "config", "genesis.json")
+			result, err := genesis.Fix(genesisFilePath, initialHeight)
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to fix genesis:", err)
+				return
+			}
+
+			if result.BackupFilePath == "" {
+				fmt.Println("INF: nothing to fix")
+				return
+			}
+			if result.Decompressed {
+				fmt.Println("INF: decompressed gzip-compressed", genesisFilePath)
+			}
+			if result.InitialHeight != "" {
+				fmt.Println("INF: updated initial_height =", result.InitialHeight, "in", genesisFilePath)
+			}
+			fmt.Println("INF: backup file:", result.BackupFilePath)
+		},
+	}
+
+	cmd.Flags().Int64(flagInitialHeight, 0, "set initial_height, default is to keep the value and only encode it as string")
+
+	return cmd
+}
+
+func readChainIdFromClientToml(clientTomlFilePath string) (string, error) {
+	bz, err := os.ReadFile(clientTomlFilePath)
+	if err != nil {
+		return "", err
+	}
+
+	var client types.ClientToml
+	if err := toml.Unmarshal(bz, &client); err != nil {
+		return "", err
+	}
+	return client.ChainId, nil
+}
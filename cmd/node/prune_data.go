@@ -2,7 +2,6 @@ package node
 
 import (
 	"fmt"
-	"github.com/bcdevtools/node-management/constants"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
 	"github.com/bcdevtools/node-management/validation"
@@ -96,75 +95,12 @@ func GetPruneNodeDataCmd() *cobra.Command {
 			} else {
 				fmt.Println("INF:", fileNamePrivValState, "is not empty, location:", filePathPrivValState)
 				fmt.Println(string(bz))
-				if backupPrivValStateJson == "" {
-					utils.ExitWithErrorMsgf("ERR: require backup file via flag --%s, to prove you already backup the file %s\n", flagBackupPrivValStateJson, fileNamePrivValState)
-					return
-				}
-
-				backupPrivValStateJsonDir, _ := path.Split(backupPrivValStateJson)
-				backupPrivValStateJsonDir = strings.TrimSuffix(backupPrivValStateJsonDir, "/")
-				if backupPrivValStateJsonDir == strings.TrimSuffix(dataDir, "/") {
-					utils.ExitWithErrorMsg("ERR: backup file must not be in data directory")
-					return
-				}
-
-				bzOfBackup, err := os.ReadFile(backupPrivValStateJson)
-				if err != nil {
-					utils.ExitWithErrorMsg("ERR: failed to read backup file", backupPrivValStateJson, ":", err)
-					return
-				}
-				if len(bzOfBackup) < 1 {
-					utils.ExitWithErrorMsg("ERR: backup file is empty:", backupPrivValStateJson)
-					return
-				}
-
-				pvsBackup := &types.PrivateValidatorState{}
-				err = pvsBackup.LoadFromJSONFile(backupPrivValStateJson)
-				if err != nil {
-					utils.ExitWithErrorMsg("ERR: failed to load backup file", backupPrivValStateJson, ":", err)
-					return
-				}
-
-				if pvsBackup.IsEmpty() {
-					utils.ExitWithErrorMsg("ERR: backup file is empty", backupPrivValStateJson)
-					return
-				}
-
-				if !pvs.Equals(*pvsBackup) {
-					utils.ExitWithErrorMsg("ERR: backup file at", backupPrivValStateJson, "has different content with", filePathPrivValState)
-					return
-				}
-
-				fmt.Println("INF:", filePathPrivValState)
-				fmt.Println(string(bz))
-				fmt.Println("INF:", backupPrivValStateJson)
-				fmt.Println(string(bzOfBackup))
-
-				// create additional backup
-				additionalBackupFile := path.Join(configDir, fmt.Sprintf("%s.%s.%s.bak", fileNamePrivValState, utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime), constants.BINARY_NAME))
-
-				fmt.Println("Going to create an additional backup of", fileNamePrivValState)
-				fmt.Println("at", additionalBackupFile)
-
-				err = os.WriteFile(additionalBackupFile, bz, 0644)
+				additionalBackupFile, err := backupNonEmptyPrivValState(pvs, bz, backupPrivValStateJson, dataDir, configDir)
 				if err != nil {
-					utils.ExitWithErrorMsg("ERR: failed to write additional backup file:", err)
+					utils.ExitWithErrorMsg("ERR:", err)
 					return
 				}
 
-				backupPsv2 := &types.PrivateValidatorState{}
-				err = backupPsv2.LoadFromJSONFile(additionalBackupFile)
-				if err != nil {
-					utils.ExitWithErrorMsg("ERR: failed to load additional backup file", additionalBackupFile, ":", err)
-					return
-				}
-				if !pvs.Equals(*backupPsv2) {
-					utils.ExitWithErrorMsg("ERR: additional backup file at", additionalBackupFile, "has different content with", filePathPrivValState)
-					return
-				}
-
-				fmt.Println("Additional backup file created successfully!")
-
 				additionalBackupPrivStateJsonFilePath = additionalBackupFile
 			}
 
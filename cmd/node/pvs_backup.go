@@ -0,0 +1,81 @@
+package node
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/pkg/errors"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// backupNonEmptyPrivValState verifies the backup file provided by user has the same content with the non-empty
+// priv_validator_state.json, to prove user already backup it, then creates an additional backup in the config dir.
+// Returns the file path of the additional backup.
+func backupNonEmptyPrivValState(pvs *types.PrivateValidatorState, bz []byte, backupPrivValStateJson, dataDir, configDir string) (string, error) {
+	filePathPrivValState := path.Join(dataDir, fileNamePrivValState)
+
+	if backupPrivValStateJson == "" {
+		return "", fmt.Errorf("require backup file via flag --%s, to prove you already backup the file %s", flagBackupPrivValStateJson, fileNamePrivValState)
+	}
+
+	backupPrivValStateJsonDir, _ := path.Split(backupPrivValStateJson)
+	backupPrivValStateJsonDir = strings.TrimSuffix(backupPrivValStateJsonDir, "/")
+	if backupPrivValStateJsonDir == strings.TrimSuffix(dataDir, "/") {
+		return "", fmt.Errorf("backup file must not be in data directory")
+	}
+
+	bzOfBackup, err := os.ReadFile(backupPrivValStateJson)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read backup file %s", backupPrivValStateJson)
+	}
+	if len(bzOfBackup) < 1 {
+		return "", fmt.Errorf("backup file is empty: %s", backupPrivValStateJson)
+	}
+
+	pvsBackup := &types.PrivateValidatorState{}
+	err = pvsBackup.LoadFromJSONFile(backupPrivValStateJson)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load backup file %s", backupPrivValStateJson)
+	}
+
+	if pvsBackup.IsEmpty() {
+		return "", fmt.Errorf("backup file is empty %s", backupPrivValStateJson)
+	}
+
+	if !pvs.Equals(*pvsBackup) {
+		return "", fmt.Errorf("backup file at %s has different content with %s", backupPrivValStateJson, filePathPrivValState)
+	}
+
+	fmt.Println("INF:", filePathPrivValState)
+	fmt.Println(string(bz))
+	fmt.Println("INF:", backupPrivValStateJson)
+	fmt.Println(string(bzOfBackup))
+
+	// create additional backup
+	additionalBackupFile := path.Join(configDir, fmt.Sprintf("%s.%s.%s.bak", fileNamePrivValState, utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime), constants.BINARY_NAME))
+
+	fmt.Println("Going to create an additional backup of", fileNamePrivValState)
+	fmt.Println("at", additionalBackupFile)
+
+	err = os.WriteFile(additionalBackupFile, bz, 0644)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write additional backup file")
+	}
+
+	backupPsv2 := &types.PrivateValidatorState{}
+	err = backupPsv2.LoadFromJSONFile(additionalBackupFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to load additional backup file %s", additionalBackupFile)
+	}
+	if !pvs.Equals(*backupPsv2) {
+		return "", fmt.Errorf("additional backup file at %s has different content with %s", additionalBackupFile, filePathPrivValState)
+	}
+
+	fmt.Println("Additional backup file created successfully!")
+
+	return additionalBackupFile, nil
+}
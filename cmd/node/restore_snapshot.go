@@ -0,0 +1,405 @@
+package node
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/bcdevtools/node-management/services/node_process"
+	"github.com/bcdevtools/node-management/services/snapshot_archive"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/bcdevtools/node-management/validation"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	flagServiceName = "service-name"
+)
+
+func GetRestoreSnapshotCmd() *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "restore-snapshot [node_home] [archive]",
+		Short: "Restore node data from snapshot archive",
+		Long: "Restore node data from snapshot archive (.tar.lz4, .tar.zst, .tar.gz), compression is detected automatically.\n" +
+			"Existing data will be removed, " + fileNamePrivValState + " is kept.",
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			utils.MustNotUserRoot()
+
+			nodeHomeDirectory := strings.TrimSpace(args[0])
+			archiveFilePath := strings.TrimSpace(args[1])
+			backupPrivValStateJson, _ := cmd.Flags().GetString(flagBackupPrivValStateJson)
+			serviceName, _ := cmd.Flags().GetString(flagServiceName)
+
+			if exitWithError := restoreSnapshot(nodeHomeDirectory, archiveFilePath, backupPrivValStateJson, serviceName); exitWithError {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().String(flagBackupPrivValStateJson, "", "Backup "+fileNamePrivValState+" file path to prove you already backup it, required if the file in data is not empty")
+	cmd.Flags().String(flagServiceName, "", "Service name, if provided, the service will be stopped before restoring and started after")
+
+	return cmd
+}
+
+// restoreSnapshot removes existing data and extracts the archive into the data dir of the node home,
+// priv_validator_state.json is kept.
+func restoreSnapshot(nodeHomeDirectory, archiveFilePath, backupPrivValStateJson, serviceName string) (exitWithError bool) {
+	/**
+	Coding convention:
+	same as dump-snapshot, the service must be restarted on error, so usage of os.Exit() is avoided,
+	and the exitWithError flag is used to indicate the error.
+	*/
+
+	if err := validation.PossibleNodeHome(nodeHomeDirectory); err != nil {
+		utils.PrintlnStdErr("ERR: invalid node home directory:", err)
+		exitWithError = true
+		return
+	}
+
+	if serviceName != "" {
+		if err := validateServiceName(serviceName); err != nil {
+			utils.PrintlnStdErr("ERR:", err)
+			exitWithError = true
+			return
+		}
+		serviceName = strings.TrimSuffix(serviceName, ".service")
+	} else if err := requireNodeStopped(nodeHomeDirectory); err != nil {
+		// fail fast, the service is not stopped by this command
+		utils.PrintlnStdErr("ERR:", err)
+		exitWithError = true
+		return
+	}
+
+	archiveFile, err := os.Open(archiveFilePath)
+	if err != nil {
+		utils.PrintlnStdErr("ERR: failed to open archive:", err)
+		exitWithError = true
+		return
+	}
+	defer func() {
+		_ = archiveFile.Close()
+	}()
+	archiveFileInfo, err := archiveFile.Stat()
+	if err != nil {
+		utils.PrintlnStdErr("ERR: failed to check archive:", err)
+		exitWithError = true
+		return
+	}
+	if archiveFileInfo.IsDir() {
+		utils.PrintlnStdErr("ERR: archive is a directory:", archiveFilePath)
+		exitWithError = true
+		return
+	}
+
+	// detect before touching anything
+	header := make([]byte, 4)
+	if _, err := archiveFile.ReadAt(header, 0); err != nil {
+		utils.PrintlnStdErr("ERR: failed to read archive header:", err)
+		exitWithError = true
+		return
+	}
+	format, err := snapshot_archive.DetectFormat(header)
+	if err != nil {
+		utils.PrintlnStdErr("ERR:", err)
+		exitWithError = true
+		return
+	}
+	fmt.Println("INF: archive compression:", format)
+
+	configDir := path.Join(nodeHomeDirectory, "config")
+	dataDir := path.Join(nodeHomeDirectory, "data")
+
+	filePathPrivValState := path.Join(dataDir, fileNamePrivValState)
+	var originalPrivValStateBz []byte
+	var additionalBackupPrivStateJsonFilePath string
+	if _, exists, isDir, err := utils.FileInfo(filePathPrivValState); err != nil {
+		utils.PrintlnStdErr("ERR: failed to check", filePathPrivValState, ":", err)
+		exitWithError = true
+		return
+	} else if isDir {
+		utils.PrintlnStdErr("ERR:", filePathPrivValState, "is a directory")
+		exitWithError = true
+		return
+	} else if exists {
+		bz, err := os.ReadFile(filePathPrivValState)
+		if err != nil {
+			utils.PrintlnStdErr("ERR: failed to read file", filePathPrivValState, ":", err)
+			exitWithError = true
+			return
+		}
+
+		pvs := &types.PrivateValidatorState{}
+		if err := pvs.LoadFromJSON(bz); err != nil {
+			utils.PrintlnStdErr("ERR: failed to load", filePathPrivValState, ":", err)
+			exitWithError = true
+			return
+		}
+
+		if pvs.IsEmpty() {
+			fmt.Println("INF:", fileNamePrivValState, "is empty, location:", filePathPrivValState)
+		} else {
+			fmt.Println("INF:", fileNamePrivValState, "is not empty, location:", filePathPrivValState)
+			fmt.Println(string(bz))
+
+			additionalBackupPrivStateJsonFilePath, err = backupNonEmptyPrivValState(pvs, bz, backupPrivValStateJson, dataDir, configDir)
+			if err != nil {
+				utils.PrintlnStdErr("ERR:", err)
+				exitWithError = true
+				return
+			}
+		}
+
+		originalPrivValStateBz = bz
+	}
+
+	appMutex := types.NewAppMutex(nodeHomeDirectory, 4*time.Second)
+	if acquiredLock, err := appMutex.AcquireLockWL(); err != nil {
+		utils.PrintlnStdErr("ERR: failed to acquire lock single instance:", err)
+		exitWithError = true
+		return
+	} else if !acquiredLock {
+		utils.PrintlnStdErr("ERR: failed to acquire lock single instance")
+		exitWithError = true
+		return
+	}
+	defer func() {
+		appMutex.ReleaseLockWL()
+	}()
+
+	const sleepTime = 30 * time.Second
+	fmt.Println("INF: Going to remove existing data and restore from", archiveFilePath, "after", sleepTime)
+	fmt.Println("INF: Data directory:", dataDir)
+	if serviceName != "" {
+		fmt.Println("INF: Service to be stopped and started:", serviceName)
+	}
+	time.Sleep(sleepTime)
+
+	var stoppedService, touchedData bool
+	defer func() {
+		if !exitWithError || !stoppedService {
+			return
+		}
+		if touchedData {
+			// starting node with incomplete data is worse than keeping it stopped
+			utils.PrintlnStdErr("ERR: service", serviceName, "is kept stopped because data is incomplete")
+			return
+		}
+		fmt.Println("INF: restarting service before exit due to error")
+		ec := utils.LaunchApp("sudo", []string{"systemctl", "restart", serviceName})
+		if ec != 0 {
+			utils.PrintlnStdErr("ERR: failed to restart service", serviceName)
+		}
+	}()
+
+	if serviceName != "" {
+		fmt.Println("INF: stopping service")
+		ec := utils.LaunchApp("sudo", []string{"systemctl", "stop", serviceName})
+		if ec != 0 {
+			utils.PrintlnStdErr("ERR: failed to stop service")
+			exitWithError = true
+			return
+		}
+		time.Sleep(15 * time.Second) // wait completely shutdown
+		stoppedService = true
+	}
+
+	if additionalBackupPrivStateJsonFilePath != "" {
+		// double check content not changed, node might still be running
+		pvs := &types.PrivateValidatorState{}
+		pvsBackup := &types.PrivateValidatorState{}
+		if err := pvs.LoadFromJSONFile(filePathPrivValState); err != nil {
+			utils.PrintlnStdErr("ERR: failed to load", fileNamePrivValState, ":", err)
+			exitWithError = true
+			return
+		}
+		if err := pvsBackup.LoadFromJSONFile(additionalBackupPrivStateJsonFilePath); err != nil {
+			utils.PrintlnStdErr("ERR: failed to load backup file", additionalBackupPrivStateJsonFilePath, ":", err)
+			exitWithError = true
+			return
+		}
+		if !pvs.Equals(*pvsBackup) {
+			utils.PrintlnStdErr("ERR: content of", fileNamePrivValState, "is changed after additional backup file created, is the node still running?")
+			exitWithError = true
+			return
+		}
+	}
+
+	restorePrivValState := func() error {
+		_, exists, _, err := utils.FileInfo(filePathPrivValState)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check %s", filePathPrivValState)
+		}
+
+		if additionalBackupPrivStateJsonFilePath == "" {
+			if exists {
+				return nil
+			}
+			if originalPrivValStateBz == nil {
+				utils.PrintlnStdErr("WARN:", fileNamePrivValState, "does not exist, it will be created by the node")
+				return nil
+			}
+			// the original one is empty
+			return os.WriteFile(filePathPrivValState, originalPrivValStateBz, 0o644)
+		}
+
+		fmt.Println("INF: Restoring", fileNamePrivValState, "from backup file:", additionalBackupPrivStateJsonFilePath)
+
+		bz, err := os.ReadFile(additionalBackupPrivStateJsonFilePath)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read backup file %s", additionalBackupPrivStateJsonFilePath)
+		}
+		pvsBackup := &types.PrivateValidatorState{}
+		if err := pvsBackup.LoadFromJSON(bz); err != nil {
+			return errors.Wrapf(err, "failed to load backup file %s", additionalBackupPrivStateJsonFilePath)
+		}
+		if pvsBackup.IsEmpty() {
+			return fmt.Errorf("backup file is empty %s", additionalBackupPrivStateJsonFilePath)
+		}
+
+		if err := os.WriteFile(filePathPrivValState, bz, 0o644); err != nil {
+			utils.PrintlnStdErr(string(bz))
+			return errors.Wrapf(err, "failed to write file %s", fileNamePrivValState)
+		}
+
+		pvs := &types.PrivateValidatorState{}
+		if err := pvs.LoadFromJSONFile(filePathPrivValState); err != nil {
+			return errors.Wrapf(err, "failed to load %s for confirmation", fileNamePrivValState)
+		}
+		if !pvs.Equals(*pvsBackup) {
+			return fmt.Errorf("restored %s has different content with backup file %s", fileNamePrivValState, additionalBackupPrivStateJsonFilePath)
+		}
+		fmt.Println(pvs.Json())
+
+		fmt.Println("INF: Successfully restored", fileNamePrivValState)
+		return nil
+	}
+
+	if err := requireNodeStopped(nodeHomeDirectory); err != nil {
+		utils.PrintlnStdErr("ERR:", err)
+		exitWithError = true
+		return
+	}
+
+	fmt.Println("INF: removing existing data")
+	touchedData = true
+	if err := cleanDataDir(dataDir); err != nil {
+		utils.PrintlnStdErr("ERR: failed to remove existing data:", err)
+		if err := restorePrivValState(); err != nil {
+			utils.PrintlnStdErr("ERR: failed to restore", fileNamePrivValState, ":", err)
+		}
+		exitWithError = true
+		return
+	}
+
+	fmt.Println("INF: extracting", archiveFilePath)
+	progressPrinter := snapshot_archive.NewProgressPrinter(os.Stderr)
+	result, err := snapshot_archive.Extract(bufio.NewReaderSize(archiveFile, 4*1024*1024), snapshot_archive.ExtractOptions{
+		DestDir:      nodeHomeDirectory,
+		IncludePaths: []string{"./data"},
+		Format:       format,
+		Progress:     progressPrinter.Update,
+		TotalSize:    archiveFileInfo.Size(),
+	})
+	progressPrinter.Finish()
+	if err != nil {
+		utils.PrintlnStdErr("ERR: failed to extract archive:", err)
+		if err := restorePrivValState(); err != nil {
+			utils.PrintlnStdErr("ERR: failed to restore", fileNamePrivValState, ":", err)
+		}
+		exitWithError = true
+		return
+	}
+
+	fmt.Println("INF: extracted", result.Files, "files")
+	if len(result.Skipped) > 0 {
+		utils.PrintlnStdErr("WARN: skipped", len(result.Skipped), "entries outside data directory, eg:", result.Skipped[0])
+	}
+
+	if err := restorePrivValState(); err != nil {
+		utils.PrintlnStdErr("ERR: failed to restore", fileNamePrivValState, ":", err)
+		exitWithError = true
+		return
+	}
+	touchedData = false
+
+	if stoppedService {
+		fmt.Println("INF: starting service")
+		ec := utils.LaunchApp("sudo", []string{"systemctl", "start", serviceName})
+		if ec != 0 {
+			utils.PrintlnStdErr("ERR: failed to start service")
+			exitWithError = true
+			return
+		}
+	}
+
+	fmt.Println("INF: successfully restored snapshot into", dataDir)
+	return
+}
+
+// validateServiceName ensures the systemd service file exists, the service is stopped and started via systemctl.
+func validateServiceName(serviceName string) error {
+	if !utils.IsLinux() {
+		return fmt.Errorf("flag --%s is only supported on Linux", flagServiceName)
+	}
+	if strings.Contains(serviceName, "/") {
+		return fmt.Errorf("service name cannot contain path, provide name only")
+	}
+
+	expectedServiceFile := path.Join("/etc/systemd/system", strings.TrimSuffix(serviceName, ".service")+".service")
+	_, exists, _, err := utils.FileInfo(expectedServiceFile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check service file %s", expectedServiceFile)
+	}
+	if !exists {
+		return fmt.Errorf("expected service file does not exists [%s], correct service file name by flag --%s", expectedServiceFile, flagServiceName)
+	}
+	return nil
+}
+
+// cleanDataDir removes everything inside the data dir but keeps the dir itself, which might be a mount point or symlink.
+func cleanDataDir(dataDir string) error {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(dataDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(path.Join(dataDir, entry.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireNodeStopped returns error if the node of the home is running:
+// a process started with start argument and the home, or with files opened under data dir of the home.
+func requireNodeStopped(nodeHomeDirectory string) error {
+	absNodeHomeDirectory, err := filepath.Abs(nodeHomeDirectory)
+	if err != nil {
+		return errors.Wrap(err, "failed to get absolute path of node home directory")
+	}
+
+	target := node_process.Target{
+		Home: absNodeHomeDirectory,
+	}
+	candidates, err := target.Find()
+	if err != nil {
+		return errors.Wrap(err, "failed to check node process")
+	}
+	for _, candidate := range candidates {
+		if candidate.Matched {
+			return fmt.Errorf("node of the home is running, PID %d: %s, stop it before restoring", candidate.Pid, candidate.Reason)
+		}
+	}
+	return nil
+}
@@ -22,6 +22,10 @@ func GetNodeCommands() *cobra.Command {
 		GetPruneNodeDataCmd(),
 		GetStateSyncCmd(),
 		GetZipSnapshotCmd(),
+		GetRestoreSnapshotCmd(),
+		GetFetchSnapshotCmd(),
+		GetConfigCmd(),
+		GetGenesisCmd(),
 		GetAutoBackupPrivValidatorStateCmd(),
 		dump_snapshot.GetDumpSnapshotCmd(),
 	)
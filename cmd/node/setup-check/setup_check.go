@@ -1,45 +1,71 @@
 package setup_check
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/bcdevtools/node-management/constants"
+	setupcheck "github.com/bcdevtools/node-management/services/setup_check"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
+	"github.com/bcdevtools/node-management/validation"
 	"github.com/spf13/cobra"
-	"net/http"
 	"os"
+	"path"
+	"slices"
 	"strings"
-	"sync"
-	"time"
 )
 
 const (
-	flagType        = "type"
-	flagServiceFile = "service-file"
+	flagType            = "type"
+	flagServiceFile     = "service-file"
+	flagFix             = "fix"
+	flagDryRun          = "dry-run"
+	flagOutput          = "output"
+	flagPolicy          = "policy"
+	flagSentryNodeId    = "sentry-node-id"
+	flagValidatorNodeId = "validator-node-id"
+	flagRpc             = "rpc"
+	flagBinary          = "binary"
 )
 
-var waitGroup sync.WaitGroup
-
 func GetStepCheckCmd() *cobra.Command {
 	validTargetValues := strings.Join(types.AllNodeTypeNames(), "/")
 
 	var cmd = &cobra.Command{
-		Use:   "setup-check [node_home]",
-		Args:  cobra.ExactArgs(1),
+		Use:   "setup-check [node_home...]",
+		Args:  cobra.MinimumNArgs(1),
 		Short: "Check node setup",
+		Long: `Check node setup.
+Multiple node homes of the same node type can be checked at once, the exit code is of the worst result.`,
 		Run: func(cmd *cobra.Command, args []string) {
+			homes := args
+			// checkers of the checked homes, in order of homes
+			var checkers []*setupcheck.Checker
+
+			defer func() {
+				if r := recover(); r != nil {
+					exitWithCouldNotRun(homes, checkers, fmt.Sprintf("ERR: %v", r))
+				}
+			}()
+
+			output, _ := cmd.Flags().GetString(flagOutput)
+			if !slices.Contains(allOutputFormats, output) {
+				exitWithErrorMsgf(homes, "ERR: invalid --%s, can be either %s\n", flagOutput, strings.Join(allOutputFormats, "/"))
+				return
+			}
+			outputFormat = output
+			if outputFormat != outputText {
+				humanOutput = os.Stderr
+			}
+
 			utils.MustNotUserRoot()
 
-			fmt.Println("App version", constants.VERSION)
-			fmt.Println("NOTICE: always update to latest version for accurate check")
-			go checkLatestRelease()
-			time.Sleep(2 * time.Second)
+			fmt.Fprintln(humanOutput, "App version", constants.VERSION)
+			fmt.Fprintln(humanOutput, "NOTICE: always update to latest version for accurate check")
 
 			typeName, _ := cmd.Flags().GetString(flagType)
 			nodeType := types.NodeTypeFromString(typeName)
 			if nodeType == types.UnspecifiedNodeType {
-				exitWithErrorMsgf("ERR: Invalid node type, can be either %s\n", validTargetValues)
+				exitWithErrorMsgf(homes, "ERR: Invalid node type, can be either %s\n", validTargetValues)
 				return
 			}
 
@@ -47,42 +73,84 @@ func GetStepCheckCmd() *cobra.Command {
 
 			serviceFilePath, _ := cmd.Flags().GetString(flagServiceFile)
 			if requireServiceFileForValidatorOnLinux && serviceFilePath == "" {
-				exitWithErrorMsgf("ERR: --%s is required on Linux to check validator setting\n", flagServiceFile)
+				exitWithErrorMsgf(homes, "ERR: --%s is required on Linux to check validator setting\n", flagServiceFile)
 				return
 			} else if nodeType != types.ValidatorNode && serviceFilePath != "" {
-				exitWithErrorMsgf("ERR: remove flag \"--%s\", only be used for validator on Linux\n", flagServiceFile)
+				exitWithErrorMsgf(homes, "ERR: remove flag \"--%s\", only be used for validator on Linux\n", flagServiceFile)
+				return
+			} else if serviceFilePath != "" && len(homes) > 1 {
+				exitWithErrorMsgf(homes, "ERR: --%s can only be used with a single node home, check validator nodes one by one\n", flagServiceFile)
 				return
 			}
 
-			defer func() {
-				waitGroup.Wait()
-				if len(checkRecords) == 0 {
-					fmt.Println("All checks passed")
+			fix, _ := cmd.Flags().GetBool(flagFix)
+			dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+			if dryRun && !fix {
+				exitWithErrorMsgf(homes, "ERR: --%s can only be used with --%s\n", flagDryRun, flagFix)
+				return
+			}
+
+			sentryNodeIds, _ := cmd.Flags().GetStringSlice(flagSentryNodeId)
+			if len(sentryNodeIds) > 0 && nodeType != types.ValidatorNode {
+				exitWithErrorMsgf(homes, "ERR: remove flag \"--%s\", only be used for validator behind sentries\n", flagSentryNodeId)
+				return
+			}
+
+			validatorNodeId, _ := cmd.Flags().GetString(flagValidatorNodeId)
+			if validatorNodeId != "" && nodeType != types.SentryNode {
+				exitWithErrorMsgf(homes, "ERR: remove flag \"--%s\", only be used for sentry node\n", flagValidatorNodeId)
+				return
+			}
+
+			rpc, _ := cmd.Flags().GetString(flagRpc)
+			if rpc != "" && len(homes) > 1 {
+				exitWithErrorMsgf(homes, "ERR: --%s can only be used with a single node home\n", flagRpc)
+				return
+			}
+
+			binary, _ := cmd.Flags().GetString(flagBinary)
+			if binary != "" {
+				if rpc == "" {
+					exitWithErrorMsgf(homes, "ERR: --%s can only be used with --%s\n", flagBinary, flagRpc)
+					return
+				}
+				if err := validation.ValidateNodeBinary(binary); err != nil {
+					exitWithErrorMsgf(homes, "ERR: invalid --%s: %v\n", flagBinary, err)
 					return
 				}
+			}
 
-				printCheckRecords()
-				os.Exit(1)
-			}()
+			policyFilePath, _ := cmd.Flags().GetString(flagPolicy)
 
-			home := args[0]
-			checkHome(home)
+			latestRelease, err := setupcheck.FetchLatestRelease()
+			if err != nil {
+				utils.PrintfStdErr("ERR: failed to check latest release: %v\n", err)
+			}
 
-			checkHomeKeyring(home, nodeType == types.ValidatorNode)
-			checkHomeConfig(home, nodeType)
-			checkHomeData(home, nodeType)
-			if requireServiceFileForValidatorOnLinux {
-				checkServiceFileForValidatorOnLinux(home, serviceFilePath)
+			for _, home := range homes {
+				if len(homes) > 1 {
+					fmt.Fprintln(humanOutput, "INF: checking", home)
+				}
+				checkers = append(checkers, checkHome(setupcheck.Options{
+					Home:            home,
+					NodeType:        nodeType,
+					ServiceFilePath: serviceFilePath,
+					LatestRelease:   latestRelease,
+					SentryNodeIds:   sentryNodeIds,
+					ValidatorNodeId: validatorNodeId,
+					Rpc:             rpc,
+					Binary:          binary,
+				}, policyFilePath, fix, dryRun))
 			}
 
-			fmt.Println("NOTICE: some tasks need to be checked manually:")
+			fmt.Fprintln(humanOutput, "NOTICE: some tasks need to be checked manually:")
 
 			var countNotice int
 			printNotice := func(message, suggest string) {
 				countNotice++
-				fmt.Printf("%d. %s\n", countNotice, message)
+				fmt.Fprintf(humanOutput, "%d. %s\n", countNotice, message)
 				if suggest != "" {
-					fmt.Println("> " + suggest)
+					fmt.Fprintln(humanOutput, "> "+suggest)
 				}
 			}
 			printNotice("Ensure P2P port is open on firewall", "sudo ufw status")
@@ -94,49 +162,71 @@ func GetStepCheckCmd() *cobra.Command {
 			} else if nodeType == types.SnapshotNode {
 				printNotice("Ensure RPC port is open on firewall", "sudo ufw status")
 				printNotice("Ensure Rest-API, Json-RPC ports are not allowed from outside", "sudo ufw status")
+			} else if nodeType == types.SentryNode || nodeType == types.SeedNode {
+				printNotice("Ensure RPC, Rest-API, Json-RPC ports are not allowed from outside", "sudo ufw status")
+			}
+			if slices.ContainsFunc(checkers, func(checker *setupcheck.Checker) bool { return checker.Report().RemoteSigner }) {
+				printNotice("Ensure remote signer port (priv_validator_laddr) is allowed only from the signer hosts on firewall", "sudo ufw status")
+			}
+			if nodeType == types.ValidatorNode && len(sentryNodeIds) > 0 {
+				printNotice("Ensure P2P port is allowed only from the sentry nodes", "sudo ufw status")
 			}
 			printNotice("Check config.toml for 'fast_sync' and 'block_sync', if exists, set to true", "")
-			fmt.Println("WARN: after checked and fixed all issues, re-check again using this tool before running node, otherwise you probably miss something")
+			fmt.Fprintln(humanOutput, "WARN: after checked and fixed all issues, re-check again using this tool before running node, otherwise you probably miss something")
+
+			exitWithCheckResult(homes, checkers)
 		},
 	}
 
 	cmd.Flags().String(flagType, "", fmt.Sprintf("type of node to check, can be: %s", validTargetValues))
 	cmd.Flags().String(flagServiceFile, "", "path to the service file to check, required for validator node on Linux")
+	cmd.Flags().Bool(flagFix, false, "automatically apply safe fixes: permissions, app.toml/config.toml values and service file fields, then re-check. fixes requiring sudo or touching keys are printed and skipped")
+	cmd.Flags().Bool(flagDryRun, false, "used with --fix, print the fixes without applying")
+	cmd.Flags().StringSlice(flagSentryNodeId, nil, "node ID of the sentry node which the validator node is behind, can be provided multiple times, enable validator-behind-sentry checks")
+	cmd.Flags().String(flagValidatorNodeId, "", "node ID of the validator node behind the sentry node, to check private_peer_ids and unconditional_peer_ids")
+	cmd.Flags().String(flagRpc, "", "RPC of the running node, to verify the running node matches the config, eg: http://localhost:26657")
+	cmd.Flags().String(flagBinary, "", "used with --rpc, the chain binary to compare its version with app version of the running node")
+	cmd.Flags().String(flagPolicy, "", fmt.Sprintf("policy file to suppress rules and override thresholds, default is %s in the node home", setupcheck.PolicyFileName))
+	cmd.Flags().String(flagOutput, outputText, fmt.Sprintf("output format, can be: %s. Exit code: %d = passed, %d = fatal found, %d = warnings only, %d = could not run", strings.Join(allOutputFormats, "/"), exitCodePassed, exitCodeFatal, exitCodeWarningsOnly, exitCodeCouldNotRun))
 
 	return cmd
 }
 
-func checkLatestRelease() {
-	waitGroup.Add(1)
-	defer func() {
-		r := recover()
-		if r != nil {
-			utils.PrintfStdErr("ERR: failed to check latest release: %v\n", r)
-		}
-		waitGroup.Done()
-	}()
-
-	resp, err := http.Get("https://api.github.com/repos/bcdevtools/node-management/releases/latest")
+// checkHome checks the node home, applies the fixes then re-checks if requested.
+// The returned checker holds the report of the last run.
+func checkHome(opts setupcheck.Options, policyFilePath string, fix, dryRun bool) *setupcheck.Checker {
+	home := opts.Home
+
+	policyRequired := policyFilePath != ""
+	if !policyRequired {
+		policyFilePath = setupcheck.DefaultPolicyFilePath(home)
+	}
+	policy, loaded, err := setupcheck.LoadPolicy(policyFilePath, policyRequired, path.Join(home, "config", "genesis.json"))
 	if err != nil {
-		panic(err)
+		return setupcheck.NewFailedChecker(opts, err)
 	}
-
-	defer resp.Body.Close()
-
-	var release struct {
-		TagName string `json:"tag_name"`
+	if loaded {
+		fmt.Fprintln(humanOutput, "INF: loaded policy file", policyFilePath)
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(&release)
+	opts.Policy = &policy
+	checker, err := setupcheck.NewChecker(opts)
 	if err != nil {
-		panic(err)
+		return setupcheck.NewFailedChecker(opts, err)
 	}
 
-	latestTagName := strings.TrimPrefix(release.TagName, "v")
-	currentVersion := strings.TrimPrefix(constants.VERSION, "v")
-	if latestTagName != currentVersion {
-		warnRecord(
-			fmt.Sprintf("latest release is v%s, must use latest version to prevent bugs and new logics", latestTagName), "",
-		)
+	report := checker.Run()
+	if !fix || report.Error != "" {
+		return checker
 	}
+
+	fixResult, err := checker.ApplyFixes(dryRun)
+	printFixResult(fixResult)
+	if err != nil || fixResult.AppliedCount() < 1 {
+		return checker
+	}
+
+	reportAfterFixes := checker.Run()
+	printFixDiff(report.Findings, reportAfterFixes.Findings)
+	return checker
 }
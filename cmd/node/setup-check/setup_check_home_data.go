@@ -1,101 +0,0 @@
-package setup_check
-
-import (
-	"github.com/bcdevtools/node-management/types"
-	"github.com/bcdevtools/node-management/utils"
-	"path"
-)
-
-func checkHomeData(home string, nodeType types.NodeType) {
-	dataPath := path.Join(home, "data")
-	perm, exists, isDir, err := utils.FileInfo(dataPath)
-	if err != nil {
-		exitWithErrorMsgf("ERR: failed to check data directory at %s: %v\n", dataPath, err)
-		return
-	}
-	if !exists {
-		exitWithErrorMsgf("ERR: data directory does not exist: %s\n", dataPath)
-		return
-	}
-	if !isDir {
-		exitWithErrorMsgf("ERR: data is not a directory: %s\n", dataPath)
-		return
-	}
-
-	filePerm := types.FilePermFrom(perm)
-	if filePerm.Other.AnyPermission() {
-		fatalRecord("data directory is accessible by others", "chmod 700 "+dataPath)
-	}
-	if filePerm.Group.AnyPermission() {
-		fatalRecord("data directory is accessible by group", "chmod 700 "+dataPath)
-	}
-	if !filePerm.User.IsFullPermission() {
-		fatalRecord("data directory is not fully accessible by user", "chmod 700 "+dataPath)
-	}
-
-	privValidatorStateFilePath := path.Join(dataPath, "priv_validator_state.json")
-	perm, exists, isDir, err = utils.FileInfo(privValidatorStateFilePath)
-	if err != nil {
-		exitWithErrorMsgf("ERR: failed to check priv_validator_state.json file at %s: %v\n", privValidatorStateFilePath, err)
-		return
-	}
-	if !exists {
-		exitWithErrorMsgf("ERR: priv_validator_state.json file is missing")
-		return
-	}
-	if isDir {
-		exitWithErrorMsgf("ERR: priv_validator_state.json is a directory, it should be a file")
-		return
-	}
-	if perm != 0o600 {
-		fatalRecord("priv_validator_state.json has invalid permission", "chmod 600 "+privValidatorStateFilePath)
-	}
-
-	pvs := &types.PrivateValidatorState{}
-	err = pvs.LoadFromJSONFile(privValidatorStateFilePath)
-	if err != nil {
-		exitWithErrorMsgf("ERR: failed to load priv_validator_state.json file: %v\n", err)
-		return
-	}
-
-	if pvs.IsEmpty() {
-		if nodeType == types.ValidatorNode {
-			fatalRecord("priv_validator_state.json is empty", "can be ignored if this is a fresh validator node")
-		}
-	} else {
-		if nodeType == types.ValidatorNode {
-			if pvs.Height == "0" {
-				exitWithErrorMsg("ERR: priv_validator_state.json is not empty, but height is 0, trouble-shoot the issue")
-				return
-			}
-			if pvs.Signature == "" {
-				exitWithErrorMsg("ERR: priv_validator_state.json is not empty, but signature is empty, trouble-shoot the issue")
-				return
-			}
-			if pvs.SignBytes == "" {
-				exitWithErrorMsg("ERR: priv_validator_state.json is not empty, but signbytes is empty, trouble-shoot the issue")
-				return
-			}
-
-			for _, dbDirName := range []string{"application.db", "blockstore.db", "state.db"} {
-				dbDirPath := path.Join(dataPath, dbDirName)
-				perm, exists, isDir, err = utils.FileInfo(dbDirPath)
-				if err != nil {
-					exitWithErrorMsgf("ERR: failed to check %s directory at %s: %v\n", dbDirName, dbDirPath, err)
-					return
-				}
-				if !exists {
-					exitWithErrorMsgf("ERR: priv_validator_state.json is not empty but data dir seem empty, missing %s\n", dbDirPath)
-					return
-				}
-				if !isDir {
-					exitWithErrorMsgf("ERR: %s is not a directory: %s", dbDirName, dbDirPath)
-					return
-				}
-			}
-		} else {
-			exitWithErrorMsg("ERR: priv_validator_state.json is not empty, it should be empty on non-validator nodes, trouble-shoot the issue")
-			return
-		}
-	}
-}
@@ -0,0 +1,464 @@
+package setup_check
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	setupcheck "github.com/bcdevtools/node-management/services/setup_check"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+const (
+	outputText  = "text"
+	outputJson  = "json"
+	outputSarif = "sarif"
+	outputJunit = "junit"
+)
+
+var allOutputFormats = []string{outputText, outputJson, outputSarif, outputJunit}
+
+const (
+	exitCodePassed       = 0
+	exitCodeFatal        = 1
+	exitCodeWarningsOnly = 2
+	exitCodeCouldNotRun  = 3
+)
+
+var outputFormat = outputText
+
+// humanOutput is where the human-readable messages are printed to,
+// it is stderr when using machine-readable output so stdout contains only the report.
+var humanOutput io.Writer = os.Stdout
+
+// writeReport writes the reports in the machine-readable output format,
+// runError is the reason the check could not run, empty if the check completed.
+func writeReport(w io.Writer, homes []string, reports []setupcheck.Report, runError string) {
+	var err error
+	switch outputFormat {
+	case outputJson:
+		err = writeJsonReport(w, homes, reports, runError)
+	case outputSarif:
+		err = writeSarifReport(w, reports, runError)
+	case outputJunit:
+		err = writeJunitReport(w, homes, reports, runError)
+	default:
+		panic(fmt.Sprintf("unsupported output format %s", outputFormat))
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "ERR: failed to write %s report: %v\n", outputFormat, err)
+		os.Exit(exitCodeCouldNotRun)
+	}
+}
+
+// jsonReport is the report of a node home.
+type jsonReport struct {
+	Home       string               `json:"home,omitempty"`
+	Status     string               `json:"status"`
+	ExitCode   int                  `json:"exit_code"`
+	Error      string               `json:"error,omitempty"`
+	Records    []setupcheck.Finding `json:"records"`
+	Suppressed []setupcheck.Finding `json:"suppressed"`
+	// RemoteSigner indicates the validator node uses a remote signer
+	RemoteSigner bool `json:"remote_signer,omitempty"`
+}
+
+// jsonSingleReport is the output when checking a single node home.
+type jsonSingleReport struct {
+	Version string `json:"version"`
+	jsonReport
+}
+
+// jsonMultiReport is the output when checking multiple node homes.
+type jsonMultiReport struct {
+	Version  string       `json:"version"`
+	Status   string       `json:"status"`
+	ExitCode int          `json:"exit_code"`
+	Error    string       `json:"error,omitempty"`
+	Reports  []jsonReport `json:"reports"`
+}
+
+func statusOfExitCode(exitCode int) string {
+	switch exitCode {
+	case exitCodePassed:
+		return setupcheck.StatusPassed
+	case exitCodeFatal:
+		return setupcheck.StatusFatal
+	case exitCodeWarningsOnly:
+		return setupcheck.StatusWarningsOnly
+	default:
+		return setupcheck.StatusError
+	}
+}
+
+func toJsonReport(report setupcheck.Report) jsonReport {
+	return jsonReport{
+		Home:         report.Home,
+		Status:       report.Status,
+		ExitCode:     exitCodeOf(report),
+		Error:        report.Error,
+		Records:      report.Findings,
+		Suppressed:   report.Suppressed,
+		RemoteSigner: report.RemoteSigner,
+	}
+}
+
+func writeJsonReport(w io.Writer, homes []string, reports []setupcheck.Report, runError string) error {
+	var output any
+	if len(homes) > 1 {
+		multiReport := jsonMultiReport{
+			Version:  constants.VERSION,
+			ExitCode: checkResultExitCode(reports, runError),
+			Error:    runError,
+			Reports:  make([]jsonReport, 0, len(reports)),
+		}
+		multiReport.Status = statusOfExitCode(multiReport.ExitCode)
+		for _, report := range reports {
+			multiReport.Reports = append(multiReport.Reports, toJsonReport(report))
+		}
+		output = multiReport
+	} else {
+		singleReport := jsonSingleReport{
+			Version: constants.VERSION,
+			jsonReport: jsonReport{
+				Records:    []setupcheck.Finding{},
+				Suppressed: []setupcheck.Finding{},
+			},
+		}
+		if len(reports) > 0 {
+			singleReport.jsonReport = toJsonReport(reports[0])
+		} else if len(homes) > 0 {
+			singleReport.Home = homes[0]
+		}
+		if runError != "" {
+			singleReport.Error = runError
+		}
+		singleReport.ExitCode = checkResultExitCode(reports, runError)
+		singleReport.Status = statusOfExitCode(singleReport.ExitCode)
+		output = singleReport
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// SARIF 2.1.0, only the properties used by this report are defined.
+
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationUri string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ExitCode                   int                 `json:"exitCode"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level   string       `json:"level"`
+	Message sarifMessage `json:"message"`
+}
+
+type sarifResult struct {
+	RuleId       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations,omitempty"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   map[string]string  `json:"properties,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// writeSarifReport writes a run per node home.
+func writeSarifReport(w io.Writer, reports []setupcheck.Report, runError string) error {
+	allRules := setupcheck.AllRules()
+	ruleIndexes := make(map[string]int)
+	rules := make([]sarifRule, 0, len(allRules))
+	for i, rule := range allRules {
+		ruleIndexes[rule.Id] = i
+		rules = append(rules, sarifRule{
+			Id:               rule.Id,
+			ShortDescription: sarifMessage{Text: rule.Description},
+		})
+	}
+
+	newRun := func(findings, suppressed []setupcheck.Finding, runError string, exitCode int) sarifRun {
+		invocation := sarifInvocation{
+			ExecutionSuccessful: runError == "",
+			ExitCode:            exitCode,
+		}
+		if runError != "" {
+			invocation.ToolExecutionNotifications = []sarifNotification{{
+				Level:   "error",
+				Message: sarifMessage{Text: runError},
+			}}
+		}
+
+		results := make([]sarifResult, 0, len(findings)+len(suppressed))
+		for _, finding := range append(append([]setupcheck.Finding{}, findings...), suppressed...) {
+			result := sarifResult{
+				RuleId:    finding.RuleId,
+				RuleIndex: ruleIndexes[finding.RuleId],
+				Level:     "warning",
+				Message:   sarifMessage{Text: finding.Message},
+			}
+			if finding.Fatal() {
+				result.Level = "error"
+			}
+			if finding.Suggest != "" {
+				result.Message.Text += ". Suggest: " + finding.Suggest
+				result.Properties = map[string]string{"suggest": finding.Suggest}
+			}
+
+			var location sarifLocation
+			if finding.File != "" {
+				location.PhysicalLocation = &sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{Uri: fileUri(finding.File)},
+				}
+			}
+			if finding.Key != "" {
+				location.LogicalLocations = []sarifLogicalLocation{{
+					FullyQualifiedName: finding.Key,
+					Kind:               "member",
+				}}
+			}
+			if location.PhysicalLocation != nil || len(location.LogicalLocations) > 0 {
+				result.Locations = []sarifLocation{location}
+			}
+			if finding.SuppressReason != "" {
+				result.Suppressions = []sarifSuppression{{
+					Kind:          "external",
+					Justification: finding.SuppressReason,
+				}}
+			}
+
+			results = append(results, result)
+		}
+
+		return sarifRun{
+			Tool: sarifTool{
+				Driver: sarifDriver{
+					Name:           constants.BINARY_NAME,
+					Version:        constants.VERSION,
+					InformationUri: "https://github.com/bcdevtools/node-management",
+					Rules:          rules,
+				},
+			},
+			Invocations: []sarifInvocation{invocation},
+			Results:     results,
+		}
+	}
+
+	var runs []sarifRun
+	for _, report := range reports {
+		runs = append(runs, newRun(report.Findings, report.Suppressed, report.Error, exitCodeOf(report)))
+	}
+	if runError != "" {
+		runs = append(runs, newRun(nil, nil, runError, exitCodeCouldNotRun))
+	}
+
+	report := sarifReport{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    runs,
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func fileUri(file string) string {
+	if absFile, err := filepath.Abs(file); err == nil {
+		file = absFile
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(file)}).String()
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Type    string `xml:"type,attr"`
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJunitReport writes a test suite per node home, each finding is a failed test case, type of the failure is the severity.
+// Findings suppressed by the policy are written as skipped test cases.
+// When the check could not run, an error test case is added.
+func writeJunitReport(w io.Writer, homes []string, reports []setupcheck.Report, runError string) error {
+	testCaseName := func(finding setupcheck.Finding) string {
+		name := finding.Message
+		if finding.File != "" {
+			name = finding.File
+			if finding.Key != "" {
+				name += ": " + finding.Key
+			}
+		}
+		return name
+	}
+
+	newSuite := func(name string, findings, suppressed []setupcheck.Finding, runError string) junitTestSuite {
+		suite := junitTestSuite{
+			Name: name,
+		}
+
+		for _, finding := range findings {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				ClassName: finding.RuleId,
+				Name:      testCaseName(finding),
+				Failure: &junitFailure{
+					Type:    finding.Severity,
+					Message: finding.Message,
+					Text:    finding.Suggest,
+				},
+			})
+			suite.Failures++
+		}
+		for _, finding := range suppressed {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				ClassName: finding.RuleId,
+				Name:      testCaseName(finding),
+				Skipped: &junitSkipped{
+					Message: finding.SuppressReason,
+				},
+			})
+			suite.Skipped++
+		}
+
+		if runError != "" {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				ClassName: "setup-check",
+				Name:      "run",
+				Error: &junitFailure{
+					Type:    "error",
+					Message: runError,
+				},
+			})
+			suite.Errors++
+		} else if len(findings) == 0 {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				ClassName: "setup-check",
+				Name:      "all checks passed",
+			})
+		}
+		suite.Tests = len(suite.TestCases)
+		return suite
+	}
+
+	var suites []junitTestSuite
+	for _, report := range reports {
+		name := "setup-check"
+		if len(homes) > 1 {
+			name += " " + report.Home
+		}
+		suites = append(suites, newSuite(name, report.Findings, report.Suppressed, report.Error))
+	}
+	if runError != "" || len(suites) == 0 {
+		suites = append(suites, newSuite("setup-check", nil, nil, runError))
+	}
+
+	report := junitTestSuites{
+		Name:   "setup-check",
+		Suites: suites,
+	}
+	for _, suite := range suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
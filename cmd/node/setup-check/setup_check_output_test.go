@@ -0,0 +1,77 @@
+package setup_check
+
+import (
+	"bytes"
+	"encoding/json"
+	setupcheck "github.com/bcdevtools/node-management/services/setup_check"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	testFindingMoniker = setupcheck.Finding{RuleId: "config-moniker", Severity: "fatal", File: "config.toml", Key: "moniker", Message: "moniker is empty", Suggest: "set moniker"}
+	testFindingSeeds   = setupcheck.Finding{RuleId: "config-p2p-seeds", Severity: "warn", File: "config.toml", Key: "p2p.seeds", Message: "seeds is empty"}
+)
+
+func Test_checkResultExitCode(t *testing.T) {
+	require.Equal(t, exitCodePassed, checkResultExitCode(nil, ""))
+
+	reports := []setupcheck.Report{
+		{Home: "/a", Status: setupcheck.StatusPassed},
+		{Home: "/b", Status: setupcheck.StatusWarningsOnly, Findings: []setupcheck.Finding{testFindingSeeds}},
+	}
+	require.Equal(t, exitCodeWarningsOnly, checkResultExitCode(reports, ""))
+
+	reports[0] = setupcheck.Report{Home: "/a", Status: setupcheck.StatusFatal, Findings: []setupcheck.Finding{testFindingMoniker}}
+	require.Equal(t, exitCodeFatal, checkResultExitCode(reports, ""))
+
+	reports[1] = setupcheck.Report{Home: "/b", Status: setupcheck.StatusError, Error: "provided home directory does not exist"}
+	require.Equal(t, exitCodeCouldNotRun, checkResultExitCode(reports, ""))
+
+	require.Equal(t, exitCodeCouldNotRun, checkResultExitCode(nil, "invalid node type"))
+}
+
+func Test_writeJsonReport(t *testing.T) {
+	homes := []string{"/a"}
+	reports := []setupcheck.Report{{
+		Home:       "/a",
+		Status:     setupcheck.StatusFatal,
+		Findings:   []setupcheck.Finding{testFindingMoniker, testFindingSeeds},
+		Suppressed: []setupcheck.Finding{},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeJsonReport(&buf, homes, reports, ""))
+
+	var report jsonSingleReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Equal(t, "fatal", report.Status)
+	require.Equal(t, exitCodeFatal, report.ExitCode)
+	require.Equal(t, []setupcheck.Finding{testFindingMoniker, testFindingSeeds}, report.Records)
+
+	buf.Reset()
+	require.NoError(t, writeJsonReport(&buf, homes, reports, "provided home directory does not exist"))
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Equal(t, "error", report.Status)
+	require.Equal(t, exitCodeCouldNotRun, report.ExitCode)
+
+	homes = []string{"/a", "/b"}
+	reports = append(reports, setupcheck.Report{
+		Home:       "/b",
+		Status:     setupcheck.StatusPassed,
+		Findings:   []setupcheck.Finding{},
+		Suppressed: []setupcheck.Finding{},
+	})
+
+	buf.Reset()
+	require.NoError(t, writeJsonReport(&buf, homes, reports, ""))
+
+	var multiReport jsonMultiReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &multiReport))
+	require.Equal(t, "fatal", multiReport.Status)
+	require.Equal(t, exitCodeFatal, multiReport.ExitCode)
+	require.Len(t, multiReport.Reports, 2)
+	require.Equal(t, "/b", multiReport.Reports[1].Home)
+	require.Equal(t, "passed", multiReport.Reports[1].Status)
+	require.Equal(t, exitCodePassed, multiReport.Reports[1].ExitCode)
+}
@@ -2,51 +2,174 @@ package setup_check
 
 import (
 	"fmt"
+	setupcheck "github.com/bcdevtools/node-management/services/setup_check"
 	"github.com/bcdevtools/node-management/utils"
-	"sort"
+	"os"
+	"slices"
 	"strings"
 )
 
-func exitWithErrorMsg(error string) {
-	printCheckRecords()
-	utils.ExitWithErrorMsg(error)
+// exitWithErrorMsgf exits with code "could not run", used before any home is checked.
+func exitWithErrorMsgf(homes []string, format string, a ...any) {
+	exitWithCouldNotRun(homes, nil, strings.TrimSuffix(fmt.Sprintf(format, a...), "\n"))
 }
 
-func exitWithErrorMsgf(format string, a ...any) {
-	printCheckRecords()
-	utils.ExitWithErrorMsgf(format, a...)
+// exitWithCouldNotRun prints the error and the reports of the checked homes so far, then exit with code "could not run".
+func exitWithCouldNotRun(homes []string, checkers []*setupcheck.Checker, error string) {
+	reports := reportsOf(checkers)
+	if outputFormat == outputText {
+		printReports(homes, reports)
+	} else {
+		writeReport(os.Stdout, homes, reports, strings.TrimPrefix(error, "ERR: "))
+	}
+	utils.PrintlnStdErr()
+	utils.PrintlnStdErr(error)
+	os.Exit(exitCodeCouldNotRun)
+}
+
+// exitWithCheckResult prints the reports and exit with code based on the worst report.
+func exitWithCheckResult(homes []string, checkers []*setupcheck.Checker) {
+	reports := reportsOf(checkers)
+	if outputFormat == outputText {
+		printReports(homes, reports)
+	} else {
+		writeReport(os.Stdout, homes, reports, "")
+	}
+
+	os.Exit(checkResultExitCode(reports, ""))
 }
 
-func printCheckRecords() {
-	if len(checkRecords) == 0 {
-		return
+// reportsOf returns the report of the last run of each checker.
+func reportsOf(checkers []*setupcheck.Checker) []setupcheck.Report {
+	reports := make([]setupcheck.Report, 0, len(checkers))
+	for _, checker := range checkers {
+		reports = append(reports, checker.Report())
 	}
+	return reports
+}
 
-	utils.PrintlnStdErr("\nReports:")
+func exitCodeOf(report setupcheck.Report) int {
+	switch report.Status {
+	case setupcheck.StatusPassed:
+		return exitCodePassed
+	case setupcheck.StatusFatal:
+		return exitCodeFatal
+	case setupcheck.StatusWarningsOnly:
+		return exitCodeWarningsOnly
+	default:
+		return exitCodeCouldNotRun
+	}
+}
+
+// checkResultExitCode returns the exit code of the worst report: could not run, fatal, warnings only then passed.
+func checkResultExitCode(reports []setupcheck.Report, runError string) int {
+	if runError != "" {
+		return exitCodeCouldNotRun
+	}
 
-	sort.Slice(checkRecords, func(i, j int) bool {
-		left := checkRecords[i]
-		right := checkRecords[j]
-		if left.fatal && !right.fatal {
-			return true
+	severityOrder := []int{exitCodePassed, exitCodeWarningsOnly, exitCodeFatal, exitCodeCouldNotRun}
+	worst := exitCodePassed
+	for _, report := range reports {
+		exitCode := exitCodeOf(report)
+		if slices.Index(severityOrder, exitCode) > slices.Index(severityOrder, worst) {
+			worst = exitCode
 		}
-		if !left.fatal && right.fatal {
-			return false
+	}
+	return worst
+}
+
+func printReports(homes []string, reports []setupcheck.Report) {
+	for _, report := range reports {
+		if len(homes) > 1 {
+			utils.PrintlnStdErr("\n==>", report.Home)
 		}
-		return left.addedNo < right.addedNo
-	})
-
-	for idx, record := range checkRecords {
-		var sb strings.Builder
-		sb.WriteString("\n")
-		sb.WriteString(fmt.Sprintf("%2d. ", idx+1))
-		if record.fatal {
-			sb.WriteString("FATAL: ")
+		printReport(report)
+	}
+}
+
+func printReport(report setupcheck.Report) {
+	if len(report.Suppressed) > 0 {
+		utils.PrintlnStdErr("\nSuppressed by policy:")
+		for _, finding := range report.Suppressed {
+			utils.PrintfStdErr(" - [%s] %s\n   reason: %s\n", finding.RuleId, finding.Message, finding.SuppressReason)
 		}
-		sb.WriteString(record.message)
-		if record.suggest != "" {
-			sb.WriteString(fmt.Sprintf("\n > %s", record.suggest))
+	}
+
+	if len(report.Findings) > 0 {
+		utils.PrintlnStdErr("\nReports:")
+
+		for idx, finding := range report.Findings {
+			var sb strings.Builder
+			sb.WriteString("\n")
+			sb.WriteString(fmt.Sprintf("%2d. ", idx+1))
+			if finding.Fatal() {
+				sb.WriteString("FATAL: ")
+			}
+			sb.WriteString(finding.Message)
+			if finding.Suggest != "" {
+				sb.WriteString(fmt.Sprintf("\n > %s", finding.Suggest))
+			}
+			utils.PrintlnStdErr(sb.String())
 		}
-		utils.PrintlnStdErr(sb.String())
+	}
+
+	if report.Error != "" {
+		utils.PrintlnStdErr()
+		utils.PrintlnStdErr("ERR:", report.Error)
+	} else if len(report.Findings) == 0 {
+		fmt.Println("All checks passed")
+	}
+}
+
+// printFixResult prints outcome of each fix and the backup files.
+func printFixResult(result setupcheck.FixResult) {
+	for _, outcome := range result.Outcomes {
+		if outcome.SkipReason != "" {
+			fmt.Fprintf(humanOutput, "WARN: skipped fix, %s: %s\n", outcome.SkipReason, outcome.Description)
+		} else if result.DryRun {
+			fmt.Fprintln(humanOutput, "INF: (dry-run) would fix:", outcome.Description)
+		} else if outcome.Error != nil {
+			utils.PrintlnStdErr("ERR: failed to fix:", outcome.Description, ":", outcome.Error)
+		} else {
+			fmt.Fprintln(humanOutput, "INF: fixed:", outcome.Description)
+		}
+	}
+
+	for _, backupFilePath := range result.BackupFiles {
+		fmt.Fprintln(humanOutput, "INF: backup file:", backupFilePath)
+	}
+}
+
+// printFixDiff prints findings resolved and newly found after applying fixes.
+func printFixDiff(before, after []setupcheck.Finding) {
+	findingKey := func(finding setupcheck.Finding) string {
+		return finding.Message + "\n" + finding.Suggest
+	}
+
+	afterKeys := make(map[string]bool)
+	for _, finding := range after {
+		afterKeys[findingKey(finding)] = true
+	}
+	beforeKeys := make(map[string]bool)
+	var resolved []setupcheck.Finding
+	for _, finding := range before {
+		beforeKeys[findingKey(finding)] = true
+		if !afterKeys[findingKey(finding)] {
+			resolved = append(resolved, finding)
+		}
+	}
+	var found []setupcheck.Finding
+	for _, finding := range after {
+		if !beforeKeys[findingKey(finding)] {
+			found = append(found, finding)
+		}
+	}
+
+	fmt.Fprintf(humanOutput, "\nINF: re-checked after fixes: %d resolved, %d remaining, %d new\n", len(resolved), len(after)-len(found), len(found))
+	for _, finding := range resolved {
+		fmt.Fprintln(humanOutput, " - "+finding.Message)
+	}
+	for _, finding := range found {
+		fmt.Fprintln(humanOutput, " + "+finding.Message)
 	}
 }
@@ -1,7 +1,10 @@
 package node
 
 import (
+	"context"
 	"fmt"
+	"github.com/bcdevtools/node-management/services/rpc_client"
+	"github.com/bcdevtools/node-management/services/toml_editor"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
 	"github.com/bcdevtools/node-management/validation"
@@ -11,7 +14,6 @@ import (
 	"os/user"
 	"path"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -194,47 +196,18 @@ func GetStateSyncCmd() *cobra.Command {
 				return
 			}
 
-			var modernSed bool
-			launchSed := func(pattern string) {
-				args := []string{"-i.bak"}
-				if modernSed {
-					args = append(args, "-E")
-				} else {
-					args = append(args, "-e")
-				}
-				args = append(args, pattern, configFilePath)
-
-				ec := utils.LaunchApp("sed", args)
-				if ec != 0 {
-					utils.PrintlnStdErr("ERR: failed to launch sed to update config file")
-					utils.PrintlnStdErr("> sed " + strings.Join(args, " "))
-					os.Exit(ec)
-				}
-			}
-
-			modernSed = false
-			if seeds != "" {
-				launchSed(fmt.Sprintf("s/^seeds *=.*/seeds = \"%s\"/", seeds))
-				fmt.Println("INF: seeds updated in config file")
-			}
-			if newPeers != "" {
-				launchSed(fmt.Sprintf("s/^persistent_peers *=.*/persistent_peers = \"%s\"/", newPeers))
-				fmt.Println("INF: persistent_peers updated in config file")
-			}
-
 			rpc = strings.TrimSuffix(rpc, "/")
 
-			output, ec := utils.LaunchAppAndGetOutput("/bin/bash", []string{"-c", fmt.Sprintf("curl -s %s/block | jq -r .result.block.header.height", rpc)})
-			if ec != 0 {
-				utils.PrintlnStdErr(output)
-				utils.ExitWithErrorMsg("ERR: failed to get block height from rpc:", rpc)
-				return
-			}
-			blockHeight, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+			rpcClient := rpc_client.NewRpcClient(rpc, 30*time.Second).WithRetry(3, 5*time.Second)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+			latestBlock, err := rpcClient.Block(ctx, 0)
+			cancel()
 			if err != nil {
-				utils.ExitWithErrorMsg("ERR: failed to parse block height", output, "from rpc:", err)
+				utils.ExitWithErrorMsg("ERR: failed to get block height from rpc:", rpc, ":", err)
 				return
 			}
+			blockHeight := int64(latestBlock.Block.Header.Height)
 			if blockHeight > 7000 {
 				blockHeight = blockHeight - 2000
 			} else if blockHeight >= 500 {
@@ -243,20 +216,59 @@ func GetStateSyncCmd() *cobra.Command {
 
 			fmt.Println("Block height:", blockHeight)
 
-			output, ec = utils.LaunchAppAndGetOutput("/bin/bash", []string{"-c", fmt.Sprintf(`curl -s "%s/block?height=%d" | jq -r .result.block_id.hash`, rpc, blockHeight)})
-			if ec != 0 {
-				utils.PrintlnStdErr(output)
-				utils.ExitWithErrorMsg("ERR: failed to get block hash from rpc:", rpc)
+			ctx, cancel = context.WithTimeout(context.Background(), 3*time.Minute)
+			trustBlock, err := rpcClient.Block(ctx, blockHeight)
+			cancel()
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to get block hash from rpc:", rpc, ":", err)
 				return
 			}
-			trustHash := strings.TrimSpace(output)
+			trustHash := strings.TrimSpace(trustBlock.BlockID.Hash)
 			if !regexp.MustCompile(`^[A-F\d]{64}$`).MatchString(trustHash) {
 				utils.ExitWithErrorMsg("ERR: invalid block hash", trustHash, "from rpc")
 				return
 			}
 
-			modernSed = true
-			launchSed(`s|^(enable[[:space:]]+=[[:space:]]+).*$|\1true| ; s|^(rpc_servers[[:space:]]+=[[:space:]]+).*$|\1\"` + rpc + "," + rpc + `\"| ; s|^(trust_height[[:space:]]+=[[:space:]]+).*$|\1` + fmt.Sprintf("%d", blockHeight) + `| ; s|^(trust_hash[[:space:]]+=[[:space:]]+).*$|\1"` + trustHash + `"|`)
+			configToml, err := toml_editor.Load(configFilePath)
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to load config file:", err)
+				return
+			}
+			for _, err := range []error{
+				configToml.SetBool("statesync", "enable", true),
+				configToml.SetString("statesync", "rpc_servers", rpc+","+rpc),
+				configToml.SetInt64("statesync", "trust_height", blockHeight),
+				configToml.SetString("statesync", "trust_hash", trustHash),
+			} {
+				if err != nil {
+					utils.ExitWithErrorMsg("ERR: failed to update state sync config:", err)
+					return
+				}
+			}
+			if seeds != "" {
+				if err := configToml.SetString("p2p", "seeds", seeds); err != nil {
+					utils.ExitWithErrorMsg("ERR: failed to update seeds:", err)
+					return
+				}
+			}
+			if newPeers != "" {
+				if err := configToml.SetString("p2p", "persistent_peers", newPeers); err != nil {
+					utils.ExitWithErrorMsg("ERR: failed to update persistent_peers:", err)
+					return
+				}
+			}
+			backupFilePath, err := configToml.SaveWithBackup(configFilePath)
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to update config file:", err)
+				return
+			}
+			fmt.Println("INF: backup of config file:", backupFilePath)
+			if seeds != "" {
+				fmt.Println("INF: seeds updated in config file")
+			}
+			if newPeers != "" {
+				fmt.Println("INF: persistent_peers updated in config file")
+			}
 			fmt.Println("INF: trust_height, rpc_servers, trust_hash and enable are updated in config file")
 
 			startArgs := []string{
@@ -292,30 +304,30 @@ func GetStateSyncCmd() *cobra.Command {
 				utils.ExitWithErrorMsg("ERR: state sync expired")
 			}
 
+			stateSyncNodeRpcClient := rpc_client.NewRpcClient(stateSyncNodeRpc, 10*time.Second)
+			getStateSyncNodeStatus := func() (*rpc_client.ResultStatus, error) {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				return stateSyncNodeRpcClient.Status(ctx)
+			}
+
 		waitSync:
 			for {
 				ensureStateSyncNotExpired()
 
 				time.Sleep(30 * time.Second)
 
-				output, ec = utils.LaunchAppAndGetOutput("/bin/bash", []string{"-c", fmt.Sprintf("curl -s %s/status | jq -r .result.sync_info.catching_up", stateSyncNodeRpc)})
-				if ec != 0 {
-					utils.PrintlnStdErr("ERR: failed to get catching_up from rpc")
+				status, err := getStateSyncNodeStatus()
+				if err != nil {
+					utils.PrintlnStdErr("ERR: failed to get catching_up from rpc:", err)
 					continue
 				}
 
-				catchingUp := strings.ToLower(strings.TrimSpace(output))
-				switch catchingUp {
-				case "false":
+				if !status.SyncInfo.CatchingUp {
 					fmt.Println("INF: node is synced")
 					break waitSync
-				case "true":
-					fmt.Println("INF: node is catching up")
-					continue
-				default:
-					utils.PrintlnStdErr("ERR: invalid catching_up value from rpc:", catchingUp)
-					continue
 				}
+				fmt.Println("INF: node is catching up")
 			}
 
 			fmt.Println("INF: retry ensure node keep synced to prevent AppHash mismatch issue")
@@ -327,17 +339,12 @@ func GetStateSyncCmd() *cobra.Command {
 
 				time.Sleep(10 * time.Second)
 
-				output, ec = utils.LaunchAppAndGetOutput("/bin/bash", []string{"-c", fmt.Sprintf("curl -s %s/status | jq -r .result.sync_info.latest_block_height", stateSyncNodeRpc)})
-				if ec != 0 {
-					utils.PrintlnStdErr("ERR: failed to get latest_block_height from rpc")
-					continue
-				}
-
-				height, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+				status, err := getStateSyncNodeStatus()
 				if err != nil {
-					utils.PrintlnStdErr("ERR: failed to parse latest_block_height from rpc:", err)
+					utils.PrintlnStdErr("ERR: failed to get latest_block_height from rpc:", err)
 					continue
 				}
+				height := int64(status.SyncInfo.LatestBlockHeight)
 
 				if heightToCompare == 0 {
 					heightToCompare = height
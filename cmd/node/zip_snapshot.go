@@ -1,17 +1,33 @@
 package node
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/services/genesis"
+	"github.com/bcdevtools/node-management/services/rpc_client"
+	"github.com/bcdevtools/node-management/services/snapshot_archive"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"os"
-	"os/exec"
 	"path"
+	"runtime"
 	"strings"
 	"time"
 )
 
+const (
+	flagFormat  = "format"
+	flagLevel   = "level"
+	flagThreads = "threads"
+	flagExclude = "exclude"
+	flagHeight  = "height"
+)
+
 func GetZipSnapshotCmd() *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "zip-snapshot [node_home]",
@@ -23,6 +39,33 @@ func GetZipSnapshotCmd() *cobra.Command {
 			nodeHomeDirectory := strings.TrimSpace(args[0])
 			validateNodeHomeDirectory(nodeHomeDirectory)
 
+			strFormat, _ := cmd.Flags().GetString(flagFormat)
+			level, _ := cmd.Flags().GetInt(flagLevel)
+			threads, _ := cmd.Flags().GetInt(flagThreads)
+			excludedPaths, _ := cmd.Flags().GetStringSlice(flagExclude)
+			height, _ := cmd.Flags().GetInt64(flagHeight)
+
+			format, err := snapshot_archive.ParseFormat(strFormat)
+			if err != nil {
+				utils.ExitWithErrorMsgf("ERR: invalid --%s flag: %v\n", flagFormat, err)
+				return
+			}
+			if level == 0 {
+				level = format.DefaultLevel()
+			}
+			if err := format.ValidateLevel(level); err != nil {
+				utils.ExitWithErrorMsgf("ERR: invalid --%s flag: %v\n", flagLevel, err)
+				return
+			}
+			if threads < 1 {
+				utils.ExitWithErrorMsgf("ERR: --%s must be positive\n", flagThreads)
+				return
+			}
+			if height < 0 {
+				utils.ExitWithErrorMsgf("ERR: --%s must be positive\n", flagHeight)
+				return
+			}
+
 			dataDirPath := path.Join(nodeHomeDirectory, "data")
 			_, exists, isDir, err := utils.FileInfo(dataDirPath)
 			if err != nil {
@@ -75,34 +118,191 @@ func GetZipSnapshotCmd() *cobra.Command {
 				return
 			}
 
-			ec := utils.LaunchAppWithSetup(
-				"/bin/bash", []string{
-					"-c", fmt.Sprintf("tar --exclude %s --exclude %s -cvf - %s | lz4 - %s",
-						"./data/snapshots",
-						"./data/tx_index.db",
-						"./data",
-						path.Join(
-							workingDir,
-							fmt.Sprintf(
-								"snapshot_%s.tar.lz4",
-								utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime),
-							),
-						),
-					),
-				},
-				func(launchCmd *exec.Cmd) {
-					launchCmd.Dir = path.Dir(dataDirPath)
-					launchCmd.Stdin = os.Stdin
-					launchCmd.Stdout = os.Stdout
-					launchCmd.Stderr = os.Stderr
-				},
+			snapshotFilePath := path.Join(
+				workingDir,
+				fmt.Sprintf(
+					"snapshot_%s%s",
+					utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime),
+					format.FileExtension(),
+				),
 			)
-			if ec != 0 {
-				utils.ExitWithErrorMsg("ERR: failed to zip data dir")
+
+			manifest, err := prepareSnapshotManifest(nodeHomeDirectory, height)
+			if err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to prepare snapshot manifest:", err)
 				return
 			}
+			manifest.Compression = string(format)
+			manifest.ExcludedPaths = excludedPaths
+
+			result, err := zipDataDir(path.Dir(dataDirPath), snapshotFilePath, snapshot_archive.CreateOptions{
+				IncludePaths: []string{"./data"},
+				ExcludePaths: excludedPaths,
+				Format:       format,
+				Level:        level,
+				Threads:      threads,
+			})
+			if err != nil {
+				_ = os.Remove(snapshotFilePath + types.SnapshotPartialFileSuffix)
+				utils.ExitWithErrorMsg("ERR: failed to zip data dir:", err)
+				return
+			}
+
+			manifest.Sha256 = result.Sha256
+			manifest.UncompressedSize = result.UncompressedSize
+			manifest.CompressedSize = result.CompressedSize
+
+			manifestFilePath := types.GetSnapshotManifestFilePath(snapshotFilePath)
+			if err := manifest.SaveToJSONFile(manifestFilePath); err != nil {
+				utils.ExitWithErrorMsg("ERR: failed to write snapshot manifest:", err)
+				return
+			}
+
+			fmt.Println("INF: snapshot file:", snapshotFilePath)
+			fmt.Println("INF: snapshot manifest:", manifestFilePath)
+			fmt.Println("INF: sha256:", result.Sha256)
 		},
 	}
 
+	cmd.Flags().String(flagFormat, string(snapshot_archive.FormatLz4), fmt.Sprintf("compression format, one of %v", snapshot_archive.AllFormats))
+	cmd.Flags().Int(flagLevel, 0, "compression level, lz4: 1-9, zstd: 1-22, gzip: 1-9, default by format")
+	cmd.Flags().Int(flagThreads, runtime.NumCPU(), "number of threads used to compress, applied to lz4 and zstd")
+	cmd.Flags().Int64(flagHeight, 0, "height of the snapshot recorded in the manifest, required if the node of the home is not running to be queried")
+	cmd.Flags().StringSlice(flagExclude, []string{"./data/snapshots", "./data/tx_index.db"}, "paths to exclude, relative to node home, supports glob pattern")
+
 	return cmd
 }
+
+// prepareSnapshotManifest collects information of the snapshot from the home and app.toml, before zipping.
+// Chain ID is read from genesis.json. The height is the provided one, otherwise queried from the node RPC in config.toml,
+// response of a node other than this home (chain ID or moniker mismatch) is rejected,
+// eg: the dump home shares the RPC address with the live node. Error is returned if the height is unknown.
+// Other missing information are warned and left empty.
+func prepareSnapshotManifest(nodeHomeDirectory string, height int64) (types.SnapshotManifest, error) {
+	manifest := types.SnapshotManifest{
+		Height:    height,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	app := &types.AppToml{}
+	if bz, err := os.ReadFile(path.Join(nodeHomeDirectory, "config", "app.toml")); err != nil {
+		utils.PrintlnStdErr("WARN: failed to read app.toml, snapshot kind will not be available in the manifest:", err)
+	} else if err := toml.Unmarshal(bz, app); err != nil {
+		utils.PrintlnStdErr("WARN: failed to unmarshal app.toml, snapshot kind will not be available in the manifest:", err)
+	} else {
+		switch app.Pruning {
+		case constants.PruningNothing:
+			manifest.Kind = "archive"
+		case constants.PruningDefault:
+			manifest.Kind = "default"
+		default:
+			manifest.Kind = "pruned"
+		}
+	}
+
+	chainId, err := genesis.ReadChainId(path.Join(nodeHomeDirectory, "config", "genesis.json"))
+	if err != nil {
+		return manifest, errors.Wrap(err, "failed to read chain ID from genesis.json")
+	}
+	manifest.ChainID = chainId
+
+	queryNode := func() error {
+		configTomlFilePath := path.Join(nodeHomeDirectory, "config", "config.toml")
+		config := &types.ConfigToml{}
+		if bz, err := os.ReadFile(configTomlFilePath); err != nil {
+			return errors.Wrap(err, "failed to read config.toml")
+		} else if err := toml.Unmarshal(bz, config); err != nil {
+			return errors.Wrap(err, "failed to unmarshal config.toml")
+		}
+
+		rpc, err := types.ReadNodeRpcFromConfigToml(configTomlFilePath)
+		if err != nil {
+			return errors.Wrap(err, "failed to read node RPC from config.toml")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		rpcClient := rpc_client.NewRpcClient(rpc, 10*time.Second)
+
+		resultStatus, err := rpcClient.Status(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to query node status")
+		}
+		if resultStatus.NodeInfo.Network != chainId || resultStatus.NodeInfo.Moniker != config.Moniker {
+			return fmt.Errorf(
+				"node at %s is %s (%s), not this home %s (%s)",
+				rpc, resultStatus.NodeInfo.Moniker, resultStatus.NodeInfo.Network, config.Moniker, chainId,
+			)
+		}
+		if manifest.Height == 0 {
+			manifest.Height = int64(resultStatus.SyncInfo.LatestBlockHeight)
+		}
+		manifest.NodeVersion = resultStatus.NodeInfo.Version
+
+		resultAbciInfo, err := rpcClient.AbciInfo(ctx)
+		if err != nil {
+			utils.PrintlnStdErr("WARN: failed to query node abci info, app version will not be available in the manifest:", err)
+			return nil
+		}
+		manifest.AppVersion = resultAbciInfo.Response.Version
+		return nil
+	}
+
+	if err := queryNode(); err != nil {
+		utils.PrintlnStdErr("WARN: versions will not be available in the manifest:", err)
+	}
+
+	if manifest.Height < 1 {
+		return manifest, fmt.Errorf("height of the snapshot is unknown, the node of the home could not be queried, provide --%s", flagHeight)
+	}
+
+	fmt.Println("INF: snapshot of", manifest.ChainID, "at height", manifest.Height)
+
+	return manifest, nil
+}
+
+// zipDataDir archives the data dir into the output file, with progress bar.
+// The archive is written to the .partial file then renamed when completed, so an incomplete archive is never served.
+func zipDataDir(nodeHomeDirectory, outputFilePath string, opts snapshot_archive.CreateOptions) (*snapshot_archive.CreateResult, error) {
+	partialFilePath := outputFilePath + types.SnapshotPartialFileSuffix
+	outputFile, err := os.Create(partialFilePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create snapshot file")
+	}
+	defer func() {
+		_ = outputFile.Close()
+	}()
+
+	progressPrinter := snapshot_archive.NewProgressPrinter(os.Stderr)
+	opts.SourceDir = nodeHomeDirectory
+	opts.Progress = progressPrinter.Update
+
+	bufferedOutput := bufio.NewWriterSize(outputFile, 4*1024*1024)
+	result, err := snapshot_archive.Create(bufferedOutput, opts)
+	progressPrinter.Finish()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bufferedOutput.Flush(); err != nil {
+		return nil, errors.Wrap(err, "failed to write snapshot file")
+	}
+	if err := outputFile.Sync(); err != nil {
+		return nil, errors.Wrap(err, "failed to flush snapshot file")
+	}
+	if err := outputFile.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close snapshot file")
+	}
+
+	if err := os.Rename(partialFilePath, outputFilePath); err != nil {
+		return nil, errors.Wrap(err, "failed to rename snapshot file")
+	}
+	if dir, err := os.Open(path.Dir(outputFilePath)); err == nil {
+		// persist the rename
+		_ = dir.Sync()
+		_ = dir.Close()
+	}
+
+	return result, nil
+}
@@ -25,18 +25,6 @@ var rootCmd = &cobra.Command{
 				suggestion: "sudo apt install -y aria2",
 			})
 		}
-		if !utils.HasBinaryName("lz4") {
-			reports = append(reports, report{
-				message:    "Require `lz4` installed!",
-				suggestion: "sudo apt install snapd -y && sudo snap install lz4",
-			})
-		}
-		if !utils.HasBinaryName("jq") {
-			reports = append(reports, report{
-				message:    "Require `jq` installed!",
-				suggestion: "sudo apt install -y jq",
-			})
-		}
 		if !utils.HasBinaryName("ssh-keygen") {
 			reports = append(reports, report{
 				message:    "Require `ssh-keygen` installed!",
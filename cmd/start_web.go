@@ -1,11 +1,12 @@
 package cmd
 
 import (
+	"fmt"
 	_ "github.com/bcdevtools/node-management/client/statik"
 	"github.com/bcdevtools/node-management/services/web_server"
 	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
 	"github.com/bcdevtools/node-management/utils"
-	"github.com/bcdevtools/node-management/validation"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"strings"
 )
@@ -31,6 +32,18 @@ const (
 
 	flagSnapshotFilePath    = "snapshot-file"
 	flagSnapshotDownloadURL = "snapshot-download-url"
+
+	flagSnapshotDirPath         = "snapshot-dir"
+	flagSnapshotDownloadBaseURL = "snapshot-download-base-url"
+	flagSnapshotServe           = "snapshot-serve"
+	flagSnapshotBandwidth       = "snapshot-bandwidth-per-client"
+	flagTrustedProxies          = "trusted-proxies"
+
+	flagChainSlug    = "chain-slug"
+	flagChainHosts   = "chain-hosts"
+	flagChainProfile = "chain-profile"
+
+	flagConfig = "config"
 )
 
 const (
@@ -46,186 +59,151 @@ func GetStartWebCmd() *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   cmdStartWeb + " [node_home]",
 		Short: "Start Web service",
-		Args:  cobra.ExactArgs(1),
+		Long: fmt.Sprintf(`Start Web service.
+The chain of the provided node home is configured via flags.
+Additional chains can be served by the same process via --%s, each is a TOML file describing a chain.
+When serving multiple chains, each chain is routed by path prefix (slug) or Host header.
+Alternatively, everything can be configured via a TOML/YAML file using --%s, the config file is reloaded on SIGHUP.`, flagChainProfile, flagConfig),
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			utils.MustNotUserRoot()
 
-			nodeHomeDirectory := strings.TrimSpace(args[0])
 			port, _ := cmd.Flags().GetUint16(flagPort)
 			authorizationToken, _ := cmd.Flags().GetString(flagAuthorizationToken)
 			monitorDisks, _ := cmd.Flags().GetStringSlice(flagMonitorDisks)
 			debug, _ := cmd.Flags().GetBool(flagDebug)
-
 			brand, _ := cmd.Flags().GetString(flagBrand)
-			chainName, _ := cmd.Flags().GetString(flagChainName)
-			chainDescription, _ := cmd.Flags().GetString(flagChainDescription)
-			chainID, _ := cmd.Flags().GetString(flagChainID)
-			generalBinaryName, _ := cmd.Flags().GetString(flagGeneralBinaryName)
-			generalNodeHomeName, _ := cmd.Flags().GetString(flagGeneralNodeHomeName)
-
-			extResLogoUrl, _ := cmd.Flags().GetString(flagExtResLogoUrl)
-			extResFaviconUrl, _ := cmd.Flags().GetString(flagExtResFaviconUrl)
-			extResRpcUrl, _ := cmd.Flags().GetString(flagExtResRpcUrl)
-			extResRestUrl, _ := cmd.Flags().GetString(flagExtResRestUrl)
-			extResGrpcUrl, _ := cmd.Flags().GetString(flagExtResGrpcUrl)
-
-			snapshotFilePath, _ := cmd.Flags().GetString(flagSnapshotFilePath)
-			snapshotDownloadURL, _ := cmd.Flags().GetString(flagSnapshotDownloadURL)
-
-			err := validation.PossibleNodeHome(nodeHomeDirectory)
-			if err != nil {
-				utils.ExitWithErrorMsg("ERR: invalid node home directory:", err)
-				return
-			}
-
-			authorizationToken = strings.TrimSpace(authorizationToken)
-			if authorizationToken == "" {
-				utils.ExitWithErrorMsgf("ERR: authorization token is required, use --%s flag to set it\n", flagAuthorizationToken)
-				return
-			}
-
-			if monitorDisks == nil || len(monitorDisks) == 0 {
-				utils.ExitWithErrorMsgf("ERR: disks are required, use --%s flag to set it\n", flagMonitorDisks)
-				return
-			}
-			for _, disk := range monitorDisks {
-				monitorDisk := strings.TrimSpace(disk)
-				if !strings.HasPrefix(monitorDisk, "/") {
-					utils.ExitWithErrorMsgf("ERR: disk must be absolute path, correct the --%s flag\n", flagMonitorDisks)
+			chainProfiles, _ := cmd.Flags().GetStringArray(flagChainProfile)
+			snapshotBandwidth, _ := cmd.Flags().GetUint(flagSnapshotBandwidth)
+			trustedProxies, _ := cmd.Flags().GetStringSlice(flagTrustedProxies)
+			configFile, _ := cmd.Flags().GetString(flagConfig)
+
+			if configFile != "" {
+				if len(args) > 0 || len(chainProfiles) > 0 {
+					utils.ExitWithErrorMsgf("ERR: node home and --%s flag can not be used together with --%s flag\n", flagChainProfile, flagConfig)
 					return
 				}
-				if strings.HasPrefix(monitorDisk, "/dev") {
-					utils.ExitWithErrorMsgf("ERR: disk must be filesystem path, not device, correct the --%s flag\n", flagMonitorDisks)
-					return
+
+				loadConfigFile := func() (*webtypes.Config, error) {
+					cfg, err := webtypes.LoadConfigFromFile(configFile)
+					if err != nil {
+						return nil, err
+					}
+
+					if cfg.Port == 0 {
+						cfg.Port = defaultWebPort
+					}
+					if len(cfg.MonitorDisks) == 0 {
+						cfg.MonitorDisks = []string{"/"}
+					}
+					for i := range cfg.Chains {
+						if strings.TrimSpace(cfg.Chains[i].Brand) == "" {
+							cfg.Chains[i].Brand = brand
+						}
+					}
+
+					cfg.Normalize()
+					if err := cfg.Validate(); err != nil {
+						return nil, errors.Wrap(err, "invalid Web service config")
+					}
+
+					return cfg, nil
 				}
-				_, exists, isDir, err := utils.FileInfo(monitorDisk)
+
+				cfg, err := loadConfigFile()
 				if err != nil {
-					utils.ExitWithErrorMsgf("ERR: disk %s is invalid, correct the --%s flag\n", monitorDisk, flagMonitorDisks)
+					utils.ExitWithErrorMsgf("ERR: failed to load config file %s: %v\n", configFile, err)
 					return
 				}
-				if !exists {
-					utils.ExitWithErrorMsgf("ERR: disk %s does not exists, correct the --%s flag\n", monitorDisk, flagMonitorDisks)
-					return
-				}
-				if !isDir {
-					utils.ExitWithErrorMsgf(
-						"ERR: disk %s is not a directory, must be filesystem path, correct the --%s flag\n",
-						monitorDisk,
-						flagMonitorDisks,
-					)
-					return
-				}
-			}
 
-			brand = strings.TrimSpace(brand)
-			if brand == "" {
-				utils.ExitWithErrorMsgf("ERR: brand is required, use --%s flag to set it\n", flagBrand)
+				web_server.StartWebServer(*cfg, loadConfigFile)
 				return
 			}
 
-			chainName = strings.TrimSpace(chainName)
-			if chainName == "" {
-				utils.ExitWithErrorMsgf("ERR: chain name is required, use --%s flag to set it\n", flagChainName)
-				return
+			cfg := webtypes.Config{
+				Port:           port,
+				AuthorizeToken: authorizationToken,
+				MonitorDisks:   monitorDisks,
+				Debug:          debug,
+
+				SnapshotBandwidthPerClient: snapshotBandwidth,
+				TrustedProxies:             trustedProxies,
 			}
 
-			chainDescription = strings.TrimSpace(chainDescription)
+			if len(args) > 0 {
+				chainSlug, _ := cmd.Flags().GetString(flagChainSlug)
+				chainHosts, _ := cmd.Flags().GetStringSlice(flagChainHosts)
 
-			chainID = strings.TrimSpace(chainID)
-			if chainID == "" {
-				utils.ExitWithErrorMsgf("ERR: chain ID is required, use --%s flag to set it\n", flagChainID)
-				return
-			}
+				chainName, _ := cmd.Flags().GetString(flagChainName)
+				chainDescription, _ := cmd.Flags().GetString(flagChainDescription)
+				chainID, _ := cmd.Flags().GetString(flagChainID)
+				generalBinaryName, _ := cmd.Flags().GetString(flagGeneralBinaryName)
+				generalNodeHomeName, _ := cmd.Flags().GetString(flagGeneralNodeHomeName)
 
-			generalBinaryName = strings.TrimSpace(generalBinaryName)
-			if generalBinaryName == "" {
-				utils.ExitWithErrorMsgf("ERR: general binary name is required, use --%s flag to set it\n", flagGeneralBinaryName)
-				return
-			}
+				extResLogoUrl, _ := cmd.Flags().GetString(flagExtResLogoUrl)
+				extResFaviconUrl, _ := cmd.Flags().GetString(flagExtResFaviconUrl)
+				extResRpcUrl, _ := cmd.Flags().GetString(flagExtResRpcUrl)
+				extResRestUrl, _ := cmd.Flags().GetString(flagExtResRestUrl)
+				extResGrpcUrl, _ := cmd.Flags().GetString(flagExtResGrpcUrl)
 
-			generalNodeHomeName = strings.TrimSpace(generalNodeHomeName)
-			if generalNodeHomeName == "" {
-				utils.ExitWithErrorMsgf("ERR: general node home name is required, use --%s flag to set it\n", flagGeneralNodeHomeName)
-				return
-			}
-			if !strings.HasPrefix(generalNodeHomeName, ".") {
-				utils.ExitWithErrorMsgf("ERR: general node home name must starts with a dot, correct the --%s flag\n", flagGeneralNodeHomeName)
-				return
-			}
-			if strings.Contains(generalNodeHomeName, "/") {
-				utils.ExitWithErrorMsgf("ERR: general node home name must be name only, not a path, correct the --%s flag\n", flagGeneralNodeHomeName)
-				return
-			}
+				snapshotFilePath, _ := cmd.Flags().GetString(flagSnapshotFilePath)
+				snapshotDownloadURL, _ := cmd.Flags().GetString(flagSnapshotDownloadURL)
+				snapshotDirPath, _ := cmd.Flags().GetString(flagSnapshotDirPath)
+				snapshotDownloadBaseURL, _ := cmd.Flags().GetString(flagSnapshotDownloadBaseURL)
+				snapshotServe, _ := cmd.Flags().GetBool(flagSnapshotServe)
 
-			extResLogoUrl = strings.TrimSpace(extResLogoUrl)
-			extResFaviconUrl = strings.TrimSpace(extResFaviconUrl)
-			extResRpcUrl = strings.TrimSpace(extResRpcUrl)
-			extResRestUrl = strings.TrimSpace(extResRestUrl)
-			extResGrpcUrl = strings.TrimSpace(extResGrpcUrl)
+				cfg.Chains = append(cfg.Chains, webtypes.ChainConfig{
+					Slug:     chainSlug,
+					Hosts:    chainHosts,
+					NodeHome: args[0],
 
-			if extResLogoUrl == "" && extResFaviconUrl != "" {
-				extResLogoUrl = extResFaviconUrl
-			}
+					Brand: brand,
 
-			if extResRpcUrl == "" {
-				utils.ExitWithErrorMsgf("ERR: external resource RPC URL is required, use --%s flag to set it\n", flagExtResRpcUrl)
-				return
-			}
-			if !strings.Contains(extResRpcUrl, "://") || strings.Contains(extResRpcUrl, "localhost") || strings.Contains(extResRpcUrl, "127.0.0.1") {
-				utils.ExitWithErrorMsgf("ERR: external resource RPC URL must contains protocol, not localhost, correct the --%s flag\n", flagExtResRpcUrl)
-				return
-			}
-			if extResRestUrl == "" {
-				utils.ExitWithErrorMsgf("ERR: external resource REST URL is required, use --%s flag to set it\n", flagExtResRestUrl)
-				return
-			}
-			if !strings.Contains(extResRestUrl, "://") || strings.Contains(extResRestUrl, "localhost") || strings.Contains(extResRestUrl, "127.0.0.1") {
-				utils.ExitWithErrorMsgf("ERR: external resource REST URL must contains protocol, not localhost, correct the --%s flag\n", flagExtResRestUrl)
-				return
+					ChainName:           chainName,
+					ChainDescription:    chainDescription,
+					ChainID:             chainID,
+					GeneralBinaryName:   generalBinaryName,
+					GeneralNodeHomeName: generalNodeHomeName,
+
+					ExternalResourceLogoUrl:    extResLogoUrl,
+					ExternalResourceFaviconUrl: extResFaviconUrl,
+					ExternalResourceRpcUrl:     extResRpcUrl,
+					ExternalResourceRestUrl:    extResRestUrl,
+					ExternalResourceGrpcUrl:    extResGrpcUrl,
+
+					SnapshotFilePath:    snapshotFilePath,
+					SnapshotDownloadURL: snapshotDownloadURL,
+
+					SnapshotDirPath:         snapshotDirPath,
+					SnapshotDownloadBaseURL: snapshotDownloadBaseURL,
+					SnapshotServe:           snapshotServe,
+				})
 			}
-			if extResGrpcUrl != "" {
-				if !strings.Contains(extResGrpcUrl, "://") || strings.Contains(extResGrpcUrl, "localhost") || strings.Contains(extResGrpcUrl, "127.0.0.1") {
-					utils.ExitWithErrorMsgf("ERR: external resource gRPC URL must contains protocol, not localhost, correct the --%s flag\n", flagExtResGrpcUrl)
+
+			for _, chainProfile := range chainProfiles {
+				chainConfig, err := webtypes.LoadChainConfigFromTomlFile(chainProfile)
+				if err != nil {
+					utils.ExitWithErrorMsgf("ERR: failed to load chain profile %s: %v\n", chainProfile, err)
 					return
 				}
+				if strings.TrimSpace(chainConfig.Brand) == "" {
+					chainConfig.Brand = brand
+				}
+				cfg.Chains = append(cfg.Chains, *chainConfig)
 			}
 
-			snapshotFilePath = strings.TrimSpace(snapshotFilePath)
-			if snapshotFilePath == "" {
-				utils.ExitWithErrorMsgf("ERR: snapshot file path is required, use --%s flag to set it\n", flagSnapshotFilePath)
+			if len(cfg.Chains) == 0 {
+				utils.ExitWithErrorMsgf("ERR: node home or at least one chain profile is required, use --%s flag to set it\n", flagChainProfile)
 				return
 			}
 
-			snapshotDownloadURL = strings.TrimSuffix(strings.TrimSpace(snapshotDownloadURL), "/")
-			if snapshotDownloadURL == "" {
-				utils.ExitWithErrorMsgf("ERR: snapshot download URL is required, use --%s flag to set it\n", flagSnapshotDownloadURL)
+			cfg.Normalize()
+			if err := cfg.Validate(); err != nil {
+				utils.ExitWithErrorMsg("ERR: invalid Web service config:", err)
 				return
 			}
 
-			web_server.StartWebServer(webtypes.Config{
-				Port:           port,
-				AuthorizeToken: authorizationToken,
-				MonitorDisks:   monitorDisks,
-				NodeHome:       nodeHomeDirectory,
-				Debug:          debug,
-
-				Brand: brand,
-
-				ChainName:           chainName,
-				ChainDescription:    chainDescription,
-				ChainID:             chainID,
-				GeneralBinaryName:   generalBinaryName,
-				GeneralNodeHomeName: generalNodeHomeName,
-
-				ExternalResourceLogoUrl:    extResLogoUrl,
-				ExternalResourceFaviconUrl: extResFaviconUrl,
-				ExternalResourceRpcUrl:     extResRpcUrl,
-				ExternalResourceRestUrl:    extResRestUrl,
-				ExternalResourceGrpcUrl:    extResGrpcUrl,
-
-				SnapshotFilePath:    snapshotFilePath,
-				SnapshotDownloadURL: snapshotDownloadURL,
-			})
+			web_server.StartWebServer(cfg, nil)
 		},
 	}
 
@@ -249,6 +227,16 @@ func GetStartWebCmd() *cobra.Command {
 
 	cmd.Flags().String(flagSnapshotFilePath, "", "snapshot local file path")
 	cmd.Flags().String(flagSnapshotDownloadURL, "", "snapshot download URL")
+	cmd.Flags().String(flagSnapshotDirPath, "", "directory contains snapshot files and their manifest, to be served as snapshot catalog")
+	cmd.Flags().String(flagSnapshotDownloadBaseURL, "", "base URL to download snapshot files in the snapshot directory, eg: https://example.com/snapshots")
+	cmd.Flags().Bool(flagSnapshotServe, false, "serve snapshot files by this Web service, supports resume and multi-connection downloads, download URLs are not required")
+	cmd.Flags().Uint(flagSnapshotBandwidth, 0, "download bandwidth limit per client IP of the built-in snapshot serving, in MB/s, 0 means unlimited")
+	cmd.Flags().StringSlice(flagTrustedProxies, nil, "IPs or CIDRs of the reverse proxies trusted to set X-Forwarded-For and X-Forwarded-Proto, default none")
+
+	cmd.Flags().String(flagChainSlug, "", "path prefix to serve the chain of the provided node home at, eg: cosmoshub → /cosmoshub/..., required when serving multiple chains")
+	cmd.Flags().StringSlice(flagChainHosts, nil, "Host headers to serve the chain of the provided node home at, eg: cosmos.example.com")
+	cmd.Flags().StringArray(flagChainProfile, nil, "TOML file describing an additional chain to serve, can be provided multiple times")
+	cmd.Flags().String(flagConfig, "", "TOML/YAML config file of the Web service and the chains, reloaded on SIGHUP. Other flags are ignored except --"+flagBrand+" as default brand")
 
 	return cmd
 }
@@ -1,5 +1,6 @@
 package constants
 
 const (
-	GinConfig = BINARY_NAME + "-gin-config"
+	GinConfig     = BINARY_NAME + "-gin-config"
+	GinChainRoute = BINARY_NAME + "-gin-chain-route"
 )
@@ -0,0 +1,72 @@
+package alert
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	"os"
+	"strings"
+	"time"
+)
+
+// EventType is the type of event to be notified.
+type EventType string
+
+const (
+	// EventFatal is fired when the state file decreased or signed differently at the same height/round/step, the node is being killed
+	EventFatal EventType = "fatal"
+	// EventRestoreSnapshot is fired when the state file is empty, possibly restoring snapshot, the node is being killed until restored
+	EventRestoreSnapshot EventType = "restore-snapshot"
+	// EventNoProcessToKill is fired when the node process to be killed is not found
+	EventNoProcessToKill EventType = "no-process-to-kill"
+)
+
+var allEventTypes = []EventType{EventFatal, EventRestoreSnapshot, EventNoProcessToKill}
+
+func (t EventType) validate() error {
+	for _, eventType := range allEventTypes {
+		if t == eventType {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown event type \"%s\", supported: %v", t, allEventTypes)
+}
+
+// Event is the notification content, the generic webhook receives it as JSON.
+type Event struct {
+	Type    EventType `json:"type"`
+	Time    time.Time `json:"time"`
+	Host    string    `json:"host"`
+	Home    string    `json:"home"`
+	Message string    `json:"message"`
+	// Details is the additional content, eg: previous and recent states
+	Details string `json:"details,omitempty"`
+}
+
+// NewEvent creates an event of the node home, at the current time on this host.
+func NewEvent(eventType EventType, home, message, details string) Event {
+	host, _ := os.Hostname()
+	return Event{
+		Type:    eventType,
+		Time:    time.Now().UTC(),
+		Host:    host,
+		Home:    home,
+		Message: message,
+		Details: details,
+	}
+}
+
+// Text is the human-readable content of the event, used by chat sinks.
+func (e Event) Text() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("[%s] %s on %s\n", constants.BINARY_NAME, strings.ToUpper(string(e.Type)), e.Host))
+	sb.WriteString(e.Message)
+	sb.WriteString("\nHome: ")
+	sb.WriteString(e.Home)
+	sb.WriteString("\nTime: ")
+	sb.WriteString(e.Time.Format(time.RFC3339))
+	if e.Details != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(e.Details)
+	}
+	return sb.String()
+}
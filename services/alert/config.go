@@ -0,0 +1,156 @@
+package alert
+
+import (
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Config is the content of the alert config file.
+//
+//	spool-dir = "/home/user/.nmngd-alert-spool"
+//	max-retries = 5
+//	retry-delay = "5s"
+//	events = ["fatal", "restore-snapshot", "no-process-to-kill"]
+//
+//	[[webhook]]
+//	url = "https://example.com/hook"
+//
+//	[[telegram]]
+//	bot-token = "123:ABC"
+//	chat-id = "-100123"
+//
+//	[[slack]]
+//	webhook-url = "https://hooks.slack.com/services/x/y/z"
+//
+//	[[discord]]
+//	webhook-url = "https://discord.com/api/webhooks/x/y"
+type Config struct {
+	// SpoolDir keeps the events failed to be delivered after retries, re-sent periodically in background
+	SpoolDir   string `toml:"spool-dir"`
+	MaxRetries int    `toml:"max-retries"`
+	RetryDelay string `toml:"retry-delay"`
+	// Events to be notified, default all
+	Events []EventType `toml:"events"`
+
+	Webhook  []WebhookConfig  `toml:"webhook"`
+	Telegram []TelegramConfig `toml:"telegram"`
+	Slack    []SlackConfig    `toml:"slack"`
+	Discord  []DiscordConfig  `toml:"discord"`
+}
+
+type WebhookConfig struct {
+	Url string `toml:"url"`
+}
+
+type TelegramConfig struct {
+	// ApiUrl overrides the Telegram bot API, eg: local Bot API server
+	ApiUrl   string `toml:"api-url"`
+	BotToken string `toml:"bot-token"`
+	ChatId   string `toml:"chat-id"`
+}
+
+type SlackConfig struct {
+	WebhookUrl string `toml:"webhook-url"`
+}
+
+type DiscordConfig struct {
+	WebhookUrl string `toml:"webhook-url"`
+}
+
+// LoadConfig reads the alert config file.
+func LoadConfig(filePath string) (Config, error) {
+	var config Config
+
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return config, errors.Wrapf(err, "failed to read alert config file %s", filePath)
+	}
+
+	decoder := toml.NewDecoder(strings.NewReader(string(bz)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return config, errors.Wrapf(err, "failed to decode alert config file %s", filePath)
+	}
+
+	return config, nil
+}
+
+// sinks returns the configured sinks, after validating.
+func (c Config) sinks() ([]Sink, error) {
+	var sinks []Sink
+
+	for _, webhook := range c.Webhook {
+		if err := validateUrl(webhook.Url); err != nil {
+			return nil, errors.Wrap(err, "webhook")
+		}
+		sinks = append(sinks, webhookSink{url: webhook.Url})
+	}
+	for _, telegram := range c.Telegram {
+		if telegram.BotToken == "" || telegram.ChatId == "" {
+			return nil, fmt.Errorf("telegram: require bot-token and chat-id")
+		}
+		apiUrl := defaultTelegramApiUrl
+		if telegram.ApiUrl != "" {
+			if err := validateUrl(telegram.ApiUrl); err != nil {
+				return nil, errors.Wrap(err, "telegram")
+			}
+			apiUrl = strings.TrimSuffix(telegram.ApiUrl, "/")
+		}
+		sinks = append(sinks, telegramSink{apiUrl: apiUrl, botToken: telegram.BotToken, chatId: telegram.ChatId})
+	}
+	for _, slack := range c.Slack {
+		if err := validateUrl(slack.WebhookUrl); err != nil {
+			return nil, errors.Wrap(err, "slack")
+		}
+		sinks = append(sinks, slackSink{webhookUrl: slack.WebhookUrl})
+	}
+	for _, discord := range c.Discord {
+		if err := validateUrl(discord.WebhookUrl); err != nil {
+			return nil, errors.Wrap(err, "discord")
+		}
+		sinks = append(sinks, discordSink{webhookUrl: discord.WebhookUrl})
+	}
+
+	return sinks, nil
+}
+
+func (c Config) retryDelay() (time.Duration, error) {
+	if c.RetryDelay == "" {
+		return 5 * time.Second, nil
+	}
+	retryDelay, err := time.ParseDuration(c.RetryDelay)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid retry-delay")
+	}
+	if retryDelay < 0 {
+		return 0, fmt.Errorf("retry-delay must not be negative")
+	}
+	return retryDelay, nil
+}
+
+func (c Config) validate() error {
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("max-retries must not be negative")
+	}
+	if c.SpoolDir != "" && !filepath.IsAbs(c.SpoolDir) {
+		return fmt.Errorf("spool-dir must be absolute path")
+	}
+	for _, eventType := range c.Events {
+		if err := eventType.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateUrl(url string) error {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return fmt.Errorf("invalid URL, must be http:// or https://")
+	}
+	return nil
+}
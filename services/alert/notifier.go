@@ -0,0 +1,285 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/pkg/errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const sendTimeout = 15 * time.Second
+
+const (
+	// minSpoolFlushInterval is the interval of flushing the spool when retry-delay is shorter
+	minSpoolFlushInterval = time.Second
+	// maxSpoolFlushInterval caps the backoff of flushing the spool while the sinks are down
+	maxSpoolFlushInterval = 10 * time.Minute
+)
+
+// Notifier delivers events to all the sinks asynchronously, so the caller, eg: killing the node, is never blocked.
+// Each sink is retried independently, events failed after retries are written into the spool directory.
+type Notifier struct {
+	sinks      []Sink
+	events     map[EventType]bool // nil means all
+	maxRetries int
+	retryDelay time.Duration
+	spoolDir   string
+	httpClient *http.Client
+
+	wg sync.WaitGroup
+}
+
+// spooledEvent is the content of a file in the spool directory.
+type spooledEvent struct {
+	Sink  string `json:"sink"`
+	Event Event  `json:"event"`
+}
+
+func NewNotifier(config Config) (*Notifier, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	sinks, err := config.sinks()
+	if err != nil {
+		return nil, err
+	}
+	retryDelay, err := config.retryDelay()
+	if err != nil {
+		return nil, err
+	}
+
+	n := &Notifier{
+		sinks:      sinks,
+		maxRetries: config.MaxRetries,
+		retryDelay: retryDelay,
+		spoolDir:   config.SpoolDir,
+		httpClient: &http.Client{
+			Timeout: sendTimeout,
+		},
+	}
+	if len(config.Events) > 0 {
+		n.events = make(map[EventType]bool)
+		for _, eventType := range config.Events {
+			n.events[eventType] = true
+		}
+	}
+
+	if n.spoolDir != "" {
+		if err := os.MkdirAll(n.spoolDir, 0o700); err != nil {
+			return nil, errors.Wrap(err, "failed to create spool directory")
+		}
+	}
+
+	return n, nil
+}
+
+// Enabled returns true if any sink is configured.
+func (n *Notifier) Enabled() bool {
+	return n != nil && len(n.sinks) > 0
+}
+
+// Describe returns the configured sinks.
+func (n *Notifier) Describe() string {
+	if !n.Enabled() {
+		return "none"
+	}
+	var names []string
+	for i := range n.sinks {
+		names = append(names, n.sinkId(i))
+	}
+	return strings.Join(names, ", ")
+}
+
+// Notify sends the event to all the sinks in background. It is safe to call on nil Notifier.
+func (n *Notifier) Notify(event Event) {
+	if !n.Enabled() {
+		return
+	}
+	if n.events != nil && !n.events[event.Type] {
+		return
+	}
+
+	for i := range n.sinks {
+		n.wg.Add(1)
+		go func(i int) {
+			defer n.wg.Done()
+			n.sendWithRetry(i, event)
+		}(i)
+	}
+}
+
+// Wait waits for the events being sent, including retries.
+func (n *Notifier) Wait() {
+	if n == nil {
+		return
+	}
+	n.wg.Wait()
+}
+
+func (n *Notifier) sendWithRetry(sinkIdx int, event Event) {
+	sink := n.sinks[sinkIdx]
+
+	var err error
+	for attempt := 0; attempt <= n.maxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(n.retryDelay)
+		}
+
+		err = n.send(sink, event)
+		if err == nil {
+			fmt.Println("INF: sent", event.Type, "alert via", n.sinkId(sinkIdx))
+			return
+		}
+		utils.PrintfStdErr("ERR: failed to send %s alert via %s, attempt %d: %v\n", event.Type, n.sinkId(sinkIdx), attempt+1, err)
+	}
+
+	if n.spoolDir == "" {
+		return
+	}
+	spoolFilePath, err := n.spool(sinkIdx, event)
+	if err != nil {
+		utils.PrintlnStdErr("ERR: failed to spool", event.Type, "alert of", n.sinkId(sinkIdx), ":", err)
+		return
+	}
+	fmt.Println("INF: spooled", event.Type, "alert of", n.sinkId(sinkIdx), "to", spoolFilePath)
+}
+
+func (n *Notifier) send(sink Sink, event Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	return sink.Send(ctx, n.httpClient, event)
+}
+
+// sinkId identifies the sink in spool files, eg: telegram-0.
+func (n *Notifier) sinkId(sinkIdx int) string {
+	return fmt.Sprintf("%s-%d", n.sinks[sinkIdx].Name(), sinkIdx)
+}
+
+func (n *Notifier) spool(sinkIdx int, event Event) (string, error) {
+	bz, err := json.Marshal(spooledEvent{
+		Sink:  n.sinkId(sinkIdx),
+		Event: event,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// write then rename, so a concurrent flush never reads a partially written file
+	spoolFilePath := filepath.Join(n.spoolDir, fmt.Sprintf("%d_%s.json", time.Now().UnixNano(), n.sinkId(sinkIdx)))
+	tmpFilePath := spoolFilePath + ".tmp"
+	if err := os.WriteFile(tmpFilePath, bz, 0o600); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpFilePath, spoolFilePath); err != nil {
+		return "", err
+	}
+	return spoolFilePath, nil
+}
+
+// KeepFlushingSpool flushes the spool periodically until the context is done, so events spooled while the sinks are down
+// are delivered once they are back, eg: the fatal alert spooled after the node is killed, the process is never restarted.
+// The interval starts from retry-delay and is doubled while events are still failed to be delivered, up to maxSpoolFlushInterval.
+// It blocks, run it in a goroutine.
+func (n *Notifier) KeepFlushingSpool(ctx context.Context) {
+	if n == nil || n.spoolDir == "" {
+		return
+	}
+
+	baseInterval := n.retryDelay
+	if baseInterval < minSpoolFlushInterval {
+		baseInterval = minSpoolFlushInterval
+	}
+
+	interval := baseInterval
+	for {
+		sent, failed, err := n.FlushSpool()
+		if err != nil {
+			utils.PrintlnStdErr("ERR: failed to flush spooled alerts:", err)
+		} else if sent > 0 || failed > 0 {
+			fmt.Println("INF: re-sent", sent, "spooled alerts,", failed, "failed")
+		}
+
+		if err != nil || failed > 0 {
+			interval *= 2
+			if interval > maxSpoolFlushInterval {
+				interval = maxSpoolFlushInterval
+			}
+		} else {
+			interval = baseInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// FlushSpool re-sends the spooled events, once each, in the order they were spooled.
+// It might take long when the sinks are down, the caller should not wait for it before protecting the node.
+// Delivered events are removed from the spool directory, the others are kept for the next flush.
+func (n *Notifier) FlushSpool() (sent, failed int, err error) {
+	if n == nil || n.spoolDir == "" {
+		return 0, 0, nil
+	}
+
+	entries, err := os.ReadDir(n.spoolDir)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read spool directory")
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	sinkById := make(map[string]Sink)
+	for i, sink := range n.sinks {
+		sinkById[n.sinkId(i)] = sink
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		spoolFilePath := filepath.Join(n.spoolDir, entry.Name())
+
+		bz, err := os.ReadFile(spoolFilePath)
+		if err != nil {
+			return sent, failed, errors.Wrapf(err, "failed to read spool file %s", spoolFilePath)
+		}
+		var spooled spooledEvent
+		if err := json.Unmarshal(bz, &spooled); err != nil {
+			utils.PrintlnStdErr("ERR: invalid spool file", spoolFilePath, ":", err)
+			failed++
+			continue
+		}
+
+		sink, found := sinkById[spooled.Sink]
+		if !found {
+			utils.PrintlnStdErr("WARN: sink", spooled.Sink, "of spool file", spoolFilePath, "is no longer configured")
+			failed++
+			continue
+		}
+
+		if err := n.send(sink, spooled.Event); err != nil {
+			utils.PrintlnStdErr("ERR: failed to re-send spooled", spooled.Event.Type, "alert via", spooled.Sink, ":", err)
+			failed++
+			continue
+		}
+
+		sent++
+		if err := os.Remove(spoolFilePath); err != nil {
+			return sent, failed, errors.Wrapf(err, "failed to remove spool file %s", spoolFilePath)
+		}
+	}
+
+	return sent, failed, nil
+}
@@ -0,0 +1,171 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNotifier(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string]map[string]any)
+	failures := make(map[string]int)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if failures[r.URL.Path] > 0 {
+			failures[r.URL.Path]--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		bz, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(bz, &body))
+		received[r.URL.Path] = body
+	}))
+	defer server.Close()
+
+	config := Config{
+		SpoolDir:   path.Join(t.TempDir(), "spool"),
+		MaxRetries: 1,
+		RetryDelay: "1ms",
+	}
+	config.Webhook = append(config.Webhook, WebhookConfig{Url: server.URL + "/webhook"})
+	config.Telegram = append(config.Telegram, TelegramConfig{ApiUrl: server.URL + "/", BotToken: "123:ABC", ChatId: "-100"})
+	config.Slack = append(config.Slack, SlackConfig{WebhookUrl: server.URL + "/slack"})
+	config.Discord = append(config.Discord, DiscordConfig{WebhookUrl: server.URL + "/discord"})
+
+	notifier, err := NewNotifier(config)
+	require.NoError(t, err)
+	require.Equal(t, "webhook-0, telegram-1, slack-2, discord-3", notifier.Describe())
+
+	event := NewEvent(EventFatal, "/home/user/.gaia", "priv_validator_state.json content decreased", "Previous state: ...")
+
+	t.Run("all sinks, with retry", func(t *testing.T) {
+		failures["/slack"] = 1
+
+		notifier.Notify(event)
+		notifier.Wait()
+
+		require.Equal(t, "fatal", received["/webhook"]["type"])
+		require.Equal(t, event.Message, received["/webhook"]["message"])
+		require.Equal(t, event.Home, received["/webhook"]["home"])
+		require.Equal(t, "-100", received["/bot123:ABC/sendMessage"]["chat_id"])
+		require.Equal(t, event.Text(), received["/bot123:ABC/sendMessage"]["text"])
+		require.Equal(t, event.Text(), received["/slack"]["text"])
+		require.Equal(t, event.Text(), received["/discord"]["content"])
+
+		entries, err := os.ReadDir(config.SpoolDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("spooled after retries, then flushed", func(t *testing.T) {
+		received = make(map[string]map[string]any)
+		failures["/discord"] = 2 // first attempt and 1 retry
+
+		notifier.Notify(NewEvent(EventNoProcessToKill, "/home/user/.gaia", "no process found to be killed", ""))
+		notifier.Wait()
+
+		require.NotContains(t, received, "/discord")
+		entries, err := os.ReadDir(config.SpoolDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		sent, failed, err := notifier.FlushSpool()
+		require.NoError(t, err)
+		require.Equal(t, 1, sent)
+		require.Zero(t, failed)
+		require.Contains(t, received["/discord"]["content"], "NO-PROCESS-TO-KILL")
+
+		entries, err = os.ReadDir(config.SpoolDir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("spooled while sinks are down, flushed in background", func(t *testing.T) {
+		received = make(map[string]map[string]any)
+		mu.Lock()
+		failures["/webhook"] = 3 // first attempt, 1 retry and the first flush
+		mu.Unlock()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go notifier.KeepFlushingSpool(ctx)
+
+		notifier.Notify(event)
+		notifier.Wait()
+
+		require.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			_, delivered := received["/webhook"]
+			return delivered
+		}, 10*time.Second, 10*time.Millisecond, "spooled event is not re-sent")
+		require.Eventually(t, func() bool {
+			entries, err := os.ReadDir(config.SpoolDir)
+			require.NoError(t, err)
+			return len(entries) == 0
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("events filter", func(t *testing.T) {
+		received = make(map[string]map[string]any)
+		config.Events = []EventType{EventFatal}
+		filtered, err := NewNotifier(config)
+		require.NoError(t, err)
+
+		filtered.Notify(NewEvent(EventRestoreSnapshot, "/home/user/.gaia", "state file is empty", ""))
+		filtered.Wait()
+		require.Empty(t, received)
+	})
+}
+
+func TestLoadConfig(t *testing.T) {
+	configFilePath := path.Join(t.TempDir(), "alert.toml")
+	require.NoError(t, os.WriteFile(configFilePath, []byte(`
+spool-dir = "/tmp/spool"
+max-retries = 3
+retry-delay = "2s"
+events = ["fatal"]
+
+[[telegram]]
+bot-token = "123:ABC"
+chat-id = "-100"
+
+[[discord]]
+webhook-url = "https://discord.com/api/webhooks/x/y"
+`), 0o600))
+
+	config, err := LoadConfig(configFilePath)
+	require.NoError(t, err)
+	require.Equal(t, 3, config.MaxRetries)
+	require.Equal(t, []EventType{EventFatal}, config.Events)
+	sinks, err := config.sinks()
+	require.NoError(t, err)
+	require.Equal(t, []Sink{
+		telegramSink{apiUrl: defaultTelegramApiUrl, botToken: "123:ABC", chatId: "-100"},
+		discordSink{webhookUrl: "https://discord.com/api/webhooks/x/y"},
+	}, sinks)
+
+	require.NoError(t, os.WriteFile(configFilePath, []byte(`unknown = 1`), 0o600))
+	_, err = LoadConfig(configFilePath)
+	require.Error(t, err)
+
+	_, err = NewNotifier(Config{Events: []EventType{"x"}})
+	require.Error(t, err)
+}
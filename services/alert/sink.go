@@ -0,0 +1,128 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const defaultTelegramApiUrl = "https://api.telegram.org"
+
+// Sink delivers events to a destination.
+type Sink interface {
+	Name() string
+	Send(ctx context.Context, httpClient *http.Client, event Event) error
+}
+
+var (
+	_ Sink = webhookSink{}
+	_ Sink = telegramSink{}
+	_ Sink = slackSink{}
+	_ Sink = discordSink{}
+)
+
+// webhookSink posts the event as JSON.
+type webhookSink struct {
+	url string
+}
+
+func (s webhookSink) Name() string {
+	return "webhook"
+}
+
+func (s webhookSink) Send(ctx context.Context, httpClient *http.Client, event Event) error {
+	return postJSON(ctx, httpClient, s.url, event)
+}
+
+// telegramSink sends the event via Telegram bot API sendMessage.
+type telegramSink struct {
+	apiUrl   string
+	botToken string
+	chatId   string
+}
+
+func (s telegramSink) Name() string {
+	return "telegram"
+}
+
+func (s telegramSink) Send(ctx context.Context, httpClient *http.Client, event Event) error {
+	const maxLength = 4096
+	return postJSON(ctx, httpClient, fmt.Sprintf("%s/bot%s/sendMessage", s.apiUrl, s.botToken), map[string]any{
+		"chat_id": s.chatId,
+		"text":    truncate(event.Text(), maxLength),
+	})
+}
+
+// slackSink posts the event to a Slack incoming webhook.
+type slackSink struct {
+	webhookUrl string
+}
+
+func (s slackSink) Name() string {
+	return "slack"
+}
+
+func (s slackSink) Send(ctx context.Context, httpClient *http.Client, event Event) error {
+	return postJSON(ctx, httpClient, s.webhookUrl, map[string]any{
+		"text": event.Text(),
+	})
+}
+
+// discordSink posts the event to a Discord webhook.
+type discordSink struct {
+	webhookUrl string
+}
+
+func (s discordSink) Name() string {
+	return "discord"
+}
+
+func (s discordSink) Send(ctx context.Context, httpClient *http.Client, event Event) error {
+	const maxLength = 2000
+	return postJSON(ctx, httpClient, s.webhookUrl, map[string]any{
+		"content": truncate(event.Text(), maxLength),
+	})
+}
+
+func postJSON(ctx context.Context, httpClient *http.Client, url string, body any) error {
+	bz, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(bz))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// do not leak the URL, it might contain secret, eg: Telegram bot token
+		if urlErr, ok := err.(interface{ Unwrap() error }); ok && urlErr.Unwrap() != nil {
+			return urlErr.Unwrap()
+		}
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+	return string(runes[:maxLength-3]) + "..."
+}
@@ -0,0 +1,358 @@
+package genesis
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/klauspost/compress/gzip"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Info is the result of inspecting a genesis file.
+type Info struct {
+	// Compressed is true if the file is gzip-compressed, which can not be loaded by the node
+	Compressed bool
+	// Size is the size of the file on disk
+	Size int64
+	// Sha256 is the checksum of the file on disk
+	Sha256 string
+	// ContentSha256 is the checksum of the decompressed content, same as Sha256 if the file is not compressed
+	ContentSha256 string
+	// CanonicalSha256 is the checksum of the canonical JSON: sorted keys, no whitespace
+	CanonicalSha256 string
+	ChainId         string
+	// InitialHeight is the raw JSON value of initial_height, empty if not set
+	InitialHeight string
+}
+
+// MatchesSha256 returns true if the expected checksum matches the file, the decompressed content or the canonical JSON.
+func (i Info) MatchesSha256(expected string) bool {
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	return expected == i.Sha256 || expected == i.ContentSha256 || expected == i.CanonicalSha256
+}
+
+// InitialHeightValue returns the initial_height, zero if not set.
+// The JSON value can be either string (as exported by the node) or number.
+func (i Info) InitialHeightValue() (int64, error) {
+	if i.InitialHeight == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(strings.Trim(i.InitialHeight, `"`), 10, 64)
+}
+
+// Inspect reads the genesis file, decompresses if gzip-compressed, then computes the checksums.
+func Inspect(filePath string) (*Info, error) {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", filePath)
+	}
+
+	info := &Info{
+		Compressed: bytes.HasPrefix(bz, gzipMagic),
+		Size:       int64(len(bz)),
+		Sha256:     sha256Hex(bz),
+	}
+
+	content := bz
+	if info.Compressed {
+		content, err = decompress(bz)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decompress gzip-compressed %s", filePath)
+		}
+	}
+	info.ContentSha256 = sha256Hex(content)
+
+	canonical, err := Canonical(content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to canonicalize %s", filePath)
+	}
+	info.CanonicalSha256 = sha256Hex(canonical)
+
+	var header struct {
+		ChainId       string          `json:"chain_id"`
+		InitialHeight json.RawMessage `json:"initial_height"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal %s", filePath)
+	}
+	info.ChainId = header.ChainId
+	info.InitialHeight = string(header.InitialHeight)
+
+	return info, nil
+}
+
+// Canonical returns the canonical JSON of the content: keys sorted, no whitespace, numbers kept as is.
+func Canonical(content []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var v any
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// ReadChainId reads the top-level chain_id of the genesis file, without loading the whole app_state which can be huge.
+// Gzip-compressed genesis is supported.
+func ReadChainId(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var reader io.Reader = bufio.NewReader(file)
+	if magic, err := reader.(*bufio.Reader).Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read gzip-compressed genesis")
+		}
+		defer func() {
+			_ = gzipReader.Close()
+		}()
+		reader = gzipReader
+	}
+
+	decoder := json.NewDecoder(reader)
+	if token, err := decoder.Token(); err != nil {
+		return "", err
+	} else if token != json.Delim('{') {
+		return "", fmt.Errorf("genesis is not a JSON object")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		if token == "chain_id" {
+			var chainId string
+			if err := decoder.Decode(&chainId); err != nil {
+				return "", errors.Wrap(err, "failed to decode chain_id")
+			}
+			return chainId, nil
+		}
+
+		if err := skipJsonValue(decoder); err != nil {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("chain_id not found")
+}
+
+func skipJsonValue(decoder *json.Decoder) error {
+	var depth int
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// SetInitialHeight returns the content with initial_height set to the height, encoded as string.
+// Only the value is replaced, formatting of the rest of the content is kept.
+func SetInitialHeight(content []byte, initialHeight int64) ([]byte, error) {
+	value := []byte(strconv.Quote(strconv.FormatInt(initialHeight, 10)))
+
+	start, end, found, err := findTopLevelValue(content, "initial_height")
+	if err != nil {
+		return nil, err
+	}
+
+	var result []byte
+	if found {
+		result = append(result, content[:start]...)
+		result = append(result, value...)
+		result = append(result, content[end:]...)
+	} else {
+		openBraceIdx := bytes.IndexByte(content, '{')
+		insert := append([]byte(`"initial_height":`), value...)
+		if len(bytes.TrimSpace(content[openBraceIdx+1:])) > 1 {
+			// not an empty object
+			insert = append(insert, ',')
+		}
+		result = append(result, content[:openBraceIdx+1]...)
+		result = append(result, insert...)
+		result = append(result, content[openBraceIdx+1:]...)
+	}
+
+	if !json.Valid(result) {
+		return nil, fmt.Errorf("genesis is invalid after setting initial_height")
+	}
+	return result, nil
+}
+
+// findTopLevelValue returns the position of the value of the top-level key.
+func findTopLevelValue(content []byte, key string) (start, end int, found bool, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	if token, err := decoder.Token(); err != nil {
+		return 0, 0, false, err
+	} else if token != json.Delim('{') {
+		return 0, 0, false, fmt.Errorf("genesis is not a JSON object")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return 0, 0, false, err
+		}
+
+		if token == key {
+			end = int(decoder.InputOffset())
+			return end - len(raw), end, true, nil
+		}
+	}
+
+	return 0, 0, false, nil
+}
+
+// FixResult is the result of fixing a genesis file.
+type FixResult struct {
+	// BackupFilePath is the copy of the original file, empty if nothing changed
+	BackupFilePath string
+	Decompressed   bool
+	// InitialHeight is the raw JSON value of initial_height after fixing, empty if not changed
+	InitialHeight string
+}
+
+// Fix decompresses the gzip-compressed genesis file and sets initial_height.
+// If initialHeight is zero, initial_height is only normalized to be encoded as string.
+// The original file is copied into a timestamped backup before writing.
+func Fix(filePath string, initialHeight int64) (FixResult, error) {
+	var result FixResult
+
+	info, err := Inspect(filePath)
+	if err != nil {
+		return result, err
+	}
+
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to check %s", filePath)
+	}
+	original, err := os.ReadFile(filePath)
+	if err != nil {
+		return result, errors.Wrapf(err, "failed to read %s", filePath)
+	}
+
+	content := original
+	if info.Compressed {
+		content, err = decompress(original)
+		if err != nil {
+			return result, errors.Wrapf(err, "failed to decompress %s", filePath)
+		}
+		result.Decompressed = true
+	}
+
+	if initialHeight == 0 && info.InitialHeight != "" && !strings.HasPrefix(info.InitialHeight, `"`) {
+		// node expects initial_height encoded as string
+		initialHeight, err = info.InitialHeightValue()
+		if err != nil {
+			return result, errors.Wrapf(err, "invalid initial_height %s", info.InitialHeight)
+		}
+	}
+	if initialHeight > 0 {
+		content, err = SetInitialHeight(content, initialHeight)
+		if err != nil {
+			return result, err
+		}
+		result.InitialHeight = strconv.Quote(strconv.FormatInt(initialHeight, 10))
+	}
+
+	if bytes.Equal(content, original) {
+		result.InitialHeight = ""
+		return result, nil
+	}
+
+	dir, fileName := filepath.Split(filePath)
+	result.BackupFilePath = filepath.Join(dir, fmt.Sprintf("%s.%s.%s.bak", fileName, utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime), constants.BINARY_NAME))
+	if err := os.WriteFile(result.BackupFilePath, original, fi.Mode().Perm()); err != nil {
+		return result, errors.Wrap(err, "failed to write backup file")
+	}
+
+	if err := os.WriteFile(filePath, content, fi.Mode().Perm()); err != nil {
+		return result, errors.Wrapf(err, "failed to write %s", filePath)
+	}
+	return result, nil
+}
+
+// ReadChainRegistry reads chain_id and the genesis checksum (codebase.genesis.sha256, optional)
+// of a chain.json file of the chain-registry.
+func ReadChainRegistry(filePath string) (chainId, genesisSha256 string, err error) {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to read %s", filePath)
+	}
+
+	var chain struct {
+		ChainId  string `json:"chain_id"`
+		Codebase struct {
+			Genesis struct {
+				Sha256 string `json:"sha256"`
+			} `json:"genesis"`
+		} `json:"codebase"`
+	}
+	if err := json.Unmarshal(bz, &chain); err != nil {
+		return "", "", errors.Wrapf(err, "failed to unmarshal %s", filePath)
+	}
+	if chain.ChainId == "" {
+		return "", "", fmt.Errorf("chain_id is missing in %s", filePath)
+	}
+
+	return chain.ChainId, strings.ToLower(chain.Codebase.Genesis.Sha256), nil
+}
+
+func decompress(bz []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(bz))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	return io.ReadAll(reader)
+}
+
+func sha256Hex(bz []byte) string {
+	checksum := sha256.Sum256(bz)
+	return hex.EncodeToString(checksum[:])
+}
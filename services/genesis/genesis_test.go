@@ -0,0 +1,125 @@
+package genesis
+
+import (
+	"bytes"
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+const testGenesis = `{
+  "genesis_time": "2024-01-01T00:00:00Z",
+  "chain_id": "chain-1",
+  "initial_height": 100,
+  "app_state": {"bank": {"supply": [], "denom": "<a&b>"}}
+}
+`
+
+func TestSetInitialHeight(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "number",
+			content: `{"chain_id": "x", "initial_height": 100, "app_state": {}}`,
+			want:    `{"chain_id": "x", "initial_height": "1", "app_state": {}}`,
+		},
+		{
+			name:    "string",
+			content: "{\n  \"initial_height\": \"100\"\n}",
+			want:    "{\n  \"initial_height\": \"1\"\n}",
+		},
+		{
+			name:    "nested key is not replaced",
+			content: `{"app_state": {"initial_height": 100}, "initial_height": "100"}`,
+			want:    `{"app_state": {"initial_height": 100}, "initial_height": "1"}`,
+		},
+		{
+			name:    "missing",
+			content: `{"chain_id": "x"}`,
+			want:    `{"initial_height":"1","chain_id": "x"}`,
+		},
+		{
+			name:    "missing, empty object",
+			content: `{ }`,
+			want:    `{"initial_height":"1" }`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SetInitialHeight([]byte(tt.content), 1)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+
+	_, err := SetInitialHeight([]byte(`[]`), 1)
+	require.Error(t, err)
+}
+
+func TestInspectAndFix(t *testing.T) {
+	dir := t.TempDir()
+	plainFilePath := path.Join(dir, "plain.json")
+	require.NoError(t, os.WriteFile(plainFilePath, []byte(testGenesis), 0o644))
+
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	_, err := gzipWriter.Write([]byte(testGenesis))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+	compressedFilePath := path.Join(dir, "compressed.json")
+	require.NoError(t, os.WriteFile(compressedFilePath, buf.Bytes(), 0o644))
+
+	plain, err := Inspect(plainFilePath)
+	require.NoError(t, err)
+	require.False(t, plain.Compressed)
+	require.Equal(t, "chain-1", plain.ChainId)
+	require.Equal(t, "100", plain.InitialHeight)
+	require.Equal(t, plain.Sha256, plain.ContentSha256)
+	require.NotEqual(t, plain.Sha256, plain.CanonicalSha256)
+
+	canonical, err := Canonical([]byte(testGenesis))
+	require.NoError(t, err)
+	require.Equal(t, `{"app_state":{"bank":{"denom":"<a&b>","supply":[]}},"chain_id":"chain-1","genesis_time":"2024-01-01T00:00:00Z","initial_height":100}`, string(canonical))
+
+	compressed, err := Inspect(compressedFilePath)
+	require.NoError(t, err)
+	require.True(t, compressed.Compressed)
+	require.NotEqual(t, plain.Sha256, compressed.Sha256)
+	require.Equal(t, plain.ContentSha256, compressed.ContentSha256)
+	require.Equal(t, plain.CanonicalSha256, compressed.CanonicalSha256)
+	require.True(t, compressed.MatchesSha256(plain.Sha256))
+	require.False(t, compressed.MatchesSha256("00"))
+
+	chainId, err := ReadChainId(compressedFilePath)
+	require.NoError(t, err)
+	require.Equal(t, "chain-1", chainId)
+
+	// initial_height is normalized to string, gzip-compressed genesis is decompressed
+	result, err := Fix(compressedFilePath, 0)
+	require.NoError(t, err)
+	require.True(t, result.Decompressed)
+	require.Equal(t, `"100"`, result.InitialHeight)
+	require.NotEmpty(t, result.BackupFilePath)
+
+	fixed, err := Inspect(compressedFilePath)
+	require.NoError(t, err)
+	require.False(t, fixed.Compressed)
+	require.Equal(t, `"100"`, fixed.InitialHeight)
+
+	// nothing to fix
+	result, err = Fix(compressedFilePath, 0)
+	require.NoError(t, err)
+	require.Equal(t, FixResult{}, result)
+
+	result, err = Fix(compressedFilePath, 1)
+	require.NoError(t, err)
+	require.Equal(t, `"1"`, result.InitialHeight)
+	height, err := Info{InitialHeight: result.InitialHeight}.InitialHeightValue()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), height)
+}
@@ -0,0 +1,377 @@
+package node_process
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/shirou/gopsutil/v3/process"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Target describes how to find the node process to be killed.
+// Priority: systemd unit, then pidfile, then matching executable path and --home argument of every process.
+// Without binary path, any executable started with the home is matched, eg: to ensure the node is stopped.
+type Target struct {
+	// SystemdUnit is the service name, the MainPID of the unit and its descendants are targeted,
+	// the MainPID might be a supervisor, eg: cosmovisor
+	SystemdUnit string
+	// PidFile contains the PID of the node process, verified against BinaryPath and Home as the PID might be reused
+	PidFile string
+	// BinaryPath is the absolute path of the node binary, matched against /proc/<pid>/exe
+	BinaryPath string
+	// Home is the node home directory, matched against the exact --home argument.
+	// For processes without --home (default home), matched against the files opened by the process.
+	Home string
+}
+
+// Candidate is a process evaluated by the target.
+type Candidate struct {
+	Pid     int32
+	Matched bool
+	// Reason explains why the process is targeted or skipped
+	Reason string
+}
+
+func (t Target) Validate() error {
+	if t.SystemdUnit == "" && t.PidFile == "" && t.BinaryPath == "" && t.Home == "" {
+		return fmt.Errorf("require either systemd unit, pidfile, binary path or home")
+	}
+	if t.BinaryPath != "" && !filepath.IsAbs(t.BinaryPath) {
+		return fmt.Errorf("binary path must be absolute: %s", t.BinaryPath)
+	}
+	if t.Home != "" && !filepath.IsAbs(t.Home) {
+		return fmt.Errorf("home must be absolute: %s", t.Home)
+	}
+	return nil
+}
+
+// Describe returns the human-readable targeting rule.
+func (t Target) Describe() string {
+	if t.SystemdUnit != "" {
+		return fmt.Sprintf("MainPID of systemd unit %s and its descendants", t.SystemdUnit)
+	}
+	if t.PidFile != "" {
+		return fmt.Sprintf("PID in pidfile %s", t.PidFile)
+	}
+	if t.BinaryPath == "" {
+		return fmt.Sprintf("any executable started with --home %s", t.Home)
+	}
+	if t.Home != "" {
+		return fmt.Sprintf("executable %s started with --home %s", t.BinaryPath, t.Home)
+	}
+	return fmt.Sprintf("executable %s started", t.BinaryPath)
+}
+
+// Find returns the processes evaluated, only the ones with Matched should be killed.
+// For matching by executable, candidates with the same executable but not matched are also returned
+// to explain why they are skipped.
+func (t Target) Find() ([]Candidate, error) {
+	if t.SystemdUnit != "" {
+		return t.findBySystemdUnit()
+	}
+	if t.PidFile != "" {
+		return t.findByPidFile()
+	}
+	return t.findByExecutable()
+}
+
+func (t Target) findBySystemdUnit() ([]Candidate, error) {
+	output, err := exec.Command("systemctl", "show", "--property", "MainPID", "--value", t.SystemdUnit).Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get MainPID of systemd unit %s", t.SystemdUnit)
+	}
+	pid, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 32)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid MainPID of systemd unit %s", t.SystemdUnit)
+	}
+	if pid == 0 {
+		// unit is not running
+		return nil, nil
+	}
+	// MainPID might be a supervisor, eg: cosmovisor or wrapper script, the node is its child
+	return withDescendants(int32(pid), fmt.Sprintf("MainPID of systemd unit %s", t.SystemdUnit))
+}
+
+// withDescendants returns the process and all its descendants as matched candidates, descendants first.
+func withDescendants(pid int32, reason string) ([]Candidate, error) {
+	processes, err := process.Processes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get processes")
+	}
+
+	parentOf := make(map[int32]int32)
+	for _, p := range processes {
+		ppid, err := p.Ppid()
+		if err != nil {
+			// exited
+			continue
+		}
+		parentOf[p.Pid] = ppid
+	}
+
+	var candidates []Candidate
+	for _, descendant := range descendantsOf(pid, parentOf) {
+		candidates = append(candidates, Candidate{
+			Pid:     descendant,
+			Matched: true,
+			Reason:  fmt.Sprintf("descendant of PID %d, %s", pid, reason),
+		})
+	}
+	return append(candidates, Candidate{
+		Pid:     pid,
+		Matched: true,
+		Reason:  reason,
+	}), nil
+}
+
+// descendantsOf returns the descendants of the process, deepest first.
+func descendantsOf(pid int32, parentOf map[int32]int32) []int32 {
+	childrenOf := make(map[int32][]int32)
+	for child, parent := range parentOf {
+		if child != parent {
+			childrenOf[parent] = append(childrenOf[parent], child)
+		}
+	}
+	for _, children := range childrenOf {
+		sort.Slice(children, func(i, j int) bool {
+			return children[i] < children[j]
+		})
+	}
+
+	var descendants []int32
+	visited := map[int32]bool{pid: true}
+	queue := []int32{pid}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range childrenOf[current] {
+			if visited[child] {
+				continue
+			}
+			visited[child] = true
+			descendants = append(descendants, child)
+			queue = append(queue, child)
+		}
+	}
+
+	// deepest first, so the node is killed before its supervisor can react
+	for i, j := 0, len(descendants)-1; i < j; i, j = i+1, j-1 {
+		descendants[i], descendants[j] = descendants[j], descendants[i]
+	}
+	return descendants
+}
+
+func (t Target) findByPidFile() ([]Candidate, error) {
+	bz, err := os.ReadFile(t.PidFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// node is not running
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read pidfile %s", t.PidFile)
+	}
+	pid, err := strconv.ParseInt(strings.TrimSpace(string(bz)), 10, 32)
+	if err != nil || pid < 1 {
+		return nil, fmt.Errorf("invalid PID in pidfile %s: %s", t.PidFile, strings.TrimSpace(string(bz)))
+	}
+
+	candidate := Candidate{
+		Pid: int32(pid),
+	}
+	if exists, err := process.PidExists(candidate.Pid); err != nil {
+		return nil, errors.Wrapf(err, "failed to check process %d", pid)
+	} else if !exists {
+		candidate.Reason = fmt.Sprintf("stale pidfile %s, process does not exist", t.PidFile)
+		return []Candidate{candidate}, nil
+	}
+
+	// the PID might be reused by an unrelated process, verify it is the node before killing
+	p := &process.Process{Pid: candidate.Pid}
+	exe, err := p.Exe()
+	if err != nil {
+		candidate.Reason = fmt.Sprintf("PID in pidfile %s, failed to get executable, not killed: %v", t.PidFile, err)
+		return []Candidate{candidate}, nil
+	}
+	args, _ := p.CmdlineSlice()
+
+	binaryPath := t.BinaryPath
+	if binaryPath == "" {
+		// any executable, still require the start argument and the home
+		binaryPath = strings.TrimSuffix(exe, " (deleted)")
+	} else if resolved, err := filepath.EvalSymlinks(binaryPath); err == nil {
+		binaryPath = resolved
+	}
+
+	matched, isCandidate := matchProcess(candidate.Pid, exe, args, openFilesOf(p), binaryPath, t.Home)
+	if !isCandidate {
+		candidate.Reason = fmt.Sprintf("stale pidfile %s, executable %s is not %s", t.PidFile, exe, binaryPath)
+		return []Candidate{candidate}, nil
+	}
+	if !matched.Matched {
+		candidate.Reason = fmt.Sprintf("stale pidfile %s, %s", t.PidFile, matched.Reason)
+		return []Candidate{candidate}, nil
+	}
+
+	candidate.Matched = true
+	candidate.Reason = fmt.Sprintf("PID in pidfile %s, %s", t.PidFile, matched.Reason)
+	return []Candidate{candidate}, nil
+}
+
+func (t Target) findByExecutable() ([]Candidate, error) {
+	binaryPath := t.BinaryPath
+	if binaryPath == "" {
+		if t.Home == "" {
+			return nil, fmt.Errorf("require binary path or home")
+		}
+		// any executable, matched by the start argument and the home
+	} else if resolved, err := filepath.EvalSymlinks(binaryPath); err == nil {
+		binaryPath = resolved
+	}
+
+	processes, err := process.Processes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get processes")
+	}
+
+	selfPid := int32(os.Getpid())
+	var candidates []Candidate
+	for _, p := range processes {
+		if p.Pid == selfPid {
+			continue
+		}
+		exe, err := p.Exe()
+		if err != nil {
+			// not accessible, eg: process of other users
+			continue
+		}
+		args, _ := p.CmdlineSlice()
+		processBinaryPath := binaryPath
+		if processBinaryPath == "" {
+			processBinaryPath = strings.TrimSuffix(exe, " (deleted)")
+		}
+		if candidate, isCandidate := matchProcess(p.Pid, exe, args, openFilesOf(p), processBinaryPath, t.Home); isCandidate {
+			candidates = append(candidates, candidate)
+		}
+	}
+	return candidates, nil
+}
+
+// FindSameNameNodes returns the node processes of the home running an executable with the same name as the binary
+// but at another path, eg: run by cosmovisor from cosmovisor/upgrades/<name>/bin.
+// Such processes are not targeted when matching by executable, so the node would keep running on fatal events.
+// Only applied when matching by executable, none of the returned candidates is matched.
+func (t Target) FindSameNameNodes() ([]Candidate, error) {
+	if t.SystemdUnit != "" || t.PidFile != "" || t.BinaryPath == "" {
+		return nil, nil
+	}
+
+	binaryPath := t.BinaryPath
+	if resolved, err := filepath.EvalSymlinks(binaryPath); err == nil {
+		binaryPath = resolved
+	}
+
+	processes, err := process.Processes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get processes")
+	}
+
+	selfPid := int32(os.Getpid())
+	var candidates []Candidate
+	for _, p := range processes {
+		if p.Pid == selfPid {
+			continue
+		}
+		exe, err := p.Exe()
+		if err != nil {
+			// not accessible, eg: process of other users
+			continue
+		}
+		exe = strings.TrimSuffix(exe, " (deleted)")
+		if filepath.Base(exe) != filepath.Base(binaryPath) || filepath.Clean(exe) == filepath.Clean(binaryPath) {
+			continue
+		}
+		args, _ := p.CmdlineSlice()
+		// the node of the home, whatever the executable path is
+		if candidate, _ := matchProcess(p.Pid, exe, args, openFilesOf(p), exe, t.Home); candidate.Matched {
+			candidates = append(candidates, Candidate{
+				Pid:    p.Pid,
+				Reason: fmt.Sprintf("%s, not the binary %s", candidate.Reason, binaryPath),
+			})
+		}
+	}
+	return candidates, nil
+}
+
+// openFilesOf returns the function listing the files opened by the process, it is slow so only called when needed.
+func openFilesOf(p *process.Process) func() []string {
+	return func() []string {
+		stats, _ := p.OpenFiles()
+		var openFiles []string
+		for _, stat := range stats {
+			openFiles = append(openFiles, stat.Path)
+		}
+		return openFiles
+	}
+}
+
+// matchProcess returns isCandidate = true if the executable is the binary,
+// the candidate is matched if also the process is started with the home.
+func matchProcess(pid int32, exe string, args []string, openFiles func() []string, binaryPath, home string) (candidate Candidate, isCandidate bool) {
+	// the binary might be replaced while the node is running, eg: upgrade
+	exe = strings.TrimSuffix(exe, " (deleted)")
+	if filepath.Clean(exe) != filepath.Clean(binaryPath) {
+		return
+	}
+
+	isCandidate = true
+	candidate.Pid = pid
+
+	var hasStart bool
+	var processHome string
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+		if arg == "start" {
+			hasStart = true
+		} else if arg == "--home" && i+1 < len(args) {
+			processHome = args[i+1]
+			i++
+		} else if strings.HasPrefix(arg, "--home=") {
+			processHome = strings.TrimPrefix(arg, "--home=")
+		}
+	}
+
+	if !hasStart {
+		candidate.Reason = fmt.Sprintf("executable %s but no start argument", exe)
+		return
+	}
+	if home == "" {
+		candidate.Matched = true
+		candidate.Reason = fmt.Sprintf("executable %s with start argument", exe)
+		return
+	}
+	if processHome == "" {
+		// default home, check the process is using data of the home, eg: LOCK of the databases
+		dataDir := filepath.Join(home, "data") + string(filepath.Separator)
+		for _, openFile := range openFiles() {
+			if strings.HasPrefix(openFile, dataDir) {
+				candidate.Matched = true
+				candidate.Reason = fmt.Sprintf("executable %s with start argument, no --home argument but opened %s", exe, openFile)
+				return
+			}
+		}
+		candidate.Reason = fmt.Sprintf("executable %s with start argument, no --home argument and no file opened under %s", exe, dataDir)
+		return
+	}
+	if filepath.Clean(processHome) != filepath.Clean(home) {
+		candidate.Reason = fmt.Sprintf("executable %s with start argument but --home %s", exe, processHome)
+		return
+	}
+
+	candidate.Matched = true
+	candidate.Reason = fmt.Sprintf("executable %s with start argument and --home %s", exe, processHome)
+	return
+}
@@ -0,0 +1,279 @@
+package node_process
+
+import (
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/stretchr/testify/require"
+	"os"
+	"os/exec"
+	"path"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func Test_matchProcess(t *testing.T) {
+	const binaryPath = "/home/user/go/bin/gaiad"
+	const home = "/home/user/.gaia"
+
+	tests := []struct {
+		name          string
+		exe           string
+		args          []string
+		openFiles     []string
+		home          string
+		wantCandidate bool
+		wantMatched   bool
+	}{
+		{
+			name:          "matched",
+			exe:           binaryPath,
+			args:          []string{"gaiad", "start", "--home", home},
+			home:          home,
+			wantCandidate: true,
+			wantMatched:   true,
+		},
+		{
+			name:          "matched, --home=",
+			exe:           binaryPath,
+			args:          []string{"gaiad", "start", "--home=" + home + "/"},
+			home:          home,
+			wantCandidate: true,
+			wantMatched:   true,
+		},
+		{
+			name:          "matched, binary replaced while running",
+			exe:           binaryPath + " (deleted)",
+			args:          []string{"gaiad", "start", "--home", home},
+			home:          home,
+			wantCandidate: true,
+			wantMatched:   true,
+		},
+		{
+			name:          "matched, home is not required",
+			exe:           binaryPath,
+			args:          []string{"gaiad", "start"},
+			wantCandidate: true,
+			wantMatched:   true,
+		},
+		{
+			name:          "other home",
+			exe:           binaryPath,
+			args:          []string{"gaiad", "start", "--home", "/home/user/.gaia-testnet"},
+			home:          home,
+			wantCandidate: true,
+		},
+		{
+			name:          "no --home, using data of the home",
+			exe:           binaryPath,
+			args:          []string{"gaiad", "start"},
+			openFiles:     []string{"/dev/null", home + "/data/application.db/LOCK"},
+			home:          home,
+			wantCandidate: true,
+			wantMatched:   true,
+		},
+		{
+			name:          "no --home, using data of other home",
+			exe:           binaryPath,
+			args:          []string{"gaiad", "start"},
+			openFiles:     []string{home + "-testnet/data/application.db/LOCK"},
+			home:          home,
+			wantCandidate: true,
+		},
+		{
+			name:          "not start",
+			exe:           binaryPath,
+			args:          []string{"gaiad", "status", "--home", home},
+			home:          home,
+			wantCandidate: true,
+		},
+		{
+			name: "other executable mentioning the binary and start",
+			exe:  "/usr/bin/journalctl",
+			args: []string{"journalctl", "-u", "gaiad", "start", "--home", home},
+			home: home,
+		},
+		{
+			name: "other executable with same name",
+			exe:  "/usr/local/bin/gaiad",
+			args: []string{"gaiad", "start", "--home", home},
+			home: home,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidate, isCandidate := matchProcess(100, tt.exe, tt.args, func() []string { return tt.openFiles }, binaryPath, tt.home)
+			require.Equal(t, tt.wantCandidate, isCandidate)
+			require.Equal(t, tt.wantMatched, candidate.Matched)
+			if isCandidate {
+				require.Equal(t, int32(100), candidate.Pid)
+				require.NotEmpty(t, candidate.Reason)
+			}
+		})
+	}
+}
+
+func TestTarget_findByPidFile(t *testing.T) {
+	pidFile := path.Join(t.TempDir(), "node.pid")
+	target := Target{PidFile: pidFile}
+
+	candidates, err := target.Find()
+	require.NoError(t, err)
+	require.Empty(t, candidates, "pidfile does not exist")
+
+	// process started with "start" argument, as the node
+	node := exec.Command("/bin/sh", "-c", "sleep 30", "start")
+	require.NoError(t, node.Start())
+	defer func() {
+		_ = node.Process.Kill()
+		_ = node.Wait()
+	}()
+
+	require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(node.Process.Pid)+"\n"), 0o644))
+	require.Eventually(t, func() bool {
+		// wait for the process to exec
+		candidates, err = target.Find()
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		require.Equal(t, int32(node.Process.Pid), candidates[0].Pid)
+		return candidates[0].Matched
+	}, 5*time.Second, 10*time.Millisecond, "process started with start argument is not matched")
+
+	t.Run("PID reused by other executable", func(t *testing.T) {
+		target := Target{PidFile: pidFile, BinaryPath: "/home/user/go/bin/gaiad"}
+		candidates, err := target.Find()
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		require.False(t, candidates[0].Matched)
+		require.Contains(t, candidates[0].Reason, "stale pidfile")
+	})
+
+	t.Run("PID reused by process without start argument", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(pidFile, []byte(strconv.Itoa(os.Getpid())), 0o644))
+		candidates, err := target.Find()
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		require.False(t, candidates[0].Matched)
+		require.Contains(t, candidates[0].Reason, "stale pidfile")
+	})
+
+	require.NoError(t, os.WriteFile(pidFile, []byte("x"), 0o644))
+	_, err = target.Find()
+	require.Error(t, err)
+}
+
+func TestTarget_findByHome(t *testing.T) {
+	home := t.TempDir()
+	target := Target{Home: home}
+	require.NoError(t, target.Validate())
+
+	// any executable
+	node := exec.Command("/bin/sh", "-c", "sleep 30; true", "start", "--home", home)
+	require.NoError(t, node.Start())
+	defer func() {
+		_ = node.Process.Kill()
+		_ = node.Wait()
+	}()
+
+	require.Eventually(t, func() bool {
+		candidates, err := target.Find()
+		require.NoError(t, err)
+		for _, candidate := range candidates {
+			if candidate.Pid == int32(node.Process.Pid) {
+				return candidate.Matched
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond, "node of the home is not found")
+
+	candidates, err := Target{Home: home + "-other"}.Find()
+	require.NoError(t, err)
+	for _, candidate := range candidates {
+		require.False(t, candidate.Matched, "node of other home")
+	}
+
+	require.Error(t, Target{}.Validate())
+}
+
+func TestTarget_FindSameNameNodes(t *testing.T) {
+	const home = "/home/user/.gaia"
+	shell, err := os.ReadFile("/bin/sh")
+	require.NoError(t, err)
+
+	// binary at ~/go/bin while the node is run from cosmovisor/upgrades/<name>/bin
+	binaryPath := path.Join(t.TempDir(), "go", "bin", "gaiad")
+	upgradeBinaryPath := path.Join(t.TempDir(), "cosmovisor", "upgrades", "v2", "bin", "gaiad")
+	for _, filePath := range []string{binaryPath, upgradeBinaryPath} {
+		require.NoError(t, os.MkdirAll(path.Dir(filePath), 0o755))
+		require.NoError(t, os.WriteFile(filePath, shell, 0o755))
+	}
+
+	node := exec.Command(upgradeBinaryPath, "-c", "sleep 30; true", "start", "--home", home)
+	require.NoError(t, node.Start())
+	defer func() {
+		_ = node.Process.Kill()
+		_ = node.Wait()
+	}()
+
+	target := Target{BinaryPath: binaryPath, Home: home}
+	require.Eventually(t, func() bool {
+		candidates, err := target.FindSameNameNodes()
+		require.NoError(t, err)
+		return len(candidates) == 1 && candidates[0].Pid == int32(node.Process.Pid)
+	}, 5*time.Second, 10*time.Millisecond, "node run from other path is not found")
+
+	candidates, err := target.Find()
+	require.NoError(t, err)
+	require.Empty(t, candidates, "not targeted by executable path")
+
+	sameNameNodes, err := Target{BinaryPath: binaryPath, Home: home + "-testnet"}.FindSameNameNodes()
+	require.NoError(t, err)
+	require.Empty(t, sameNameNodes, "node of other home")
+
+	sameNameNodes, err = Target{BinaryPath: upgradeBinaryPath, Home: home}.FindSameNameNodes()
+	require.NoError(t, err)
+	require.Empty(t, sameNameNodes, "targeted by executable path")
+}
+
+func Test_withDescendants(t *testing.T) {
+	// wrapper script, the node is its child, eg: cosmovisor
+	wrapper := exec.Command("/bin/sh", "-c", "sleep 30; true")
+	require.NoError(t, wrapper.Start())
+	defer func() {
+		_ = wrapper.Process.Kill()
+		_ = wrapper.Wait()
+	}()
+	wrapperPid := int32(wrapper.Process.Pid)
+
+	var candidates []Candidate
+	require.Eventually(t, func() bool {
+		var err error
+		candidates, err = withDescendants(wrapperPid, "MainPID of systemd unit gaiad")
+		require.NoError(t, err)
+		return len(candidates) > 1
+	}, 5*time.Second, 10*time.Millisecond, "child of the wrapper is not found")
+
+	require.Len(t, candidates, 2)
+	child := candidates[0]
+	require.True(t, child.Matched)
+	require.NotEqual(t, wrapperPid, child.Pid)
+	require.Contains(t, child.Reason, "descendant of PID")
+	ppid, err := (&process.Process{Pid: child.Pid}).Ppid()
+	require.NoError(t, err)
+	require.Equal(t, wrapperPid, ppid)
+
+	require.Equal(t, wrapperPid, candidates[1].Pid, "supervisor is killed after its descendants")
+	require.True(t, candidates[1].Matched)
+}
+
+func Test_descendantsOf(t *testing.T) {
+	parentOf := map[int32]int32{
+		1:  0,
+		10: 1,  // supervisor
+		11: 10, // node
+		12: 11, // child of node
+		13: 10, // another child of supervisor
+		20: 1,  // unrelated
+	}
+	require.Equal(t, []int32{12, 13, 11}, descendantsOf(10, parentOf))
+	require.Empty(t, descendantsOf(20, parentOf))
+}
@@ -0,0 +1,173 @@
+package pvs_homes
+
+import (
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MinKeepRecent is the minimum number of recent blocks to keep backup of.
+const MinKeepRecent = 3
+
+// Config is the content of the multi-home config file of auto-backup-pvs.
+//
+//	[[home]]
+//	name = "gaia-mainnet"
+//	home = "/home/val/.gaia"
+//	binary = "/home/val/go/bin/gaiad"
+//	service-name = "gaiad"
+//	keep = 5
+//
+//	[[home]]
+//	name = "gaia-testnet"
+//	home = "/data/.gaia-testnet"
+//	pid-file = "/run/gaiad-testnet.pid"
+//	backup-dir = "/data/.backup_pvs_gaia-testnet"
+//	state-file = "/home/val/.horcrux/state/theta-testnet-001_priv_validator_state.json"
+type Config struct {
+	Homes []HomeConfig `toml:"home"`
+}
+
+// HomeConfig is the node home to be protected, with its own backup directory and process targeting rule.
+type HomeConfig struct {
+	// Name identifies the home in logs, alerts and default file names, default is the home directory name
+	Name string `toml:"name"`
+	Home string `toml:"home"`
+	// Binary is the node binary, the process of it started with the home is killed, unless service-name or pid-file is provided
+	Binary      string `toml:"binary"`
+	ServiceName string `toml:"service-name"`
+	PidFile     string `toml:"pid-file"`
+	// StateFile overrides data/priv_validator_state.json, eg: sign-state file of remote signer
+	StateFile string `toml:"state-file"`
+	// BackupDir default is <default backup dir>_<name>
+	BackupDir string `toml:"backup-dir"`
+	// Keep backup of the last N blocks, default and minimum is MinKeepRecent
+	Keep int `toml:"keep"`
+}
+
+var regexName = regexp.MustCompile(`^[a-zA-Z\d][a-zA-Z\d_.-]*$`)
+
+// LoadConfig reads the multi-home config file, fills the defaults and validates.
+// The default backup directory of each home is defaultBackupDirPrefix suffixed by the name of the home.
+func LoadConfig(filePath, defaultBackupDirPrefix string) (Config, error) {
+	var config Config
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return config, errors.Wrapf(err, "failed to open config file %s", filePath)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if err := toml.NewDecoder(file).DisallowUnknownFields().Decode(&config); err != nil {
+		return config, errors.Wrapf(err, "failed to parse config file %s", filePath)
+	}
+
+	if err := config.normalize(defaultBackupDirPrefix); err != nil {
+		return config, errors.Wrapf(err, "invalid config file %s", filePath)
+	}
+
+	return config, nil
+}
+
+// normalize fills the defaults then validates, the homes must not share any name, home, backup directory or state file.
+func (c *Config) normalize(defaultBackupDirPrefix string) error {
+	if len(c.Homes) < 1 {
+		return fmt.Errorf("require at least one [[home]]")
+	}
+
+	uniqueness := make(map[string]string)
+	requireUnique := func(field, value string, homeIdx int) error {
+		key := field + "=" + value
+		if previous, found := uniqueness[key]; found {
+			return fmt.Errorf("home #%d: %s %s is already used by home %s", homeIdx+1, field, value, previous)
+		}
+		uniqueness[key] = c.Homes[homeIdx].Name
+		return nil
+	}
+
+	for i := range c.Homes {
+		home := &c.Homes[i]
+
+		if err := home.normalize(defaultBackupDirPrefix); err != nil {
+			return errors.Wrapf(err, "home #%d", i+1)
+		}
+
+		if err := requireUnique("name", home.Name, i); err != nil {
+			return err
+		}
+		if err := requireUnique("home", home.Home, i); err != nil {
+			return err
+		}
+		if err := requireUnique("backup-dir", home.BackupDir, i); err != nil {
+			return err
+		}
+		if err := requireUnique("state-file", home.StateFilePath(), i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *HomeConfig) normalize(defaultBackupDirPrefix string) error {
+	h.Home = strings.TrimSuffix(strings.TrimSpace(h.Home), "/")
+	if h.Home == "" {
+		return fmt.Errorf("require home")
+	}
+	if !filepath.IsAbs(h.Home) {
+		return fmt.Errorf("home must be absolute path: %s", h.Home)
+	}
+
+	if h.Name == "" {
+		h.Name = strings.TrimPrefix(filepath.Base(h.Home), ".")
+	}
+	if !regexName.MatchString(h.Name) {
+		return fmt.Errorf("invalid name \"%s\", only letters, digits, '_', '.' and '-' are allowed", h.Name)
+	}
+
+	if h.Binary == "" && h.ServiceName == "" && h.PidFile == "" {
+		return fmt.Errorf("require binary, service-name or pid-file to target the node process")
+	}
+	if h.ServiceName != "" && h.PidFile != "" {
+		return fmt.Errorf("only one of service-name and pid-file can be used")
+	}
+	for field, filePath := range map[string]string{
+		"binary":     h.Binary,
+		"pid-file":   h.PidFile,
+		"state-file": h.StateFile,
+		"backup-dir": h.BackupDir,
+	} {
+		if filePath != "" && !filepath.IsAbs(filePath) {
+			return fmt.Errorf("%s must be absolute path: %s", field, filePath)
+		}
+	}
+
+	if h.BackupDir == "" {
+		h.BackupDir = fmt.Sprintf("%s_%s", defaultBackupDirPrefix, h.Name)
+	}
+	h.BackupDir = filepath.Clean(h.BackupDir)
+	if h.BackupDir == h.Home || strings.HasPrefix(h.BackupDir, h.Home+"/") {
+		// restoring snapshot or resetting the node would remove the backups
+		return fmt.Errorf("backup-dir must not be inside the home: %s", h.BackupDir)
+	}
+
+	if h.Keep < MinKeepRecent {
+		h.Keep = MinKeepRecent
+	}
+
+	return nil
+}
+
+// StateFilePath returns the state file to be watched.
+func (h HomeConfig) StateFilePath() string {
+	if h.StateFile != "" {
+		return h.StateFile
+	}
+	return filepath.Join(h.Home, "data", "priv_validator_state.json")
+}
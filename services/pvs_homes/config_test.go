@@ -0,0 +1,148 @@
+package pvs_homes
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	const defaultBackupDirPrefix = "/home/val/.backup_priv_validator_state_nmngd"
+
+	load := func(t *testing.T, content string) (Config, error) {
+		configFilePath := path.Join(t.TempDir(), "homes.toml")
+		require.NoError(t, os.WriteFile(configFilePath, []byte(content), 0o600))
+		return LoadConfig(configFilePath, defaultBackupDirPrefix)
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		config, err := load(t, `
+[[home]]
+home = "/home/val/.gaia/"
+binary = "/home/val/go/bin/gaiad"
+
+[[home]]
+name = "gaia-testnet"
+home = "/data/.gaia-testnet"
+pid-file = "/run/gaiad-testnet.pid"
+backup-dir = "/data/backup/"
+state-file = "/home/val/.horcrux/state/theta-testnet-001_priv_validator_state.json"
+keep = 10
+`)
+		require.NoError(t, err)
+		require.Equal(t, []HomeConfig{
+			{
+				Name:      "gaia",
+				Home:      "/home/val/.gaia",
+				Binary:    "/home/val/go/bin/gaiad",
+				BackupDir: defaultBackupDirPrefix + "_gaia",
+				Keep:      MinKeepRecent,
+			},
+			{
+				Name:      "gaia-testnet",
+				Home:      "/data/.gaia-testnet",
+				PidFile:   "/run/gaiad-testnet.pid",
+				StateFile: "/home/val/.horcrux/state/theta-testnet-001_priv_validator_state.json",
+				BackupDir: "/data/backup",
+				Keep:      10,
+			},
+		}, config.Homes)
+		require.Equal(t, "/home/val/.gaia/data/priv_validator_state.json", config.Homes[0].StateFilePath())
+		require.Equal(t, "/home/val/.horcrux/state/theta-testnet-001_priv_validator_state.json", config.Homes[1].StateFilePath())
+	})
+
+	tests := []struct {
+		name            string
+		content         string
+		wantErrContains string
+	}{
+		{
+			name:            "no home",
+			content:         ``,
+			wantErrContains: "require at least one",
+		},
+		{
+			name:            "unknown field",
+			content:         "[[home]]\nhome = \"/home/val/.gaia\"\nbinary = \"/usr/bin/gaiad\"\nunknown = 1",
+			wantErrContains: "failed to parse",
+		},
+		{
+			name:            "relative home",
+			content:         "[[home]]\nhome = \".gaia\"\nbinary = \"/usr/bin/gaiad\"",
+			wantErrContains: "home must be absolute path",
+		},
+		{
+			name:            "no process targeting rule",
+			content:         "[[home]]\nhome = \"/home/val/.gaia\"",
+			wantErrContains: "require binary, service-name or pid-file",
+		},
+		{
+			name:            "both service name and pidfile",
+			content:         "[[home]]\nhome = \"/home/val/.gaia\"\nservice-name = \"gaiad\"\npid-file = \"/run/gaiad.pid\"",
+			wantErrContains: "only one of service-name and pid-file",
+		},
+		{
+			name:            "relative binary",
+			content:         "[[home]]\nhome = \"/home/val/.gaia\"\nbinary = \"gaiad\"",
+			wantErrContains: "binary must be absolute path",
+		},
+		{
+			name:            "invalid name",
+			content:         "[[home]]\nname = \"a/b\"\nhome = \"/home/val/.gaia\"\nbinary = \"/usr/bin/gaiad\"",
+			wantErrContains: "invalid name",
+		},
+		{
+			name:            "backup dir inside home",
+			content:         "[[home]]\nhome = \"/home/val/.gaia\"\nbinary = \"/usr/bin/gaiad\"\nbackup-dir = \"/home/val/.gaia/backup\"",
+			wantErrContains: "must not be inside the home",
+		},
+		{
+			name: "duplicated name",
+			content: `
+[[home]]
+home = "/home/val/.gaia"
+binary = "/usr/bin/gaiad"
+[[home]]
+home = "/data/.gaia"
+binary = "/usr/bin/gaiad"
+`,
+			wantErrContains: "name gaia is already used",
+		},
+		{
+			name: "duplicated backup dir",
+			content: `
+[[home]]
+home = "/home/val/.gaia"
+binary = "/usr/bin/gaiad"
+backup-dir = "/data/backup"
+[[home]]
+home = "/home/val/.osmosisd"
+binary = "/usr/bin/osmosisd"
+backup-dir = "/data/backup/"
+`,
+			wantErrContains: "backup-dir /data/backup is already used by home gaia",
+		},
+		{
+			name: "duplicated state file",
+			content: `
+[[home]]
+home = "/home/val/.gaia"
+binary = "/usr/bin/gaiad"
+state-file = "/home/val/.horcrux/state/cosmoshub-4_priv_validator_state.json"
+[[home]]
+home = "/home/val/.gaia-2"
+binary = "/usr/bin/gaiad"
+state-file = "/home/val/.horcrux/state/cosmoshub-4_priv_validator_state.json"
+`,
+			wantErrContains: "state-file",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := load(t, tt.content)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErrContains)
+		})
+	}
+}
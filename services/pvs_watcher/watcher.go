@@ -0,0 +1,203 @@
+package pvs_watcher
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// rewatchInterval is the interval of retrying to watch the parent directory after it was removed.
+const rewatchInterval = 100 * time.Millisecond
+
+// Source is where the change notification comes from.
+type Source string
+
+const (
+	SourceInotify Source = "inotify"
+	SourcePoll    Source = "poll"
+	SourceTrigger Source = "trigger"
+)
+
+// Change notifies the watched file possibly changed, the file should be re-read.
+type Change struct {
+	// Time is when the change was detected, used to measure reaction time
+	Time   time.Time
+	Source Source
+}
+
+// Watcher watches the state file using inotify (write/create/rename/remove events of the parent directory,
+// so atomic writes via rename are covered), plus polling at the given interval as a safety net.
+// When inotify is not available (eg: network filesystems), it falls back to polling only.
+// When the parent directory is removed or renamed (eg: restoring snapshot, unsafe-reset-all, state-sync),
+// it polls only until the directory is re-created then watches it again.
+type Watcher struct {
+	filePath     string
+	dir          string
+	pollInterval time.Duration
+	fsWatcher    *fsnotify.Watcher // nil when inotify is not available
+	inotifyErr   error
+	rewatching   atomic.Bool
+
+	changes   chan Change
+	stop      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewWatcher starts watching the file. Inotify failure is not an error, it falls back to polling,
+// check InotifyError.
+func NewWatcher(filePath string, pollInterval time.Duration) (*Watcher, error) {
+	if pollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+	filePath = filepath.Clean(filePath)
+
+	w := &Watcher{
+		filePath:     filePath,
+		dir:          filepath.Dir(filePath),
+		pollInterval: pollInterval,
+		// changes are coalesced, the file is re-read after receiving so no content is missed in between
+		changes: make(chan Change, 1),
+		stop:    make(chan struct{}),
+	}
+
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		// watch the parent directory, the node writes the state file atomically by renaming a temp file
+		if err = fsWatcher.Add(w.dir); err != nil {
+			_ = fsWatcher.Close()
+		}
+	}
+	if err != nil {
+		w.inotifyErr = errors.Wrap(err, "inotify is not available")
+	} else {
+		w.fsWatcher = fsWatcher
+		w.wg.Add(1)
+		go w.watchInotify()
+	}
+
+	w.wg.Add(1)
+	go w.poll()
+
+	return w, nil
+}
+
+// InotifyError returns the reason inotify is not available, nil if inotify is used.
+func (w *Watcher) InotifyError() error {
+	return w.inotifyErr
+}
+
+// Changes returns the channel of change notifications.
+func (w *Watcher) Changes() <-chan Change {
+	return w.changes
+}
+
+// Trigger requests the file to be re-read immediately.
+func (w *Watcher) Trigger() {
+	w.notify(SourceTrigger)
+}
+
+// Close stops watching.
+func (w *Watcher) Close() {
+	w.closeOnce.Do(func() {
+		close(w.stop)
+		if w.fsWatcher != nil {
+			_ = w.fsWatcher.Close()
+		}
+		w.wg.Wait()
+	})
+}
+
+func (w *Watcher) notify(source Source) {
+	select {
+	case w.changes <- Change{Time: time.Now(), Source: source}:
+	default:
+		// a change is pending, the file will be re-read anyway
+	}
+}
+
+func (w *Watcher) watchInotify() {
+	defer w.wg.Done()
+
+	const ops = fsnotify.Write | fsnotify.Create | fsnotify.Rename | fsnotify.Remove
+	for {
+		select {
+		case <-w.stop:
+			return
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			eventPath := filepath.Clean(event.Name)
+			if eventPath == w.dir && event.Op.Has(fsnotify.Remove|fsnotify.Rename) {
+				// the watch is gone with the directory, the state file is gone too
+				w.notify(SourceInotify)
+				if w.rewatching.CompareAndSwap(false, true) {
+					w.wg.Add(1)
+					go w.rewatch()
+				}
+				continue
+			}
+			if eventPath != w.filePath || !event.Op.Has(ops) {
+				continue
+			}
+			w.notify(SourceInotify)
+		case _, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			// events might be lost (eg: queue overflow), re-read to be safe
+			w.notify(SourceInotify)
+		}
+	}
+}
+
+// rewatch watches the parent directory again, retrying until it is re-created.
+func (w *Watcher) rewatch() {
+	defer w.wg.Done()
+	defer w.rewatching.Store(false)
+
+	utils.PrintlnStdErr("WARN: directory", w.dir, "was removed, inotify watch is lost, fallback to polling the state file every", w.pollInterval, "until it is re-created")
+
+	// renamed directory is still watched at its new path
+	_ = w.fsWatcher.Remove(w.dir)
+
+	ticker := time.NewTicker(rewatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			if err := w.fsWatcher.Add(w.dir); err != nil {
+				continue
+			}
+			fmt.Println("INF: directory", w.dir, "is re-created, watching the state file using inotify again")
+			// the state file might be written before watching
+			w.notify(SourceInotify)
+			return
+		}
+	}
+}
+
+func (w *Watcher) poll() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.notify(SourcePoll)
+		}
+	}
+}
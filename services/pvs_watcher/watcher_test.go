@@ -0,0 +1,156 @@
+package pvs_watcher
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestWatcher(t *testing.T) {
+	dir := t.TempDir()
+	filePath := path.Join(dir, "priv_validator_state.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{}`), 0o600))
+
+	// long poll interval, so changes can only come from inotify
+	w, err := NewWatcher(filePath, time.Hour)
+	require.NoError(t, err)
+	defer w.Close()
+	if w.InotifyError() != nil {
+		t.Skip(w.InotifyError())
+	}
+
+	expectChange := func(t *testing.T, source Source) {
+		select {
+		case change := <-w.Changes():
+			require.Equal(t, source, change.Source)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no change notified")
+		}
+	}
+	expectNoChange := func(t *testing.T) {
+		select {
+		case change := <-w.Changes():
+			t.Fatalf("unexpected change from %s", change.Source)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+
+	t.Run("write", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filePath, []byte(`{"height":"1"}`), 0o600))
+		expectChange(t, SourceInotify)
+	})
+
+	t.Run("atomic write via rename", func(t *testing.T) {
+		time.Sleep(50 * time.Millisecond)
+		for len(w.Changes()) > 0 {
+			<-w.Changes()
+		}
+
+		tmpFilePath := path.Join(dir, "tmp")
+		require.NoError(t, os.WriteFile(tmpFilePath, []byte(`{"height":"2"}`), 0o600))
+		expectNoChange(t)
+		require.NoError(t, os.Rename(tmpFilePath, filePath))
+		expectChange(t, SourceInotify)
+	})
+
+	t.Run("other files are ignored", func(t *testing.T) {
+		time.Sleep(50 * time.Millisecond)
+		for len(w.Changes()) > 0 {
+			<-w.Changes()
+		}
+
+		require.NoError(t, os.WriteFile(path.Join(dir, "other.json"), []byte(`{}`), 0o600))
+		expectNoChange(t)
+	})
+
+	t.Run("trigger", func(t *testing.T) {
+		w.Trigger()
+		expectChange(t, SourceTrigger)
+	})
+}
+
+func TestWatcher_directoryRecreated(t *testing.T) {
+	dir := path.Join(t.TempDir(), "data")
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	filePath := path.Join(dir, "priv_validator_state.json")
+	require.NoError(t, os.WriteFile(filePath, []byte(`{}`), 0o600))
+
+	// long poll interval, so changes can only come from inotify
+	w, err := NewWatcher(filePath, time.Hour)
+	require.NoError(t, err)
+	defer w.Close()
+	if w.InotifyError() != nil {
+		t.Skip(w.InotifyError())
+	}
+
+	drain := func() {
+		for {
+			select {
+			case <-w.Changes():
+			case <-time.After(3 * rewatchInterval):
+				return
+			}
+		}
+	}
+	expectInotifyChange := func(t *testing.T) {
+		select {
+		case change := <-w.Changes():
+			require.Equal(t, SourceInotify, change.Source)
+		case <-time.After(5 * time.Second):
+			t.Fatal("no change notified")
+		}
+	}
+
+	// eg: unsafe-reset-all, restoring snapshot
+	require.NoError(t, os.RemoveAll(dir))
+	expectInotifyChange(t)
+	drain()
+
+	require.NoError(t, os.Mkdir(dir, 0o700))
+	drain() // wait for re-watching
+
+	require.NoError(t, os.WriteFile(filePath, []byte(`{"height":"1"}`), 0o600))
+	expectInotifyChange(t)
+
+	t.Run("renamed", func(t *testing.T) {
+		drain()
+		require.NoError(t, os.Rename(dir, dir+".bak"))
+		expectInotifyChange(t)
+		drain()
+
+		require.NoError(t, os.Mkdir(dir, 0o700))
+		drain()
+
+		require.NoError(t, os.WriteFile(filePath, []byte(`{"height":"2"}`), 0o600))
+		expectInotifyChange(t)
+
+		// the renamed directory is no longer watched
+		drain()
+		require.NoError(t, os.WriteFile(path.Join(dir+".bak", "priv_validator_state.json"), []byte(`{"height":"3"}`), 0o600))
+		select {
+		case change := <-w.Changes():
+			t.Fatalf("unexpected change from %s", change.Source)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+}
+
+func TestWatcher_poll(t *testing.T) {
+	filePath := path.Join(t.TempDir(), "priv_validator_state.json")
+
+	w, err := NewWatcher(filePath, 10*time.Millisecond)
+	require.NoError(t, err)
+	defer w.Close()
+
+	select {
+	case change := <-w.Changes():
+		require.Equal(t, SourcePoll, change.Source)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notified")
+	}
+
+	_, err = NewWatcher(filePath, 0)
+	require.Error(t, err)
+}
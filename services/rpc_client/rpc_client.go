@@ -0,0 +1,177 @@
+package rpc_client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RpcClient is a minimal client of the CometBFT RPC.
+type RpcClient struct {
+	endpoint   string
+	httpClient *http.Client
+	maxRetries int
+	retryDelay time.Duration
+}
+
+func NewRpcClient(endpoint string, timeout time.Duration) *RpcClient {
+	return &RpcClient{
+		endpoint: strings.TrimSuffix(strings.TrimSpace(endpoint), "/"),
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// WithRetry returns a copy of the client, which retries failed requests up to maxRetries times.
+// Only network errors and unexpected responses are retried, errors returned by the RPC are not.
+func (c *RpcClient) WithRetry(maxRetries int, retryDelay time.Duration) *RpcClient {
+	clone := *c
+	clone.maxRetries = maxRetries
+	clone.retryDelay = retryDelay
+	return &clone
+}
+
+func (c *RpcClient) Endpoint() string {
+	return c.endpoint
+}
+
+func (c *RpcClient) Status(ctx context.Context) (*ResultStatus, error) {
+	var result ResultStatus
+	if err := c.get(ctx, "/status", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *RpcClient) AbciInfo(ctx context.Context) (*ResultAbciInfo, error) {
+	var result ResultAbciInfo
+	if err := c.get(ctx, "/abci_info", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Block returns the block at the given height, or the latest block if height is zero.
+func (c *RpcClient) Block(ctx context.Context, height int64) (*ResultBlock, error) {
+	var result ResultBlock
+	if err := c.get(ctx, "/block", heightQuery(height), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Commit returns the commit of the block at the given height, or the latest commit if height is zero.
+func (c *RpcClient) Commit(ctx context.Context, height int64) (*ResultCommit, error) {
+	var result ResultCommit
+	if err := c.get(ctx, "/commit", heightQuery(height), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *RpcClient) NetInfo(ctx context.Context) (*ResultNetInfo, error) {
+	var result ResultNetInfo
+	if err := c.get(ctx, "/net_info", nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Validators returns a page of the validator set at the given height, or the latest if height is zero.
+// Page starts from 1, zero page or perPage uses the default of the node.
+func (c *RpcClient) Validators(ctx context.Context, height int64, page, perPage int) (*ResultValidators, error) {
+	query := heightQuery(height)
+	if page > 0 {
+		query.Set("page", strconv.Itoa(page))
+	}
+	if perPage > 0 {
+		query.Set("per_page", strconv.Itoa(perPage))
+	}
+
+	var result ResultValidators
+	if err := c.get(ctx, "/validators", query, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func heightQuery(height int64) url.Values {
+	query := url.Values{}
+	if height > 0 {
+		query.Set("height", strconv.FormatInt(height, 10))
+	}
+	return query
+}
+
+func (c *RpcClient) get(ctx context.Context, path string, query url.Values, result any) error {
+	url := c.endpoint + path
+	if len(query) > 0 {
+		url += "?" + query.Encode()
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		var retryable bool
+		retryable, err = c.getOnce(ctx, url, result)
+		if err == nil || !retryable || attempt >= c.maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(c.retryDelay):
+		}
+	}
+}
+
+func (c *RpcClient) getOnce(ctx context.Context, url string, result any) (retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to create request %s", url)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, errors.Wrapf(err, "failed to request %s", url)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	bz, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return true, errors.Wrapf(err, "failed to read response body of %s", url)
+	}
+
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(bz, &rpcResp); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return true, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+		}
+		return true, errors.Wrapf(err, "failed to unmarshal response of %s", url)
+	}
+	if rpcResp.Error != nil {
+		return false, errors.Wrapf(rpcResp.Error, "rpc error from %s", url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return true, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	if len(rpcResp.Result) == 0 {
+		return true, fmt.Errorf("empty result from %s", url)
+	}
+
+	if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+		return false, errors.Wrapf(err, "failed to unmarshal result of %s", url)
+	}
+
+	return false, nil
+}
@@ -0,0 +1,80 @@
+package rpc_client
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRpcClient_Block(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/block", r.URL.Path)
+		require.Equal(t, "100", r.URL.Query().Get("height"))
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"block_id":{"hash":"ABCD","parts":{"total":1,"hash":"EF"}},"block":{"header":{"chain_id":"c-1","height":"100","time":"2024-01-01T00:00:00Z"}}}}`))
+	}))
+	defer server.Close()
+
+	block, err := NewRpcClient(server.URL+"/", time.Second).Block(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, "ABCD", block.BlockID.Hash)
+	require.Equal(t, Int64(1), block.BlockID.PartSetHeader.Total)
+	require.Equal(t, "c-1", block.Block.Header.ChainID)
+	require.Equal(t, Int64(100), block.Block.Header.Height)
+}
+
+func TestRpcClient_Retry(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"sync_info":{"latest_block_height":"5","catching_up":true}}}`))
+	}))
+	defer server.Close()
+
+	client := NewRpcClient(server.URL, time.Second)
+
+	_, err := client.Status(context.Background())
+	require.Error(t, err, "should not retry by default")
+	require.Equal(t, int32(1), requests.Load())
+
+	status, err := client.WithRetry(3, time.Millisecond).Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(3), requests.Load())
+	require.Equal(t, Int64(5), status.SyncInfo.LatestBlockHeight)
+	require.True(t, status.SyncInfo.CatchingUp)
+}
+
+func TestRpcClient_RpcErrorIsNotRetried(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"height 100 must be less than or equal to the current blockchain height 50"}}`))
+	}))
+	defer server.Close()
+
+	_, err := NewRpcClient(server.URL, time.Second).WithRetry(3, time.Millisecond).Commit(context.Background(), 100)
+	require.ErrorContains(t, err, "must be less than or equal")
+	require.Equal(t, int32(1), requests.Load())
+}
+
+func TestRpcClient_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	startedAt := time.Now()
+	_, err := NewRpcClient(server.URL, time.Minute).WithRetry(10, time.Second).NetInfo(ctx)
+	require.Error(t, err)
+	require.Less(t, time.Since(startedAt), time.Second, "should not retry after context is done")
+}
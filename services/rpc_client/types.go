@@ -0,0 +1,188 @@
+package rpc_client
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Int64 is an int64 which can be decoded from both JSON number and JSON string,
+// CometBFT encodes 64-bit integers as strings but some forks do not.
+type Int64 int64
+
+func (i *Int64) UnmarshalJSON(bz []byte) error {
+	str := strings.Trim(string(bz), `"`)
+	if str == "" || str == "null" {
+		*i = 0
+		return nil
+	}
+	v, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = Int64(v)
+	return nil
+}
+
+func (i Int64) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(i))
+}
+
+type rpcResponse struct {
+	JsonRpc string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result"`
+	Error   *RpcError       `json:"error"`
+}
+
+type RpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e RpcError) Error() string {
+	if e.Data != "" {
+		return e.Message + ": " + e.Data
+	}
+	return e.Message
+}
+
+type PubKey struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type ResultStatus struct {
+	NodeInfo      NodeInfo      `json:"node_info"`
+	SyncInfo      SyncInfo      `json:"sync_info"`
+	ValidatorInfo ValidatorInfo `json:"validator_info"`
+}
+
+type NodeInfo struct {
+	ProtocolVersion struct {
+		P2P   Int64 `json:"p2p"`
+		Block Int64 `json:"block"`
+		App   Int64 `json:"app"`
+	} `json:"protocol_version"`
+	ID         string `json:"id"`
+	ListenAddr string `json:"listen_addr"`
+	Network    string `json:"network"`
+	Version    string `json:"version"`
+	Moniker    string `json:"moniker"`
+	Other      struct {
+		TxIndex    string `json:"tx_index"`
+		RpcAddress string `json:"rpc_address"`
+	} `json:"other"`
+}
+
+type SyncInfo struct {
+	LatestBlockHash     string    `json:"latest_block_hash"`
+	LatestAppHash       string    `json:"latest_app_hash"`
+	LatestBlockHeight   Int64     `json:"latest_block_height"`
+	LatestBlockTime     time.Time `json:"latest_block_time"`
+	EarliestBlockHeight Int64     `json:"earliest_block_height"`
+	EarliestBlockTime   time.Time `json:"earliest_block_time"`
+	CatchingUp          bool      `json:"catching_up"`
+}
+
+type ValidatorInfo struct {
+	Address     string `json:"address"`
+	PubKey      PubKey `json:"pub_key"`
+	VotingPower Int64  `json:"voting_power"`
+}
+
+type ResultAbciInfo struct {
+	Response struct {
+		Data             string `json:"data"`
+		Version          string `json:"version"`
+		AppVersion       Int64  `json:"app_version"`
+		LastBlockHeight  Int64  `json:"last_block_height"`
+		LastBlockAppHash string `json:"last_block_app_hash"`
+	} `json:"response"`
+}
+
+type BlockID struct {
+	Hash          string `json:"hash"`
+	PartSetHeader struct {
+		Total Int64  `json:"total"`
+		Hash  string `json:"hash"`
+	} `json:"parts"`
+}
+
+type Header struct {
+	Version struct {
+		Block Int64 `json:"block"`
+		App   Int64 `json:"app"`
+	} `json:"version"`
+	ChainID            string    `json:"chain_id"`
+	Height             Int64     `json:"height"`
+	Time               time.Time `json:"time"`
+	LastBlockID        BlockID   `json:"last_block_id"`
+	LastCommitHash     string    `json:"last_commit_hash"`
+	DataHash           string    `json:"data_hash"`
+	ValidatorsHash     string    `json:"validators_hash"`
+	NextValidatorsHash string    `json:"next_validators_hash"`
+	ConsensusHash      string    `json:"consensus_hash"`
+	AppHash            string    `json:"app_hash"`
+	LastResultsHash    string    `json:"last_results_hash"`
+	EvidenceHash       string    `json:"evidence_hash"`
+	ProposerAddress    string    `json:"proposer_address"`
+}
+
+type CommitSig struct {
+	BlockIDFlag      int       `json:"block_id_flag"`
+	ValidatorAddress string    `json:"validator_address"`
+	Timestamp        time.Time `json:"timestamp"`
+	Signature        string    `json:"signature"`
+}
+
+type Commit struct {
+	Height     Int64       `json:"height"`
+	Round      int         `json:"round"`
+	BlockID    BlockID     `json:"block_id"`
+	Signatures []CommitSig `json:"signatures"`
+}
+
+type ResultBlock struct {
+	BlockID BlockID `json:"block_id"`
+	Block   struct {
+		Header     Header  `json:"header"`
+		LastCommit *Commit `json:"last_commit"`
+	} `json:"block"`
+}
+
+type ResultCommit struct {
+	SignedHeader struct {
+		Header Header `json:"header"`
+		Commit Commit `json:"commit"`
+	} `json:"signed_header"`
+	Canonical bool `json:"canonical"`
+}
+
+type Peer struct {
+	NodeInfo   NodeInfo `json:"node_info"`
+	IsOutbound bool     `json:"is_outbound"`
+	RemoteIP   string   `json:"remote_ip"`
+}
+
+type ResultNetInfo struct {
+	Listening bool     `json:"listening"`
+	Listeners []string `json:"listeners"`
+	NPeers    Int64    `json:"n_peers"`
+	Peers     []Peer   `json:"peers"`
+}
+
+type Validator struct {
+	Address          string `json:"address"`
+	PubKey           PubKey `json:"pub_key"`
+	VotingPower      Int64  `json:"voting_power"`
+	ProposerPriority Int64  `json:"proposer_priority"`
+}
+
+type ResultValidators struct {
+	BlockHeight Int64       `json:"block_height"`
+	Validators  []Validator `json:"validators"`
+	Count       Int64       `json:"count"`
+	Total       Int64       `json:"total"`
+}
@@ -0,0 +1,280 @@
+package setup_check
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/validation"
+	"github.com/pkg/errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	SeverityFatal = "fatal"
+	SeverityWarn  = "warn"
+)
+
+const (
+	StatusPassed       = "passed"
+	StatusFatal        = "fatal"
+	StatusWarningsOnly = "warn"
+	// StatusError indicates the check could not complete
+	StatusError = "error"
+)
+
+// Options of checking a node home.
+type Options struct {
+	Home     string
+	NodeType types.NodeType
+	// ServiceFilePath is the systemd service file of the validator node, not checked if empty
+	ServiceFilePath string
+	// Policy is the thresholds and the suppressed rules, default policy is used if nil
+	Policy *Policy
+	// LatestRelease is the latest release version of this tool, reported if differs from the running version, not checked if empty
+	LatestRelease string
+	// SentryNodeIds are node IDs of the sentries the validator node is behind, validator-behind-sentry rules are not checked if empty
+	SentryNodeIds []string
+	// ValidatorNodeId is node ID of the validator node behind the sentry node, not checked if empty
+	ValidatorNodeId string
+	// Rpc is the RPC endpoint of the running node, to verify the running node matches the config, not checked if empty
+	Rpc string
+	// Binary is the chain binary, app version reported by "<binary> version" is compared with the running node, requires Rpc
+	Binary string
+}
+
+// Checker checks setup of a node home. Each run starts from scratch, so it can be run again, eg: after applying fixes.
+type Checker struct {
+	opts   Options
+	policy Policy
+
+	records           []checkRecord
+	suppressedRecords []checkRecord
+	// remoteSigner is detected from config of the validator node, the key and the double-sign protection state are kept by the signer
+	remoteSigner bool
+
+	// failure is the reason the checker could not run at all, eg: failed to load the policy
+	failure    error
+	lastReport Report
+}
+
+func NewChecker(opts Options) (*Checker, error) {
+	if opts.Home == "" {
+		return nil, fmt.Errorf("node home is required")
+	}
+	if opts.NodeType == types.UnspecifiedNodeType {
+		return nil, fmt.Errorf("node type is required")
+	}
+	if opts.ServiceFilePath != "" && opts.NodeType != types.ValidatorNode {
+		return nil, fmt.Errorf("service file can only be checked for validator node")
+	}
+	if len(opts.SentryNodeIds) > 0 && opts.NodeType != types.ValidatorNode {
+		return nil, fmt.Errorf("sentry node IDs can only be provided for validator node")
+	}
+	for _, sentryNodeId := range opts.SentryNodeIds {
+		if !validation.IsValidNodeId(sentryNodeId) {
+			return nil, fmt.Errorf("invalid sentry node ID: %s", sentryNodeId)
+		}
+	}
+	if opts.Binary != "" && opts.Rpc == "" {
+		return nil, fmt.Errorf("binary can only be checked with RPC of the running node")
+	}
+	if opts.ValidatorNodeId != "" {
+		if opts.NodeType != types.SentryNode {
+			return nil, fmt.Errorf("validator node ID can only be provided for sentry node")
+		}
+		if !validation.IsValidNodeId(opts.ValidatorNodeId) {
+			return nil, fmt.Errorf("invalid validator node ID: %s", opts.ValidatorNodeId)
+		}
+	}
+
+	policy := DefaultPolicy()
+	if opts.Policy != nil {
+		policy = *opts.Policy
+		if policy.Suppressions == nil {
+			policy.Suppressions = make(map[string]string)
+		}
+	}
+
+	return &Checker{
+		opts:   opts,
+		policy: policy,
+	}, nil
+}
+
+// NewFailedChecker returns a checker which could not run, eg: failed to load the policy or invalid options.
+// Its report is the error, the home is not checked.
+func NewFailedChecker(opts Options, err error) *Checker {
+	c := &Checker{
+		opts:    opts,
+		policy:  DefaultPolicy(),
+		failure: err,
+	}
+	c.lastReport = c.report(err)
+	return c
+}
+
+// Report is the result of a check run.
+type Report struct {
+	Home     string `json:"home"`
+	NodeType string `json:"node_type"`
+	Status   string `json:"status"`
+	// Error is the reason the check could not complete, findings found before the error are still reported
+	Error string `json:"error,omitempty"`
+	// Findings are sorted, fatal first
+	Findings []Finding `json:"findings"`
+	// Suppressed are findings of rules suppressed by the policy, they do not affect the status
+	Suppressed []Finding `json:"suppressed"`
+	// RemoteSigner indicates the validator node uses a remote signer (eg: tmkms, horcrux), rules were checked accordingly
+	RemoteSigner bool `json:"remote_signer,omitempty"`
+}
+
+type Finding struct {
+	RuleId   string `json:"rule_id"`
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Key      string `json:"key,omitempty"`
+	Message  string `json:"message"`
+	Suggest  string `json:"suggest,omitempty"`
+	// Fix is the description of the automatic remediation, if any
+	Fix            string `json:"fix,omitempty"`
+	SuppressReason string `json:"suppress_reason,omitempty"`
+}
+
+func (f Finding) Fatal() bool {
+	return f.Severity == SeverityFatal
+}
+
+// Run checks the node home and returns the report, records of the previous run are discarded.
+func (c *Checker) Run() Report {
+	c.records = nil
+	c.suppressedRecords = nil
+	c.remoteSigner = false
+
+	if c.failure != nil {
+		c.lastReport = c.report(c.failure)
+		return c.lastReport
+	}
+
+	if c.opts.LatestRelease != "" {
+		c.checkLatestRelease(c.opts.LatestRelease)
+	}
+
+	c.lastReport = c.report(c.runChecks())
+	return c.lastReport
+}
+
+// Report returns the report of the last run, or the error when the checker could not run.
+func (c *Checker) Report() Report {
+	return c.lastReport
+}
+
+func (c *Checker) runChecks() error {
+	home := c.opts.Home
+	nodeType := c.opts.NodeType
+
+	if err := c.checkHome(home); err != nil {
+		return err
+	}
+	if err := c.checkHomeKeyring(home, nodeType == types.ValidatorNode); err != nil {
+		return err
+	}
+	if err := c.checkHomeConfig(home, nodeType); err != nil {
+		return err
+	}
+	if err := c.checkHomeData(home, nodeType); err != nil {
+		return err
+	}
+	if c.opts.ServiceFilePath != "" {
+		if err := c.checkServiceFileForValidatorOnLinux(home, c.opts.ServiceFilePath); err != nil {
+			return err
+		}
+	}
+	if c.opts.Rpc != "" {
+		if err := c.checkLiveNode(home, nodeType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Checker) report(runErr error) Report {
+	sort.SliceStable(c.records, func(i, j int) bool {
+		left := c.records[i]
+		right := c.records[j]
+		if left.fatal != right.fatal {
+			return left.fatal
+		}
+		return left.addedNo < right.addedNo
+	})
+
+	report := Report{
+		Home:         c.opts.Home,
+		NodeType:     c.opts.NodeType.String(),
+		Status:       StatusPassed,
+		Findings:     make([]Finding, 0, len(c.records)),
+		Suppressed:   make([]Finding, 0, len(c.suppressedRecords)),
+		RemoteSigner: c.remoteSigner,
+	}
+	for _, record := range c.records {
+		report.Findings = append(report.Findings, record.finding())
+		if record.fatal {
+			report.Status = StatusFatal
+		} else if report.Status == StatusPassed {
+			report.Status = StatusWarningsOnly
+		}
+	}
+	for _, record := range c.suppressedRecords {
+		report.Suppressed = append(report.Suppressed, record.finding())
+	}
+	if runErr != nil {
+		report.Error = runErr.Error()
+		report.Status = StatusError
+	}
+
+	return report
+}
+
+func (c *Checker) checkLatestRelease(latestRelease string) {
+	latestTagName := strings.TrimPrefix(latestRelease, "v")
+	currentVersion := strings.TrimPrefix(constants.VERSION, "v")
+	if latestTagName != currentVersion {
+		c.putCheckRecord(checkRecord{
+			rule:    ruleLatestRelease,
+			message: fmt.Sprintf("latest release is v%s, must use latest version to prevent bugs and new logics", latestTagName),
+		})
+	}
+}
+
+// FetchLatestRelease returns the tag name of the latest release of this tool on GitHub.
+func FetchLatestRelease() (string, error) {
+	httpClient := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	resp, err := httpClient.Get("https://api.github.com/repos/bcdevtools/node-management/releases/latest")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&release)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode latest release")
+	}
+	if release.TagName == "" {
+		return "", fmt.Errorf("latest release not found")
+	}
+
+	return release.TagName, nil
+}
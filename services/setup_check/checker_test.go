@@ -0,0 +1,172 @@
+package setup_check
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestChecker_Run(t *testing.T) {
+	t.Run("home does not exist", func(t *testing.T) {
+		checker, err := NewChecker(Options{
+			Home:     path.Join(t.TempDir(), "missing"),
+			NodeType: types.RpcNode,
+		})
+		require.NoError(t, err)
+
+		report := checker.Run()
+		require.Equal(t, StatusError, report.Status)
+		require.Equal(t, "provided home directory does not exist", report.Error)
+		require.Empty(t, report.Findings)
+	})
+
+	t.Run("can run again", func(t *testing.T) {
+		home := t.TempDir()
+		require.NoError(t, os.Chmod(home, 0o777))
+
+		checker, err := NewChecker(Options{
+			Home:     home,
+			NodeType: types.RpcNode,
+			Policy: &Policy{
+				Thresholds: DefaultThresholds(),
+				Suppressions: map[string]string{
+					rulePermission.Id: "testing",
+				},
+			},
+			LatestRelease: "v0.0.0-not-exists",
+		})
+		require.NoError(t, err)
+
+		first := checker.Run()
+		second := checker.Run()
+		require.Equal(t, first, second)
+		require.Equal(t, second, checker.Report())
+
+		require.Equal(t, StatusError, first.Status)
+		require.Contains(t, first.Error, "config directory does not exist")
+		require.Len(t, first.Findings, 1)
+		require.Equal(t, ruleLatestRelease.Id, first.Findings[0].RuleId)
+		require.Len(t, first.Suppressed, 2)
+		require.Equal(t, "testing", first.Suppressed[0].SuppressReason)
+	})
+
+	t.Run("could not run", func(t *testing.T) {
+		checker := NewFailedChecker(Options{
+			Home:     "/tmp",
+			NodeType: types.RpcNode,
+		}, fmt.Errorf("failed to load policy"))
+
+		report := checker.Report()
+		require.Equal(t, "/tmp", report.Home)
+		require.Equal(t, StatusError, report.Status)
+		require.Equal(t, "failed to load policy", report.Error)
+		require.NotNil(t, report.Findings)
+		require.Equal(t, report, checker.Run(), "home must not be checked")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := NewChecker(Options{Home: "/tmp"})
+		require.Error(t, err)
+
+		_, err = NewChecker(Options{Home: "/tmp", NodeType: types.RpcNode, ServiceFilePath: "/etc/systemd/system/node.service"})
+		require.Error(t, err)
+
+		const nodeId = "0123456789abcdef0123456789abcdef01234567"
+
+		_, err = NewChecker(Options{Home: "/tmp", NodeType: types.SentryNode, SentryNodeIds: []string{nodeId}})
+		require.Error(t, err)
+
+		_, err = NewChecker(Options{Home: "/tmp", NodeType: types.ValidatorNode, SentryNodeIds: []string{"node-id"}})
+		require.Error(t, err)
+
+		_, err = NewChecker(Options{Home: "/tmp", NodeType: types.ValidatorNode, ValidatorNodeId: nodeId})
+		require.Error(t, err)
+
+		_, err = NewChecker(Options{Home: "/tmp", NodeType: types.SentryNode, ValidatorNodeId: nodeId})
+		require.NoError(t, err)
+	})
+}
+
+func TestChecker_checkSentryPeerIds(t *testing.T) {
+	const validatorNodeId = "0123456789abcdef0123456789abcdef01234567"
+	const otherNodeId = "89abcdef0123456789abcdef0123456789abcdef"
+	strict := true
+	notStrict := false
+
+	tests := []struct {
+		name            string
+		persistentPeers string
+		addrBookStrict  *bool
+		wantWarn        bool
+		wantFix         bool
+	}{
+		{
+			name:            "validator at private address, addr_book_strict absent",
+			persistentPeers: otherNodeId + "@1.1.1.1:26656," + validatorNodeId + "@10.0.0.2:26656",
+			wantWarn:        true,
+		},
+		{
+			name:            "validator at private address, addr_book_strict enabled",
+			persistentPeers: validatorNodeId + "@192.168.1.2:26656",
+			addrBookStrict:  &strict,
+			wantWarn:        true,
+			wantFix:         true,
+		},
+		{
+			name:            "validator at private address, addr_book_strict disabled",
+			persistentPeers: validatorNodeId + "@192.168.1.2:26656",
+			addrBookStrict:  &notStrict,
+		},
+		{
+			name:            "validator at public address",
+			persistentPeers: validatorNodeId + "@1.2.3.4:26656",
+			addrBookStrict:  &strict,
+		},
+		{
+			name:            "validator not in persistent_peers",
+			persistentPeers: otherNodeId + "@10.0.0.3:26656",
+			addrBookStrict:  &strict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker, err := NewChecker(Options{Home: "/tmp", NodeType: types.SentryNode, ValidatorNodeId: validatorNodeId})
+			require.NoError(t, err)
+
+			checker.checkSentryPeerIds("config.toml", &types.P2pConfigToml{
+				PersistentPeers:      tt.persistentPeers,
+				PrivatePeerIds:       validatorNodeId,
+				UnconditionalPeerIds: validatorNodeId,
+				AddrBookStrict:       tt.addrBookStrict,
+			})
+
+			var found []checkRecord
+			for _, record := range checker.records {
+				if record.rule == ruleConfigAddrBookStrict {
+					found = append(found, record)
+				}
+			}
+			if !tt.wantWarn {
+				require.Empty(t, found)
+				return
+			}
+			require.Len(t, found, 1)
+			require.False(t, found[0].fatal)
+			require.Equal(t, tt.wantFix, found[0].fix != nil)
+		})
+	}
+}
+
+func TestChecker_checkHomeConfigPrivValidatorKeyJson(t *testing.T) {
+	configPath := t.TempDir()
+	checker, err := NewChecker(Options{Home: "/tmp", NodeType: types.ValidatorNode})
+	require.NoError(t, err)
+
+	require.NoError(t, checker.checkHomeConfigPrivValidatorKeyJson(configPath), "missing key file must be reported as finding")
+	require.Len(t, checker.records, 1)
+	require.Equal(t, rulePrivValidatorKeyMissing, checker.records[0].rule)
+	require.True(t, checker.records[0].fatal)
+}
@@ -0,0 +1,287 @@
+package setup_check
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/services/toml_editor"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// checkFix is the automatic remediation of a check record.
+type checkFix struct {
+	description string
+	// skipReason is set when the fix must not be applied automatically, eg: requires sudo or touches keys
+	skipReason string
+	apply      func(session *fixSession) error
+}
+
+// skippedFix is a fix which is only printed, the user has to apply it manually.
+func skippedFix(description, skipReason string) *checkFix {
+	return &checkFix{
+		description: description,
+		skipReason:  skipReason,
+	}
+}
+
+// keyFix is a fix touching keys, it is always skipped.
+func keyFix(description string) *checkFix {
+	return skippedFix(description, "touches keys")
+}
+
+// chmodFix removes the clearBits and adds the setBits to the permission of the file,
+// skipped if the file is not owned by the current user.
+func chmodFix(filePath string, clearBits, setBits os.FileMode) *checkFix {
+	fix := &checkFix{
+		description: describeChmod(filePath, clearBits, setBits),
+		apply: func(_ *fixSession) error {
+			fi, err := os.Stat(filePath)
+			if err != nil {
+				return err
+			}
+			return os.Chmod(filePath, fi.Mode().Perm()&^clearBits|setBits)
+		},
+	}
+
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		fix.skipReason = err.Error()
+	} else if stat, ok := fi.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		fix.skipReason = "requires sudo, not owned by current user"
+	}
+
+	return fix
+}
+
+func describeChmod(filePath string, clearBits, setBits os.FileMode) string {
+	if clearBits == 0o777 {
+		return fmt.Sprintf("chmod %03o %s", setBits, filePath)
+	}
+
+	var parts []string
+	for i, who := range []string{"u", "g", "o"} {
+		shift := uint(6 - 3*i)
+		for _, op := range []struct {
+			sign string
+			bits os.FileMode
+		}{{"-", clearBits}, {"+", setBits}} {
+			bits := (op.bits >> shift) & 0o7
+			if bits == 0 {
+				continue
+			}
+			var sb strings.Builder
+			sb.WriteString(who + op.sign)
+			if bits&0o4 != 0 {
+				sb.WriteString("r")
+			}
+			if bits&0o2 != 0 {
+				sb.WriteString("w")
+			}
+			if bits&0o1 != 0 {
+				sb.WriteString("x")
+			}
+			parts = append(parts, sb.String())
+		}
+	}
+	return fmt.Sprintf("chmod %s %s", strings.Join(parts, ","), filePath)
+}
+
+// tomlChange is a change of a key in a TOML file, value is raw TOML value, eg: `"custom"`, `true`, `100`.
+type tomlChange struct {
+	section  string
+	key      string
+	rawValue string
+}
+
+// tomlFix updates existing keys of the TOML file, comments and ordering are kept.
+func tomlFix(filePath string, changes ...tomlChange) *checkFix {
+	var descriptions []string
+	for _, change := range changes {
+		sectionKey := change.key
+		if change.section != "" {
+			sectionKey = change.section + "." + change.key
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s = %s", sectionKey, change.rawValue))
+	}
+
+	return &checkFix{
+		description: fmt.Sprintf("set %s in %s", strings.Join(descriptions, ", "), filePath),
+		apply: func(session *fixSession) error {
+			doc, err := session.tomlDocument(filePath)
+			if err != nil {
+				return err
+			}
+			for _, change := range changes {
+				if err := doc.Set(change.section, change.key, change.rawValue); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// serviceFileFix sets the key in the section of the service file, empty value removes the key,
+// skipped if the service file is not writable by the current user.
+func serviceFileFix(filePath, section, key, value string) *checkFix {
+	description := fmt.Sprintf("set %s=%s in [%s] section of %s", key, value, section, filePath)
+	if value == "" {
+		description = fmt.Sprintf("remove %s from [%s] section of %s", key, section, filePath)
+	}
+
+	fix := &checkFix{
+		description: description,
+		apply: func(session *fixSession) error {
+			doc, err := session.serviceFileDocument(filePath)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				doc.remove(section, key)
+			} else {
+				doc.set(section, key, value)
+			}
+			return nil
+		},
+	}
+
+	if unix.Access(filePath, unix.W_OK) != nil || unix.Access(filepath.Dir(filePath), unix.W_OK) != nil {
+		fix.skipReason = "requires sudo"
+	}
+
+	return fix
+}
+
+// fixSession holds the documents edited by fixes, each file is saved once with a single backup of the original content.
+type fixSession struct {
+	tomlDocuments        map[string]*toml_editor.Document
+	serviceFileDocuments map[string]*serviceFileDocument
+	filePaths            []string
+}
+
+func newFixSession() *fixSession {
+	return &fixSession{
+		tomlDocuments:        make(map[string]*toml_editor.Document),
+		serviceFileDocuments: make(map[string]*serviceFileDocument),
+	}
+}
+
+func (s *fixSession) tomlDocument(filePath string) (*toml_editor.Document, error) {
+	if doc, found := s.tomlDocuments[filePath]; found {
+		return doc, nil
+	}
+	doc, err := toml_editor.Load(filePath)
+	if err != nil {
+		return nil, err
+	}
+	s.tomlDocuments[filePath] = doc
+	s.filePaths = append(s.filePaths, filePath)
+	return doc, nil
+}
+
+func (s *fixSession) serviceFileDocument(filePath string) (*serviceFileDocument, error) {
+	if doc, found := s.serviceFileDocuments[filePath]; found {
+		return doc, nil
+	}
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	doc := parseServiceFile(bz)
+	s.serviceFileDocuments[filePath] = doc
+	s.filePaths = append(s.filePaths, filePath)
+	return doc, nil
+}
+
+// save writes the edited files, returns the backup file paths.
+func (s *fixSession) save() ([]string, error) {
+	var backupFilePaths []string
+	for _, filePath := range s.filePaths {
+		var backupFilePath string
+		var err error
+		if doc, found := s.tomlDocuments[filePath]; found {
+			backupFilePath, err = doc.SaveWithBackup(filePath)
+		} else {
+			backupFilePath, err = s.serviceFileDocuments[filePath].saveWithBackup(filePath)
+		}
+		if backupFilePath != "" {
+			backupFilePaths = append(backupFilePaths, backupFilePath)
+		}
+		if err != nil {
+			return backupFilePaths, err
+		}
+	}
+	return backupFilePaths, nil
+}
+
+// FixOutcome is the outcome of a fix, neither skipped nor failed means applied, or would be applied on dry-run.
+type FixOutcome struct {
+	Description string
+	// SkipReason is set when the fix must be applied manually, eg: requires sudo or touches keys
+	SkipReason string
+	// Error is set when failed to apply the fix
+	Error error
+}
+
+type FixResult struct {
+	DryRun   bool
+	Outcomes []FixOutcome
+	// BackupFiles are backups of the original content of the edited files
+	BackupFiles []string
+}
+
+// AppliedCount returns number of applied fixes, always zero on dry-run.
+func (r FixResult) AppliedCount() int {
+	if r.DryRun {
+		return 0
+	}
+	var count int
+	for _, outcome := range r.Outcomes {
+		if outcome.SkipReason == "" && outcome.Error == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// ApplyFixes applies fixes of the records found by the last run, re-run to get the report after fixes.
+// Fixes requiring sudo or touching keys are skipped.
+// Error is returned when failed to save the edited files, the report of the last run is marked as error accordingly.
+func (c *Checker) ApplyFixes(dryRun bool) (FixResult, error) {
+	result := FixResult{
+		DryRun: dryRun,
+	}
+	session := newFixSession()
+	processed := make(map[string]bool)
+
+	for _, record := range c.records {
+		fix := record.fix
+		if fix == nil || processed[fix.description] {
+			continue
+		}
+		processed[fix.description] = true
+
+		outcome := FixOutcome{
+			Description: fix.description,
+			SkipReason:  fix.skipReason,
+		}
+		if outcome.SkipReason == "" && !dryRun {
+			outcome.Error = fix.apply(session)
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+
+	backupFilePaths, err := session.save()
+	result.BackupFiles = backupFilePaths
+	if err != nil {
+		err = errors.Wrap(err, "failed to save fixes")
+		c.lastReport.Status = StatusError
+		c.lastReport.Error = err.Error()
+		return result, err
+	}
+
+	return result, nil
+}
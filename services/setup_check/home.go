@@ -0,0 +1,33 @@
+package setup_check
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+)
+
+func (c *Checker) checkHome(home string) error {
+	perm, exists, isDir, err := utils.FileInfo(home)
+	if err != nil {
+		return fmt.Errorf("failed to check provided home directory: %s", err)
+	}
+	if !exists {
+		return fmt.Errorf("provided home directory does not exist")
+	}
+	if !isDir {
+		return fmt.Errorf("provided home directory is not a directory")
+	}
+
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.Write {
+		c.fatalRecord(rulePermission, home, "home directory is writable by others", fmt.Sprintf("chmod o-w %s", home), chmodFix(home, 0o002, 0))
+	}
+	if filePerm.Group.Write {
+		c.fatalRecord(rulePermission, home, "home directory is writable by group", fmt.Sprintf("chmod g-w %s", home), chmodFix(home, 0o020, 0))
+	}
+	if !filePerm.User.IsFullPermission() {
+		c.fatalRecord(rulePermission, home, "home directory is fully accessible by user", fmt.Sprintf("chmod u+rwx %s", home), chmodFix(home, 0, 0o700))
+	}
+
+	return nil
+}
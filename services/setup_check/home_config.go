@@ -0,0 +1,1245 @@
+package setup_check
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/bcdevtools/node-management/validation"
+	"github.com/pelletier/go-toml/v2"
+	"net"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func (c *Checker) checkHomeConfig(home string, nodeType types.NodeType) error {
+	configPath := path.Join(home, "config")
+	perm, exists, isDir, err := utils.FileInfo(configPath)
+	if err != nil {
+		return fmt.Errorf("failed to check config directory at %s: %v", configPath, err)
+	}
+	if !exists {
+		return fmt.Errorf("config directory does not exist: %s", configPath)
+	}
+	if !isDir {
+		return fmt.Errorf("config is not a directory: %s", configPath)
+	}
+
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.Write {
+		c.fatalRecord(rulePermission, configPath, "config directory is writable by others", "chmod o-w "+configPath, chmodFix(configPath, 0o002, 0))
+	}
+	if filePerm.Group.Write {
+		c.fatalRecord(rulePermission, configPath, "config directory is writable by group", "chmod g-w "+configPath, chmodFix(configPath, 0o020, 0))
+	}
+	if !filePerm.User.IsFullPermission() {
+		c.fatalRecord(rulePermission, configPath, "config directory is not fully accessible by user", "chmod u+rwx "+configPath, chmodFix(configPath, 0, 0o700))
+	}
+
+	appToml, err := c.checkHomeConfigAppToml(configPath, nodeType)
+	if err != nil {
+		return err
+	}
+	if err := c.checkHomeConfigClientToml(configPath); err != nil {
+		return err
+	}
+	configToml, err := c.checkHomeConfigConfigToml(configPath, nodeType)
+	if err != nil {
+		return err
+	}
+	if err := c.checkHomeConfigGenesisJson(configPath); err != nil {
+		return err
+	}
+	if err := c.checkHomeConfigNodeKeyJson(configPath); err != nil {
+		return err
+	}
+	if nodeType == types.ValidatorNode {
+		// a missing key file is not a remote signer, it is reported as missing
+		c.remoteSigner = configToml.PrivValidatorLaddr != ""
+	}
+	if c.remoteSigner {
+		if err := c.checkHomeConfigRemoteSigner(configPath, configToml); err != nil {
+			return err
+		}
+	} else if err := c.checkHomeConfigPrivValidatorKeyJson(configPath); err != nil {
+		return err
+	}
+	return c.checkHomeConfigConfigTomlAndAppToml(configPath, nodeType, configToml, appToml)
+}
+
+func (c *Checker) checkHomeConfigAppToml(configPath string, nodeType types.NodeType) (*types.AppToml, error) {
+	isValidator := nodeType == types.ValidatorNode
+	isRpc := nodeType == types.RpcNode
+	isSnapshotNode := nodeType == types.SnapshotNode
+	isArchivalNode := nodeType == types.ArchivalNode
+	isSeedNode := nodeType == types.SeedNode
+	appTomlFilePath := path.Join(configPath, "app.toml")
+	perm, exists, isDir, err := utils.FileInfo(appTomlFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check app.toml file at %s: %v", appTomlFilePath, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("app.toml file does not exist: %s", appTomlFilePath)
+	}
+	if isDir {
+		return nil, fmt.Errorf("app.toml is a directory, it should be a file: %s", appTomlFilePath)
+	}
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.Write {
+		c.fatalRecord(rulePermission, appTomlFilePath, "app.toml file is writable by others", "chmod 644 "+appTomlFilePath, chmodFix(appTomlFilePath, 0o777, 0o644))
+	}
+	if filePerm.Group.Write {
+		c.fatalRecord(rulePermission, appTomlFilePath, "app.toml file is writable by group", "chmod 644 "+appTomlFilePath, chmodFix(appTomlFilePath, 0o777, 0o644))
+	}
+	if !filePerm.User.Read {
+		c.fatalRecord(rulePermission, appTomlFilePath, "app.toml file is not readable by user", "chmod 644 "+appTomlFilePath, chmodFix(appTomlFilePath, 0o777, 0o644))
+	}
+	if !filePerm.User.Write {
+		c.fatalRecord(rulePermission, appTomlFilePath, "app.toml file is not writable by user", "chmod 644 "+appTomlFilePath, chmodFix(appTomlFilePath, 0o777, 0o644))
+	}
+
+	bz, err := os.ReadFile(appTomlFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app.toml file at %s: %v", appTomlFilePath, err)
+	}
+
+	var app types.AppToml
+	err = toml.Unmarshal(bz, &app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal app.toml file at %s: %v", appTomlFilePath, err)
+	}
+
+	if app.MinimumGasPrices == "" {
+		if isValidator {
+			c.warnRecord(ruleAppMinimumGasPrices, appTomlFilePath, "minimum-gas-prices is empty, validator must set, in app.toml file", "", nil)
+		} else {
+			c.warnRecord(ruleAppMinimumGasPrices, appTomlFilePath, "minimum-gas-prices is empty in app.toml file", "", nil)
+		}
+	} else if regexp.MustCompile(`^\s*0[a-z]+\s*$`).MatchString(app.MinimumGasPrices) {
+		if isValidator {
+			c.warnRecord(ruleAppMinimumGasPrices, appTomlFilePath, fmt.Sprintf("minimum-gas-prices is zero, validator must set, in app.toml file: %s", app.MinimumGasPrices), "", nil)
+		} else {
+			c.warnRecord(ruleAppMinimumGasPrices, appTomlFilePath, fmt.Sprintf("minimum-gas-prices is zero in app.toml file: %s", app.MinimumGasPrices), "", nil)
+		}
+	}
+
+	recommendPruningCustomKeepRecent := c.policy.Thresholds.PruningKeepRecent
+
+	pruningCustomFix := func(keepRecent int64) *checkFix {
+		return tomlFix(
+			appTomlFilePath,
+			tomlChange{key: "pruning", rawValue: `"custom"`},
+			tomlChange{key: "pruning-keep-recent", rawValue: fmt.Sprintf(`"%d"`, keepRecent)},
+			tomlChange{key: "pruning-interval", rawValue: `"10"`},
+		)
+	}
+	pruningNothingFix := tomlFix(appTomlFilePath, tomlChange{key: "pruning", rawValue: `"nothing"`})
+
+	switch app.Pruning {
+	case constants.PruningDefault:
+		if isValidator {
+			c.warnRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"pruning set to 'default' in app.toml file",
+				fmt.Sprintf("set pruning to 'custom' %d/10", recommendPruningCustomKeepRecent),
+				pruningCustomFix(recommendPruningCustomKeepRecent),
+			)
+		} else if isSnapshotNode {
+			c.warnRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"pruning set to 'default' in app.toml file, snapshot not should be configured properly for snapshot purpose",
+				fmt.Sprintf("set pruning to 'custom' 100/10"),
+				pruningCustomFix(100),
+			)
+		} else if isArchivalNode {
+			c.fatalRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"pruning set to 'default' in app.toml file, archival node must be configured properly for archival purpose",
+				"set pruning to 'nothing'",
+				pruningNothingFix,
+			)
+		}
+	case constants.PruningNothing:
+		if isValidator {
+			c.fatalRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"pruning set to 'nothing' in app.toml file, validator should not use this option",
+				fmt.Sprintf("set pruning to 'custom' %d/10", recommendPruningCustomKeepRecent),
+				pruningCustomFix(recommendPruningCustomKeepRecent),
+			)
+		} else if isSnapshotNode {
+			c.fatalRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"pruning set to 'nothing' in app.toml file, snapshot not should be configured properly for snapshot purpose",
+				"set pruning to 'custom' 100/10",
+				pruningCustomFix(100),
+			)
+		}
+	case constants.PruningEverything:
+		if isValidator {
+			c.warnRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				fmt.Sprintf(
+					"pruning set to 'everything', however to work properly with double_sign_check_height, it should be set to 'custom' at least %d/10 in app.toml file",
+					constants.RecommendDoubleSignCheckHeight+10,
+				),
+				fmt.Sprintf("set pruning = 'custom', pruning-keep-recent = at least double_sign_check_height + 10 or recommend %d, pruning-interval = 10", recommendPruningCustomKeepRecent),
+				pruningCustomFix(recommendPruningCustomKeepRecent),
+			)
+			c.warnRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				fmt.Sprintf(
+					"pruning set to 'everything', however to work properly with evident, it should be set to 'custom' %d/10 in app.toml file",
+					recommendPruningCustomKeepRecent,
+				),
+				fmt.Sprintf("set pruning to 'custom' %d/10", recommendPruningCustomKeepRecent),
+				pruningCustomFix(recommendPruningCustomKeepRecent),
+			)
+		} else if isArchivalNode {
+			c.fatalRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"pruning set to 'everything' in app.toml file, archival node must not use this option",
+				"set pruning to 'nothing'",
+				pruningNothingFix,
+			)
+		} else if isSnapshotNode {
+			c.fatalRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"pruning set to 'everything' in app.toml file, snapshot node must not use this option",
+				"set pruning to 'custom' 100/10",
+				pruningCustomFix(100),
+			)
+		} else if isSeedNode {
+			// no problem, seed node only serves addresses
+		} else {
+			c.fatalRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"pruning set to 'everything' in app.toml file, non-validator should not use this option",
+				fmt.Sprintf("set pruning to 'custom' %d/10", recommendPruningCustomKeepRecent),
+				pruningCustomFix(recommendPruningCustomKeepRecent),
+			)
+		}
+	case constants.PruningCustom:
+		if isArchivalNode {
+			c.fatalRecord(ruleAppPruning, appTomlFilePath, "pruning set to 'custom' in app.toml file, archival node must not use this option", "set pruning to nothing", pruningNothingFix)
+		}
+	default:
+		msg := fmt.Sprintf("invalid pruning option '%s' in app.toml file", app.Pruning)
+		if isArchivalNode {
+			c.fatalRecord(ruleAppPruning, appTomlFilePath, msg, "set pruning to nothing", nil)
+		} else {
+			c.fatalRecord(ruleAppPruning, appTomlFilePath, msg, fmt.Sprintf("set pruning to custom %d/10", recommendPruningCustomKeepRecent), nil)
+		}
+		return nil, fmt.Errorf("invalid pruning option in app.toml file %s: %s", appTomlFilePath, app.Pruning)
+	}
+
+	if isSnapshotNode {
+		if app.Pruning != constants.PruningCustom || app.PruningKeepRecent != "100" || app.PruningInterval != "10" {
+			c.warnRecord(
+				ruleAppPruning,
+				appTomlFilePath,
+				"snapshot node should use pruning custom 100/10 in app.toml file",
+				"set pruning to 'custom' 100/10",
+				pruningCustomFix(100),
+			)
+		}
+	}
+
+	if app.Pruning == constants.PruningCustom {
+		if app.PruningKeepRecent != "" {
+			pruningKeepRecent, err := strconv.ParseInt(app.PruningKeepRecent, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pruning-keep-recent in app.toml file: %v", err)
+			}
+
+			if pruningKeepRecent > c.policy.Thresholds.MaxPruningKeepRecent {
+				c.warnRecord(ruleAppPruningKeepRecent, appTomlFilePath, "pruning-keep-recent is too high in app.toml file", "", nil)
+			} else if pruningKeepRecent < 2 {
+				c.fatalRecord(ruleAppPruningKeepRecent, appTomlFilePath, "pruning-keep-recent is too low in app.toml file", "", nil)
+			}
+
+			if isArchivalNode {
+				// ignore, there is another warning indicate archival should use prune nothing
+			} else if isSnapshotNode && pruningKeepRecent != 100 {
+				c.warnRecord(
+					ruleAppPruningKeepRecent,
+					appTomlFilePath,
+					"snapshot node should use pruning-keep-recent 100",
+					"set pruning-keep-recent to 100",
+					tomlFix(appTomlFilePath, tomlChange{key: "pruning-keep-recent", rawValue: `"100"`}),
+				)
+			} else if pruningKeepRecent < recommendPruningCustomKeepRecent {
+				c.warnRecord(
+					ruleAppPruningKeepRecent,
+					appTomlFilePath,
+					fmt.Sprintf("pruning-keep-recent is too low in app.toml file, recommend %d", recommendPruningCustomKeepRecent),
+					fmt.Sprintf("set pruning-keep-recent to %d", recommendPruningCustomKeepRecent),
+					tomlFix(appTomlFilePath, tomlChange{key: "pruning-keep-recent", rawValue: fmt.Sprintf(`"%d"`, recommendPruningCustomKeepRecent)}),
+				)
+			}
+		} else {
+			if isSnapshotNode {
+				c.fatalRecord(
+					ruleAppPruningKeepRecent,
+					appTomlFilePath,
+					"pruning-keep-recent is empty in app.toml file, snapshot node must set this to 100",
+					"set pruning-keep-recent to 100",
+					tomlFix(appTomlFilePath, tomlChange{key: "pruning-keep-recent", rawValue: `"100"`}),
+				)
+			} else if isArchivalNode {
+				// ignore, there is another warning indicate archival should use prune nothing
+			} else {
+				c.fatalRecord(
+					ruleAppPruningKeepRecent,
+					appTomlFilePath,
+					"pruning-keep-recent is empty in app.toml file",
+					fmt.Sprintf("set pruning-keep-recent to %d", recommendPruningCustomKeepRecent),
+					tomlFix(appTomlFilePath, tomlChange{key: "pruning-keep-recent", rawValue: fmt.Sprintf(`"%d"`, recommendPruningCustomKeepRecent)}),
+				)
+			}
+		}
+
+		if app.PruningInterval != "" {
+			pruningInterval, err := strconv.ParseInt(app.PruningInterval, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse pruning-interval in app.toml file: %v", err)
+			}
+
+			if pruningInterval > 10_000 {
+				c.warnRecord(ruleAppPruningInterval, appTomlFilePath, "pruning-interval is too high in app.toml file", "", nil)
+			} else if pruningInterval < 10 {
+				c.fatalRecord(ruleAppPruningInterval, appTomlFilePath, "pruning-interval is too low in app.toml file", "", nil)
+			}
+		} else {
+			c.fatalRecord(ruleAppPruningInterval, appTomlFilePath, "pruning-interval is empty in app.toml file", "set pruning-interval to 10", tomlFix(appTomlFilePath, tomlChange{key: "pruning-interval", rawValue: `"10"`}))
+		}
+	}
+
+	if app.HaltHeight > 0 {
+		c.warnRecord(ruleAppHaltHeight, appTomlFilePath, fmt.Sprintf("halt-height is set to %d in app.toml file", app.HaltHeight), "unset halt-height unless on purpose", nil)
+	}
+
+	if app.HaltTime > 0 {
+		c.warnRecord(ruleAppHaltTime, appTomlFilePath, fmt.Sprintf("halt-time is set to %d in app.toml file", app.HaltTime), "unset halt-time unless on purpose", nil)
+	}
+
+	if app.Pruning == constants.PruningDefault {
+		if app.MinRetainsBlock < 362880 {
+			c.warnRecord(
+				ruleAppMinRetainBlocks,
+				appTomlFilePath,
+				"min-retain-blocks should be set to 362880 if pruning \"default\" in app.toml file",
+				"set min-retain-blocks to 362880",
+				tomlFix(appTomlFilePath, tomlChange{key: "min-retain-blocks", rawValue: "362880"}),
+			)
+		}
+	} else if app.Pruning == constants.PruningEverything {
+		if app.MinRetainsBlock < 2 {
+			c.warnRecord(
+				ruleAppMinRetainBlocks,
+				appTomlFilePath,
+				"min-retain-blocks should be set to 2 if pruning \"everything\" in app.toml file",
+				"set min-retain-blocks to 2",
+				tomlFix(appTomlFilePath, tomlChange{key: "min-retain-blocks", rawValue: "2"}),
+			)
+		}
+	} else if app.Pruning == constants.PruningCustom {
+		pruningKeepRecent, err := strconv.ParseUint(app.PruningKeepRecent, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse pruning-keep-recent in app.toml file: %v", err)
+		}
+		if uint64(app.MinRetainsBlock) < pruningKeepRecent {
+			c.warnRecord(
+				ruleAppPruningKeepRecent,
+				appTomlFilePath,
+				fmt.Sprintf("min-retain-blocks should be equals to pruning-keep-recent (%s) in app.toml file", app.PruningKeepRecent),
+				fmt.Sprintf("set min-retain-blocks to \"%s\"", app.PruningKeepRecent),
+				tomlFix(appTomlFilePath, tomlChange{key: "min-retain-blocks", rawValue: strconv.FormatUint(pruningKeepRecent, 10)}),
+			)
+		}
+	} else if app.Pruning == constants.PruningNothing {
+		if app.MinRetainsBlock != 0 {
+			c.fatalRecord(
+				ruleAppMinRetainBlocks,
+				appTomlFilePath,
+				"min-retain-blocks must be 0 if pruning \"nothing\" (archival node) in app.toml file",
+				"set min-retain-blocks to 0",
+				tomlFix(appTomlFilePath, tomlChange{key: "min-retain-blocks", rawValue: "0"}),
+			)
+		}
+	}
+
+	if app.Api == nil {
+		return nil, fmt.Errorf("[api] section is missing in app.toml file at %s", appTomlFilePath)
+	}
+	if app.Api.Enable {
+		if isValidator {
+			c.warnRecord(ruleAppApiEnable, appTomlFilePath, "api is enabled in app.toml file, validator should disable it", "set enable to false", tomlFix(appTomlFilePath, tomlChange{section: "api", key: "enable", rawValue: "false"}))
+		} else if isSeedNode {
+			c.warnRecord(ruleAppApiEnable, appTomlFilePath, "api is enabled in app.toml file, seed node should disable it", "set enable to false", tomlFix(appTomlFilePath, tomlChange{section: "api", key: "enable", rawValue: "false"}))
+		}
+
+		if !app.Api.Swagger {
+			if isRpc {
+				c.warnRecord(ruleAppApiSwagger, appTomlFilePath, "rpc node should enable swagger", "set swagger to true", tomlFix(appTomlFilePath, tomlChange{section: "api", key: "swagger", rawValue: "true"}))
+			} else if isArchivalNode {
+				c.warnRecord(ruleAppApiSwagger, appTomlFilePath, "archival node should enable swagger", "set swagger to true", tomlFix(appTomlFilePath, tomlChange{section: "api", key: "swagger", rawValue: "true"}))
+			}
+		}
+	} else {
+		if isRpc {
+			c.fatalRecord(ruleAppApiEnable, appTomlFilePath, "api is disabled in app.toml file, rpc node should enable it", "set enable to true", tomlFix(appTomlFilePath, tomlChange{section: "api", key: "enable", rawValue: "true"}))
+		} else if isArchivalNode {
+			c.warnRecord(ruleAppApiEnable, appTomlFilePath, "api is disabled in app.toml file, archival node should enable it", "set enable to true", tomlFix(appTomlFilePath, tomlChange{section: "api", key: "enable", rawValue: "true"}))
+		}
+	}
+
+	if app.JsonRpc != nil {
+		if app.JsonRpc.Enable {
+			if isValidator {
+				c.warnRecord(ruleAppJsonRpcEnable, appTomlFilePath, "json-rpc is enabled in app.toml file, validator should disable it", "set enable to false", tomlFix(appTomlFilePath, tomlChange{section: "json-rpc", key: "enable", rawValue: "false"}))
+			} else if isSeedNode {
+				c.warnRecord(ruleAppJsonRpcEnable, appTomlFilePath, "json-rpc is enabled in app.toml file, seed node should disable it", "set enable to false", tomlFix(appTomlFilePath, tomlChange{section: "json-rpc", key: "enable", rawValue: "false"}))
+			}
+		} else {
+			if isRpc {
+				c.fatalRecord(ruleAppJsonRpcEnable, appTomlFilePath, "json-rpc is disabled in app.toml file, rpc node should enable it", "set enable to true", tomlFix(appTomlFilePath, tomlChange{section: "json-rpc", key: "enable", rawValue: "true"}))
+			} else if isArchivalNode {
+				c.warnRecord(ruleAppJsonRpcEnable, appTomlFilePath, "json-rpc is disabled in app.toml file, archival node should enable it", "set enable to true", tomlFix(appTomlFilePath, tomlChange{section: "json-rpc", key: "enable", rawValue: "true"}))
+			}
+		}
+
+		if app.JsonRpc.EnableIndexer {
+			if isValidator {
+				c.warnRecord(
+					ruleAppJsonRpcEnableIndexer,
+					appTomlFilePath,
+					"json-rpc custom EVM-indexer is enabled in app.toml file, validator should disable it",
+					"set enable-indexer to false",
+					tomlFix(appTomlFilePath, tomlChange{section: "json-rpc", key: "enable-indexer", rawValue: "false"}),
+				)
+			}
+		} else {
+			if isRpc {
+				c.fatalRecord(
+					ruleAppJsonRpcEnableIndexer,
+					appTomlFilePath,
+					"json-rpc custom EVM-indexer is disabled in app.toml file, rpc node should enable it",
+					"set enable-indexer to true",
+					tomlFix(appTomlFilePath, tomlChange{section: "json-rpc", key: "enable-indexer", rawValue: "true"}),
+				)
+			} else if isArchivalNode {
+				c.warnRecord(
+					ruleAppJsonRpcEnableIndexer,
+					appTomlFilePath,
+					"json-rpc custom EVM-indexer is disabled in app.toml file, archival node should enable it",
+					"set enable-indexer to true",
+					tomlFix(appTomlFilePath, tomlChange{section: "json-rpc", key: "enable-indexer", rawValue: "true"}),
+				)
+			}
+		}
+	}
+
+	if app.StateSync == nil {
+		return nil, fmt.Errorf("[state-sync] section is missing in app.toml file at %s", appTomlFilePath)
+	}
+	if app.StateSync.SnapshotInterval == 0 {
+		if isRpc {
+			c.warnRecord(
+				ruleAppSnapshotInterval,
+				appTomlFilePath,
+				"snapshot-interval is 0 (disable snapshot) in app.toml file, RPC nodes should set this",
+				"set snapshot-interval to 2000",
+				tomlFix(appTomlFilePath, tomlChange{section: "state-sync", key: "snapshot-interval", rawValue: "2000"}),
+			)
+		} else if isSnapshotNode {
+			c.fatalRecord(
+				ruleAppSnapshotInterval,
+				appTomlFilePath,
+				"snapshot-interval is 0 (disable snapshot) in app.toml file, snapshot nodes must set this",
+				"set snapshot-interval to 2000",
+				tomlFix(appTomlFilePath, tomlChange{section: "state-sync", key: "snapshot-interval", rawValue: "2000"}),
+			)
+		}
+	} else {
+		if isValidator {
+			c.warnRecord(
+				ruleAppSnapshotInterval,
+				appTomlFilePath,
+				"snapshot-interval is set in app.toml file, validator should not set this",
+				"set snapshot-interval to 0 to disable snapshot",
+				tomlFix(appTomlFilePath, tomlChange{section: "state-sync", key: "snapshot-interval", rawValue: "0"}),
+			)
+		} else if int64(app.StateSync.SnapshotInterval) < c.policy.Thresholds.MinSnapshotInterval {
+			c.warnRecord(
+				ruleAppSnapshotInterval,
+				appTomlFilePath,
+				"snapshot-interval is too low in app.toml file",
+				"set snapshot-interval to 2000",
+				tomlFix(appTomlFilePath, tomlChange{section: "state-sync", key: "snapshot-interval", rawValue: "2000"}),
+			)
+		}
+	}
+	if app.StateSync.SnapshotKeepRecent == 0 {
+		c.fatalRecord(
+			ruleAppSnapshotKeepRecent,
+			appTomlFilePath,
+			"snapshot-keep-recent is 0 in app.toml file, means keep all, unset it",
+			"set snapshot-keep-recent to 2",
+			tomlFix(appTomlFilePath, tomlChange{section: "state-sync", key: "snapshot-keep-recent", rawValue: "2"}),
+		)
+	} else if app.StateSync.SnapshotKeepRecent > 2 {
+		c.warnRecord(
+			ruleAppSnapshotKeepRecent,
+			appTomlFilePath,
+			"snapshot-keep-recent is too high in app.toml file, wasting disk space",
+			"set snapshot-keep-recent to 2",
+			tomlFix(appTomlFilePath, tomlChange{section: "state-sync", key: "snapshot-keep-recent", rawValue: "2"}),
+		)
+	}
+
+	if app.Grpc == nil {
+		return nil, fmt.Errorf("[grpc] section is missing in app.toml file at %s", appTomlFilePath)
+	}
+	if app.Grpc.Enable {
+		if isValidator {
+			c.warnRecord(ruleAppGrpcEnable, appTomlFilePath, "grpc is enabled in app.toml file, validator should disable it", "set [grpc] enable to false", tomlFix(appTomlFilePath, tomlChange{section: "grpc", key: "enable", rawValue: "false"}))
+		} else if isSeedNode {
+			c.warnRecord(ruleAppGrpcEnable, appTomlFilePath, "grpc is enabled in app.toml file, seed node should disable it", "set [grpc] enable to false", tomlFix(appTomlFilePath, tomlChange{section: "grpc", key: "enable", rawValue: "false"}))
+		}
+	} else {
+		if isValidator || isSeedNode {
+			// good
+		} else if isSnapshotNode || nodeType == types.SentryNode {
+			// no problem
+		} else {
+			c.fatalRecord(
+				ruleAppGrpcEnable,
+				appTomlFilePath,
+				"grpc is disabled in app.toml file, non-validator node should enable it",
+				"set [grpc] enable to true",
+				tomlFix(appTomlFilePath, tomlChange{section: "grpc", key: "enable", rawValue: "true"}),
+			)
+		}
+	}
+	const suggestedMaxSendMsgSizeMb = 100
+	const suggestedMaxSendMsgSizeBytes = suggestedMaxSendMsgSizeMb * 1024 * 1024
+	maxSendMsgSize, err := strconv.ParseInt(app.Grpc.MaxSendMsgSize, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse max-send-msg-size \"%s\" in app.toml file: %v", app.Grpc.MaxSendMsgSize, err)
+	}
+	if maxSendMsgSize < suggestedMaxSendMsgSizeBytes {
+		c.warnRecord(
+			ruleAppGrpcMaxSendMsgSize,
+			appTomlFilePath,
+			"max-send-msg-size is too low in app.toml file",
+			fmt.Sprintf("set max-send-msg-size to %d (%d MB)", suggestedMaxSendMsgSizeBytes, suggestedMaxSendMsgSizeMb),
+			tomlFix(appTomlFilePath, tomlChange{section: "grpc", key: "max-send-msg-size", rawValue: fmt.Sprintf(`"%d"`, suggestedMaxSendMsgSizeBytes)}),
+		)
+	}
+	if isRpc || isArchivalNode {
+		if app.Grpc.Enable {
+			if maxSendMsgSize > suggestedMaxSendMsgSizeBytes*5 {
+				c.warnRecord(
+					ruleAppGrpcMaxSendMsgSize,
+					appTomlFilePath,
+					"max-send-msg-size is too high in app.toml file",
+					fmt.Sprintf("set max-send-msg-size to %d (%d MB)", suggestedMaxSendMsgSizeBytes, suggestedMaxSendMsgSizeMb),
+					tomlFix(appTomlFilePath, tomlChange{section: "grpc", key: "max-send-msg-size", rawValue: fmt.Sprintf(`"%d"`, suggestedMaxSendMsgSizeBytes)}),
+				)
+			}
+			if strings.HasSuffix(app.Grpc.Address, ":9090") {
+				c.warnRecord(
+					ruleAppGrpcAddress,
+					appTomlFilePath,
+					"GRPC port should not be the default one (9090) on RPC and Archival node",
+					"set [grpc] address to a custom port",
+					nil,
+				)
+			}
+		}
+	}
+
+	return &app, nil
+}
+
+func (c *Checker) checkHomeConfigClientToml(configPath string) error {
+	clientTomlFilePath := path.Join(configPath, "client.toml")
+	perm, exists, isDir, err := utils.FileInfo(clientTomlFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to check client.toml file at %s: %v", clientTomlFilePath, err)
+	}
+	if !exists {
+		return fmt.Errorf("client.toml file does not exist: %s", clientTomlFilePath)
+	}
+	if isDir {
+		return fmt.Errorf("client.toml is a directory, it should be a file: %s", clientTomlFilePath)
+	}
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.AnyPermission() {
+		c.fatalRecord(rulePermission, clientTomlFilePath, "client.toml file is accessible by others", "chmod 600 "+clientTomlFilePath, chmodFix(clientTomlFilePath, 0o777, 0o600))
+	}
+	if filePerm.Group.AnyPermission() {
+		c.fatalRecord(rulePermission, clientTomlFilePath, "client.toml file is accessible by group", "chmod 600 "+clientTomlFilePath, chmodFix(clientTomlFilePath, 0o777, 0o600))
+	}
+	if !filePerm.User.Read {
+		c.fatalRecord(rulePermission, clientTomlFilePath, "client.toml file is not readable by user", "chmod 600 "+clientTomlFilePath, chmodFix(clientTomlFilePath, 0o777, 0o600))
+	}
+	if !filePerm.User.Write {
+		c.fatalRecord(rulePermission, clientTomlFilePath, "client.toml file is not writable by user", "chmod 600 "+clientTomlFilePath, chmodFix(clientTomlFilePath, 0o777, 0o600))
+	}
+
+	return nil
+}
+
+func (c *Checker) checkHomeConfigConfigToml(configPath string, nodeType types.NodeType) (*types.ConfigToml, error) {
+	isValidator := nodeType == types.ValidatorNode
+	isSentryNode := nodeType == types.SentryNode
+	isSeedNode := nodeType == types.SeedNode
+	isValidatorBehindSentries := isValidator && len(c.opts.SentryNodeIds) > 0
+	configTomlFilePath := path.Join(configPath, "config.toml")
+	perm, exists, isDir, err := utils.FileInfo(configTomlFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check config.toml file at %s: %v", configTomlFilePath, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("config.toml file does not exist: %s", configTomlFilePath)
+	}
+	if isDir {
+		return nil, fmt.Errorf("config.toml is a directory, it should be a file: %s", configTomlFilePath)
+	}
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.Write {
+		c.fatalRecord(rulePermission, configTomlFilePath, "config.toml file is writable by others", "chmod 644 "+configTomlFilePath, chmodFix(configTomlFilePath, 0o777, 0o644))
+	}
+	if filePerm.Group.Write {
+		c.fatalRecord(rulePermission, configTomlFilePath, "config.toml file is writable by group", "chmod 644 "+configTomlFilePath, chmodFix(configTomlFilePath, 0o777, 0o644))
+	}
+	if !filePerm.User.Read {
+		c.fatalRecord(rulePermission, configTomlFilePath, "config.toml file is not readable by user", "chmod 644 "+configTomlFilePath, chmodFix(configTomlFilePath, 0o777, 0o644))
+	}
+	if !filePerm.User.Write {
+		c.fatalRecord(rulePermission, configTomlFilePath, "config.toml file is not writable by user", "chmod 644 "+configTomlFilePath, chmodFix(configTomlFilePath, 0o777, 0o644))
+	}
+
+	bz, err := os.ReadFile(configTomlFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config.toml file at %s: %v", configTomlFilePath, err)
+	}
+
+	var config types.ConfigToml
+	err = toml.Unmarshal(bz, &config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config.toml file at %s: %v", configTomlFilePath, err)
+	}
+
+	if config.Moniker == "" {
+		c.fatalRecord(ruleConfigMoniker, configTomlFilePath, "moniker is empty in config.toml file", "set moniker to a unique name", nil)
+	}
+
+	if config.P2P == nil {
+		return nil, fmt.Errorf("[p2p] section is missing in config.toml file at %s", configTomlFilePath)
+	}
+	if config.P2P.Seeds == "" {
+		c.warnRecord(ruleConfigSeeds, configTomlFilePath, "seeds is empty in config.toml file", "set seeds to seed nodes", nil)
+	} else if !validation.IsValidPeer(config.P2P.Seeds) {
+		c.warnRecord(ruleConfigSeeds, configTomlFilePath, "invalid seeds format in config.toml file", "correct the format of seeds", nil)
+	}
+	if strings.HasSuffix(config.P2P.Laddr, ":26656") {
+		if isSentryNode || isSeedNode {
+			// no problem, public node
+		} else if isValidator {
+			c.warnRecord(ruleConfigP2pLaddr, configTomlFilePath, "P2P port should not be the default one (26656) on validator node", "set p2p laddr to a custom port", nil)
+		} else {
+			c.warnRecord(ruleConfigP2pLaddr, configTomlFilePath, "P2P port should not be the default one (26656)", "set p2p laddr to a custom port", nil)
+		}
+	}
+	if config.P2P.PersistentPeers == "" {
+		if isValidatorBehindSentries {
+			c.fatalRecord(ruleConfigSentryPeers, configTomlFilePath, "persistent_peers is empty in config.toml file, validator behind sentries must peer with the sentries", "set persistent_peers to the sentry nodes", nil)
+		} else if !isSeedNode {
+			c.warnRecord(ruleConfigPersistentPeers, configTomlFilePath, "persistent_peers is empty in config.toml file", "set persistent_peers to persistent peer nodes", nil)
+		}
+	} else if !validation.IsValidPeer(config.P2P.PersistentPeers) {
+		c.warnRecord(ruleConfigPersistentPeers, configTomlFilePath, "invalid persistent_peers format in config.toml file", "correct the format of persistent_peers", nil)
+	} else if isValidatorBehindSentries {
+		for _, peerNodeId := range peerNodeIds(config.P2P.PersistentPeers) {
+			if !slices.Contains(c.opts.SentryNodeIds, peerNodeId) {
+				c.fatalRecord(
+					ruleConfigSentryPeers,
+					configTomlFilePath,
+					fmt.Sprintf("persistent_peers contains non-sentry node %s in config.toml file, validator behind sentries must only peer with the sentries", peerNodeId),
+					"remove non-sentry nodes from persistent_peers",
+					nil,
+				)
+			}
+		}
+	}
+	if isSeedNode {
+		if int64(config.P2P.MaxNumInboundPeers) < c.policy.Thresholds.MinSeedInboundPeers {
+			c.warnRecord(
+				ruleConfigMaxNumInboundPeers,
+				configTomlFilePath,
+				"max_num_inbound_peers is too low in config.toml file, seed node should accept many inbound peers",
+				fmt.Sprintf("increase max_num_inbound_peers to %d", c.policy.Thresholds.MinSeedInboundPeers),
+				tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: "max_num_inbound_peers", rawValue: strconv.FormatInt(c.policy.Thresholds.MinSeedInboundPeers, 10)}),
+			)
+		}
+	} else if int64(config.P2P.MaxNumInboundPeers) < c.policy.Thresholds.MinInboundPeers {
+		recommendInboundPeers := max(120, c.policy.Thresholds.MinInboundPeers)
+		c.warnRecord(
+			ruleConfigMaxNumInboundPeers,
+			configTomlFilePath,
+			"max_num_inbound_peers is too low in config.toml file",
+			fmt.Sprintf("increase max_num_inbound_peers to %d", recommendInboundPeers),
+			tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: "max_num_inbound_peers", rawValue: strconv.FormatInt(recommendInboundPeers, 10)}),
+		)
+	}
+	if int64(config.P2P.MaxNumOutboundPeers) < c.policy.Thresholds.MinOutboundPeers {
+		recommendOutboundPeers := max(60, c.policy.Thresholds.MinOutboundPeers)
+		c.warnRecord(
+			ruleConfigMaxNumOutboundPeers,
+			configTomlFilePath,
+			"max_num_outbound_peers is too low in config.toml file",
+			fmt.Sprintf("increase max_num_outbound_peers to %d", recommendOutboundPeers),
+			tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: "max_num_outbound_peers", rawValue: strconv.FormatInt(recommendOutboundPeers, 10)}),
+		)
+	}
+	if isSeedNode {
+		if !config.P2P.SeedMode {
+			c.fatalRecord(ruleConfigSeedMode, configTomlFilePath, "seed_mode is disabled in config.toml file, seed node must enable it", "set seed_mode to true", tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: "seed_mode", rawValue: "true"}))
+		}
+	} else if config.P2P.SeedMode {
+		c.warnRecord(ruleConfigSeedMode, configTomlFilePath, "seed_mode is enabled in config.toml file", "disable seed_mode if not on purpose", nil)
+	}
+	if isSentryNode || isSeedNode {
+		if !config.P2P.IsPexEnabled() {
+			// only reported when explicitly disabled, so the key exists
+			c.fatalRecord(ruleConfigPex, configTomlFilePath, fmt.Sprintf("pex is disabled in config.toml file, %s node must enable it", nodeType), "set pex to true", tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: "pex", rawValue: "true"}))
+		}
+	} else if isValidatorBehindSentries {
+		if config.P2P.IsPexEnabled() {
+			var fix *checkFix
+			suggest := "set pex to false"
+			if config.P2P.Pex != nil {
+				fix = tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: "pex", rawValue: "false"})
+			} else {
+				// absent key, enabled by default
+				suggest = "add pex = false to [p2p] section"
+			}
+			c.fatalRecord(ruleConfigPex, configTomlFilePath, "pex is enabled in config.toml file, validator behind sentries must disable it to not expose itself", suggest, fix)
+		}
+	}
+	if isSentryNode {
+		c.checkSentryPeerIds(configTomlFilePath, config.P2P)
+	}
+	if isSeedNode && !config.P2P.IsAddrBookStrict() {
+		c.warnRecord(ruleConfigSeedAddrBookStrict, configTomlFilePath, "addr_book_strict is disabled in config.toml file, public seed node should not gossip private addresses", "set addr_book_strict to true", tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: "addr_book_strict", rawValue: "true"}))
+	}
+
+	if config.StateSync == nil {
+		return nil, fmt.Errorf("[statesync] section is missing in config.toml file at %s", configTomlFilePath)
+	}
+	if config.StateSync.Enable {
+		c.warnRecord(ruleConfigStateSyncEnable, configTomlFilePath, "statesync is enabled in config.toml file", "disable state sync in section [statesync]", tomlFix(configTomlFilePath, tomlChange{section: "statesync", key: "enable", rawValue: "false"}))
+	}
+
+	if config.Consensus == nil {
+		return nil, fmt.Errorf("[consensus] section is missing in config.toml file at %s", configTomlFilePath)
+	}
+	if config.Consensus.DoubleSignCheckHeight > 0 {
+		if isValidator {
+			if config.Consensus.DoubleSignCheckHeight > constants.MaxDoubleSignCheckHeight {
+				c.warnRecord(
+					ruleConfigDoubleSignCheckHeight,
+					configTomlFilePath,
+					fmt.Sprintf("double_sign_check_height %d is too high in config.toml file, can lower uptime", config.Consensus.DoubleSignCheckHeight),
+					fmt.Sprintf("set double_sign_check_height to %d", constants.RecommendDoubleSignCheckHeight),
+					tomlFix(configTomlFilePath, tomlChange{section: "consensus", key: "double_sign_check_height", rawValue: strconv.Itoa(constants.RecommendDoubleSignCheckHeight)}),
+				)
+			} else if config.Consensus.DoubleSignCheckHeight < constants.MinDoubleSignCheckHeight {
+				c.warnRecord(
+					ruleConfigDoubleSignCheckHeight,
+					configTomlFilePath,
+					fmt.Sprintf("double_sign_check_height %d is too low in config.toml file", config.Consensus.DoubleSignCheckHeight),
+					fmt.Sprintf("set double_sign_check_height to %d", constants.RecommendDoubleSignCheckHeight),
+					tomlFix(configTomlFilePath, tomlChange{section: "consensus", key: "double_sign_check_height", rawValue: strconv.Itoa(constants.RecommendDoubleSignCheckHeight)}),
+				)
+			}
+		}
+	} else {
+		if isValidator {
+			c.fatalRecord(
+				ruleConfigDoubleSignCheckHeight,
+				configTomlFilePath,
+				"double_sign_check_height is not set in config.toml file, validator nodes should set this",
+				fmt.Sprintf("set double_sign_check_height to %d", constants.RecommendDoubleSignCheckHeight),
+				tomlFix(configTomlFilePath, tomlChange{section: "consensus", key: "double_sign_check_height", rawValue: strconv.Itoa(constants.RecommendDoubleSignCheckHeight)}),
+			)
+		}
+	}
+	if config.Consensus.SkipTimeoutCommit {
+		if isValidator {
+			c.fatalRecord(
+				ruleConfigSkipTimeoutCommit,
+				configTomlFilePath,
+				"skip_timeout_commit is enabled in config.toml file, validator nodes should not use this",
+				"disable skip_timeout_commit",
+				tomlFix(configTomlFilePath, tomlChange{section: "consensus", key: "skip_timeout_commit", rawValue: "false"}),
+			)
+		} else {
+			c.warnRecord(
+				ruleConfigSkipTimeoutCommit,
+				configTomlFilePath,
+				"skip_timeout_commit is enabled in config.toml file",
+				"disable skip_timeout_commit",
+				tomlFix(configTomlFilePath, tomlChange{section: "consensus", key: "skip_timeout_commit", rawValue: "false"}),
+			)
+		}
+	}
+
+	if config.TxIndex == nil {
+		return nil, fmt.Errorf("[tx_index] section is missing in config.toml file at %s", configTomlFilePath)
+	}
+	switch config.TxIndex.Indexer {
+	case "":
+		if isValidator {
+			c.fatalRecord(
+				ruleConfigTxIndexIndexer,
+				configTomlFilePath,
+				"indexer is empty in [tx_index] section of config.toml file, validator nodes should set this to \"null\"",
+				"set indexer to \"null\"",
+				tomlFix(configTomlFilePath, tomlChange{section: "tx_index", key: "indexer", rawValue: `"null"`}),
+			)
+		} else {
+			c.warnRecord(
+				ruleConfigTxIndexIndexer,
+				configTomlFilePath,
+				"indexer is empty in [tx_index] section of config.toml file, non-validator nodes should set this to \"kv\"",
+				"set indexer to \"kv\"",
+				tomlFix(configTomlFilePath, tomlChange{section: "tx_index", key: "indexer", rawValue: `"kv"`}),
+			)
+		}
+	case "kv":
+		if isValidator {
+			c.warnRecord(
+				ruleConfigTxIndexIndexer,
+				configTomlFilePath,
+				"indexer is set to \"kv\" in [tx_index] section of config.toml file, validator nodes should set this to \"null\"",
+				"set indexer to \"null\"",
+				tomlFix(configTomlFilePath, tomlChange{section: "tx_index", key: "indexer", rawValue: `"null"`}),
+			)
+		}
+	case "null":
+		if !isValidator && !isSeedNode {
+			c.fatalRecord(
+				ruleConfigTxIndexIndexer,
+				configTomlFilePath,
+				"indexer is set to \"null\" (disable indexer) in [tx_index] section of config.toml file, non-validator nodes should set this to \"kv\"",
+				"set indexer to \"kv\"",
+				tomlFix(configTomlFilePath, tomlChange{section: "tx_index", key: "indexer", rawValue: `"kv"`}),
+			)
+		}
+	default:
+		if isValidator {
+			c.fatalRecord(
+				ruleConfigTxIndexIndexer,
+				configTomlFilePath,
+				fmt.Sprintf("invalid indexer option \"%s\" in [tx_index] section of config.toml file", config.TxIndex.Indexer),
+				"set indexer to \"null\"",
+				tomlFix(configTomlFilePath, tomlChange{section: "tx_index", key: "indexer", rawValue: `"null"`}),
+			)
+		} else {
+			c.fatalRecord(
+				ruleConfigTxIndexIndexer,
+				configTomlFilePath,
+				fmt.Sprintf("invalid indexer option \"%s\" in [tx_index] section of config.toml file", config.TxIndex.Indexer),
+				"set indexer to \"kv\"",
+				tomlFix(configTomlFilePath, tomlChange{section: "tx_index", key: "indexer", rawValue: `"kv"`}),
+			)
+		}
+	}
+
+	return &config, nil
+}
+
+// checkSentryPeerIds checks the validator node ID is kept private and always accepted by the sentry node.
+func (c *Checker) checkSentryPeerIds(configTomlFilePath string, p2p *types.P2pConfigToml) {
+	validatorNodeId := c.opts.ValidatorNodeId
+	if validatorNodeId == "" {
+		if strings.TrimSpace(p2p.PrivatePeerIds) == "" {
+			c.warnRecord(ruleConfigPrivatePeerIds, configTomlFilePath, "private_peer_ids is empty in config.toml file, sentry node should keep the validator node ID private", "add validator node ID to private_peer_ids", nil)
+		}
+		if strings.TrimSpace(p2p.UnconditionalPeerIds) == "" {
+			c.warnRecord(ruleConfigUnconditionalPeerIds, configTomlFilePath, "unconditional_peer_ids is empty in config.toml file, sentry node should always accept the validator node", "add validator node ID to unconditional_peer_ids", nil)
+		}
+		return
+	}
+
+	for _, peerIds := range []struct {
+		rule  Rule
+		key   string
+		value string
+	}{
+		{ruleConfigPrivatePeerIds, "private_peer_ids", p2p.PrivatePeerIds},
+		{ruleConfigUnconditionalPeerIds, "unconditional_peer_ids", p2p.UnconditionalPeerIds},
+	} {
+		nodeIds := splitNodeIds(peerIds.value)
+		if slices.Contains(nodeIds, validatorNodeId) {
+			continue
+		}
+		c.fatalRecord(
+			peerIds.rule,
+			configTomlFilePath,
+			fmt.Sprintf("validator node ID %s is missing in %s in config.toml file", validatorNodeId, peerIds.key),
+			fmt.Sprintf("add validator node ID to %s", peerIds.key),
+			tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: peerIds.key, rawValue: strconv.Quote(strings.Join(append(nodeIds, validatorNodeId), ","))}),
+		)
+	}
+
+	// addr_book_strict rejects non-routable addresses, the validator at private address would not be reached
+	if validatorHost, found := peerHost(p2p.PersistentPeers, validatorNodeId); found && isPrivateHost(validatorHost) && p2p.IsAddrBookStrict() {
+		var fix *checkFix
+		suggest := "set addr_book_strict to false"
+		if p2p.AddrBookStrict != nil {
+			fix = tomlFix(configTomlFilePath, tomlChange{section: "p2p", key: "addr_book_strict", rawValue: "false"})
+		} else {
+			// absent key, enabled by default
+			suggest = "add addr_book_strict = false to [p2p] section"
+		}
+		c.warnRecord(
+			ruleConfigAddrBookStrict,
+			configTomlFilePath,
+			fmt.Sprintf("addr_book_strict is enabled in config.toml file, sentry node would not accept the validator node at private address %s", validatorHost),
+			suggest,
+			fix,
+		)
+	}
+}
+
+// peerHost returns the host of the peer with the node ID in the comma-separated peers, eg: persistent_peers.
+func peerHost(peers, nodeId string) (host string, found bool) {
+	for _, peer := range splitNodeIds(peers) {
+		peerNodeId, address, _ := strings.Cut(peer, "@")
+		if peerNodeId != nodeId {
+			continue
+		}
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return "", false
+		}
+		return host, true
+	}
+	return "", false
+}
+
+// isPrivateHost returns true if the host is a private, loopback or link-local IP address. Hostnames are not resolved.
+func isPrivateHost(host string) bool {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast()
+}
+
+// splitNodeIds splits the comma-separated node IDs, eg: private_peer_ids.
+func splitNodeIds(nodeIds string) []string {
+	var result []string
+	for _, nodeId := range strings.Split(nodeIds, ",") {
+		nodeId = strings.TrimSpace(nodeId)
+		if nodeId != "" {
+			result = append(result, nodeId)
+		}
+	}
+	return result
+}
+
+// peerNodeIds returns the node IDs of the comma-separated peers, eg: persistent_peers.
+func peerNodeIds(peers string) []string {
+	var result []string
+	for _, peer := range splitNodeIds(peers) {
+		nodeId, _, _ := strings.Cut(peer, "@")
+		result = append(result, nodeId)
+	}
+	return result
+}
+
+func (c *Checker) checkHomeConfigGenesisJson(configPath string) error {
+	genesisJsonFilePath := path.Join(configPath, "genesis.json")
+	perm, exists, isDir, err := utils.FileInfo(genesisJsonFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to check genesis.json file at %s: %v", genesisJsonFilePath, err)
+	}
+	if !exists {
+		return fmt.Errorf("genesis.json file does not exist: %s", genesisJsonFilePath)
+	}
+	if isDir {
+		return fmt.Errorf("genesis.json is a directory, it should be a file: %s", genesisJsonFilePath)
+	}
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.Write {
+		c.fatalRecord(rulePermission, genesisJsonFilePath, "genesis.json file is writable by others", "chmod 644 "+genesisJsonFilePath, chmodFix(genesisJsonFilePath, 0o777, 0o644))
+	}
+	if filePerm.Group.Write {
+		c.fatalRecord(rulePermission, genesisJsonFilePath, "genesis.json file is writable by group", "chmod 644 "+genesisJsonFilePath, chmodFix(genesisJsonFilePath, 0o777, 0o644))
+	}
+	if !filePerm.User.Read {
+		c.fatalRecord(rulePermission, genesisJsonFilePath, "genesis.json file is not readable by user", "chmod 644 "+genesisJsonFilePath, chmodFix(genesisJsonFilePath, 0o777, 0o644))
+	}
+	if !filePerm.User.Write {
+		c.fatalRecord(rulePermission, genesisJsonFilePath, "genesis.json file is not writable by user", "chmod 644 "+genesisJsonFilePath, chmodFix(genesisJsonFilePath, 0o777, 0o644))
+	}
+
+	return nil
+}
+
+func (c *Checker) checkHomeConfigNodeKeyJson(configPath string) error {
+	nodeKeyJsonFilePath := path.Join(configPath, "node_key.json")
+	perm, exists, isDir, err := utils.FileInfo(nodeKeyJsonFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to check node_key.json file at %s: %v", nodeKeyJsonFilePath, err)
+	}
+	if !exists {
+		return fmt.Errorf("node_key.json file does not exist: %s", nodeKeyJsonFilePath)
+	}
+	if isDir {
+		return fmt.Errorf("node_key.json is a directory, it should be a file: %s", nodeKeyJsonFilePath)
+	}
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.AnyPermission() {
+		c.fatalRecord(ruleKeyPermission, nodeKeyJsonFilePath, "node_key.json file is accessible by others", "chmod 600 "+nodeKeyJsonFilePath, keyFix("chmod 600 "+nodeKeyJsonFilePath))
+	}
+	if filePerm.Group.AnyPermission() {
+		c.fatalRecord(ruleKeyPermission, nodeKeyJsonFilePath, "node_key.json file is accessible by group", "chmod 600 "+nodeKeyJsonFilePath, keyFix("chmod 600 "+nodeKeyJsonFilePath))
+	}
+	if !filePerm.User.Read {
+		c.fatalRecord(ruleKeyPermission, nodeKeyJsonFilePath, "node_key.json file is not readable by user", "chmod 600 "+nodeKeyJsonFilePath, keyFix("chmod 600 "+nodeKeyJsonFilePath))
+	}
+	if !filePerm.User.Write {
+		c.fatalRecord(ruleKeyPermission, nodeKeyJsonFilePath, "node_key.json file is not writable by user", "chmod 600 "+nodeKeyJsonFilePath, keyFix("chmod 600 "+nodeKeyJsonFilePath))
+	}
+
+	type nodeKeyPrivKey struct {
+		Type  string `json:"type"`
+		Value string `json:"value"`
+	}
+	type nodeKey struct {
+		PrivKey *nodeKeyPrivKey `json:"priv_key"`
+	}
+
+	bz, err := os.ReadFile(nodeKeyJsonFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read node_key.json file at %s: %v", nodeKeyJsonFilePath, err)
+	}
+
+	if len(bz) == 0 {
+		return fmt.Errorf("node_key.json file is empty: %s", nodeKeyJsonFilePath)
+	}
+
+	var nk nodeKey
+	err = json.Unmarshal(bz, &nk)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal node_key.json file at %s: %v", nodeKeyJsonFilePath, err)
+	}
+
+	if nk.PrivKey == nil {
+		return fmt.Errorf("priv_key is missing in node_key.json file at %s", nodeKeyJsonFilePath)
+	}
+
+	if len(nk.PrivKey.Type) == 0 {
+		return fmt.Errorf("type is missing in priv_key in node_key.json file at %s", nodeKeyJsonFilePath)
+	}
+
+	if len(nk.PrivKey.Value) == 0 {
+		return fmt.Errorf("value is missing in priv_key in node_key.json file at %s", nodeKeyJsonFilePath)
+	}
+
+	return nil
+}
+
+func (c *Checker) checkHomeConfigPrivValidatorKeyJson(configPath string) error {
+	privValidatorJsonFilePath := path.Join(configPath, "priv_validator_key.json")
+	perm, exists, isDir, err := utils.FileInfo(privValidatorJsonFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to check priv_validator_key.json file at %s: %v", privValidatorJsonFilePath, err)
+	}
+	if !exists {
+		suggest := "restore priv_validator_key.json from backup"
+		if c.opts.NodeType == types.ValidatorNode {
+			suggest += ", or set priv_validator_laddr in config.toml if the validator uses remote signer"
+		}
+		c.fatalRecord(rulePrivValidatorKeyMissing, privValidatorJsonFilePath, "priv_validator_key.json file does not exist", suggest, nil)
+		return nil
+	}
+	if isDir {
+		return fmt.Errorf("priv_validator_key.json is a directory, it should be a file: %s", privValidatorJsonFilePath)
+	}
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.AnyPermission() {
+		c.fatalRecord(ruleKeyPermission, privValidatorJsonFilePath, "priv_validator_key.json file is accessible by others", "chmod 600 "+privValidatorJsonFilePath, keyFix("chmod 600 "+privValidatorJsonFilePath))
+	}
+	if filePerm.Group.AnyPermission() {
+		c.fatalRecord(ruleKeyPermission, privValidatorJsonFilePath, "priv_validator_key.json file is accessible by group", "chmod 600 "+privValidatorJsonFilePath, keyFix("chmod 600 "+privValidatorJsonFilePath))
+	}
+	if !filePerm.User.Read {
+		c.fatalRecord(ruleKeyPermission, privValidatorJsonFilePath, "priv_validator_key.json file is not readable by user", "chmod 600 "+privValidatorJsonFilePath, keyFix("chmod 600 "+privValidatorJsonFilePath))
+	}
+	if !filePerm.User.Write {
+		c.fatalRecord(ruleKeyPermission, privValidatorJsonFilePath, "priv_validator_key.json file is not writable by user", "chmod 600 "+privValidatorJsonFilePath, keyFix("chmod 600 "+privValidatorJsonFilePath))
+	}
+
+	bz, err := os.ReadFile(privValidatorJsonFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read priv_validator_key.json file at %s: %v", privValidatorJsonFilePath, err)
+	}
+
+	if len(bz) == 0 {
+		return fmt.Errorf("priv_validator_key.json file is empty: %s", privValidatorJsonFilePath)
+	}
+
+	var nk types.PrivValidatorKey
+	err = json.Unmarshal(bz, &nk)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal priv_validator_key.json file at %s: %v", privValidatorJsonFilePath, err)
+	}
+
+	if nk.PrivKey == nil {
+		return fmt.Errorf("priv_key is missing in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	if len(nk.PrivKey.Type) == 0 {
+		return fmt.Errorf("type is missing in priv_key in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	if len(nk.PrivKey.Value) == 0 {
+		return fmt.Errorf("value is missing in priv_key in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	if nk.PubKey == nil {
+		return fmt.Errorf("pub_key is missing in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	if len(nk.PubKey.Type) == 0 {
+		return fmt.Errorf("type is missing in pub_key in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	if len(nk.PubKey.Value) == 0 {
+		return fmt.Errorf("value is missing in pub_key in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	if len(nk.Address) == 0 {
+		return fmt.Errorf("address is missing in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	if !regexp.MustCompile(`^[\dA-F]{40}$`).MatchString(nk.Address) {
+		return fmt.Errorf("address is malformed in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	return nil
+}
+
+func (c *Checker) checkHomeConfigRemoteSigner(configPath string, configToml *types.ConfigToml) error {
+	configTomlFilePath := path.Join(configPath, "config.toml")
+
+	privValidatorJsonFilePath := path.Join(configPath, "priv_validator_key.json")
+	_, exists, _, err := utils.FileInfo(privValidatorJsonFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to check priv_validator_key.json file at %s: %v", privValidatorJsonFilePath, err)
+	}
+	if exists {
+		c.fatalRecord(
+			ruleRemoteSignerKeyFile,
+			privValidatorJsonFilePath,
+			"priv_validator_key.json file exists while using remote signer, the validator key must only be kept by the signer",
+			"backup then remove "+privValidatorJsonFilePath,
+			keyFix("remove "+privValidatorJsonFilePath),
+		)
+	}
+
+	host, _, err := net.SplitHostPort(strings.TrimPrefix(configToml.PrivValidatorLaddr, "tcp://"))
+	if err != nil {
+		c.fatalRecord(
+			ruleConfigPrivValidatorLaddr,
+			configTomlFilePath,
+			fmt.Sprintf("invalid priv_validator_laddr \"%s\" in config.toml file", configToml.PrivValidatorLaddr),
+			"set priv_validator_laddr to the address the remote signer connects to, eg: tcp://<private IP>:26659",
+			nil,
+		)
+	} else if host == "" || host == "0.0.0.0" || host == "::" {
+		c.warnRecord(
+			ruleConfigPrivValidatorLaddr,
+			configTomlFilePath,
+			"priv_validator_laddr listens on all interfaces in config.toml file, the signer port must be firewalled",
+			"listen on the private network interface, allow the signer port only from the signer hosts",
+			nil,
+		)
+	}
+
+	return nil
+}
+
+func (c *Checker) checkHomeConfigConfigTomlAndAppToml(configPath string, nodeType types.NodeType, configToml *types.ConfigToml, appToml *types.AppToml) error {
+	if configToml == nil || appToml == nil {
+		panic("configToml or appToml is nil")
+	}
+
+	appTomlFilePath := path.Join(configPath, "app.toml")
+
+	isValidator := nodeType == types.ValidatorNode
+
+	if isValidator {
+		if appToml.Pruning == constants.PruningCustom {
+			if appToml.PruningKeepRecent != "" {
+				pruningKeepRecent, err := strconv.ParseUint(appToml.PruningKeepRecent, 10, 64)
+				if err != nil {
+					return fmt.Errorf("failed to parse pruning-keep-recent in app.toml file: %v", err)
+				}
+
+				if pruningKeepRecent <= uint64(configToml.Consensus.DoubleSignCheckHeight) {
+					c.warnRecord(
+						ruleAppPruningKeepRecent,
+						appTomlFilePath,
+						fmt.Sprintf(
+							"pruning-keep-recent %d should be greater than double_sign_check_height %d in app.toml file",
+							pruningKeepRecent,
+							configToml.Consensus.DoubleSignCheckHeight,
+						),
+						fmt.Sprintf("increase pruning-keep-recent to be greater than double_sign_check_height few blocks"),
+						nil,
+					)
+				}
+			}
+
+			if appToml.MinRetainsBlock <= configToml.Consensus.DoubleSignCheckHeight {
+				c.warnRecord(
+					ruleAppMinRetainBlocks,
+					appTomlFilePath,
+					fmt.Sprintf(
+						"min-retain-blocks %d should be greater than double_sign_check_height %d in app.toml file",
+						appToml.MinRetainsBlock,
+						configToml.Consensus.DoubleSignCheckHeight,
+					),
+					fmt.Sprintf("increase min-retain-blocks to be greater than double_sign_check_height few blocks"),
+					nil,
+				)
+			}
+		}
+	}
+
+	return nil
+}
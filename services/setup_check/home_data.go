@@ -0,0 +1,95 @@
+package setup_check
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"path"
+)
+
+func (c *Checker) checkHomeData(home string, nodeType types.NodeType) error {
+	dataPath := path.Join(home, "data")
+	perm, exists, isDir, err := utils.FileInfo(dataPath)
+	if err != nil {
+		return fmt.Errorf("failed to check data directory at %s: %v", dataPath, err)
+	}
+	if !exists {
+		return fmt.Errorf("data directory does not exist: %s", dataPath)
+	}
+	if !isDir {
+		return fmt.Errorf("data is not a directory: %s", dataPath)
+	}
+
+	filePerm := types.FilePermFrom(perm)
+	if filePerm.Other.AnyPermission() {
+		c.fatalRecord(rulePermission, dataPath, "data directory is accessible by others", "chmod 700 "+dataPath, chmodFix(dataPath, 0o777, 0o700))
+	}
+	if filePerm.Group.AnyPermission() {
+		c.fatalRecord(rulePermission, dataPath, "data directory is accessible by group", "chmod 700 "+dataPath, chmodFix(dataPath, 0o777, 0o700))
+	}
+	if !filePerm.User.IsFullPermission() {
+		c.fatalRecord(rulePermission, dataPath, "data directory is not fully accessible by user", "chmod 700 "+dataPath, chmodFix(dataPath, 0o777, 0o700))
+	}
+
+	if c.remoteSigner {
+		// the double-sign protection state is kept by the remote signer
+		return nil
+	}
+
+	privValidatorStateFilePath := path.Join(dataPath, "priv_validator_state.json")
+	perm, exists, isDir, err = utils.FileInfo(privValidatorStateFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to check priv_validator_state.json file at %s: %v", privValidatorStateFilePath, err)
+	}
+	if !exists {
+		return fmt.Errorf("priv_validator_state.json file is missing")
+	}
+	if isDir {
+		return fmt.Errorf("priv_validator_state.json is a directory, it should be a file")
+	}
+	if perm != 0o600 {
+		c.fatalRecord(rulePermission, privValidatorStateFilePath, "priv_validator_state.json has invalid permission", "chmod 600 "+privValidatorStateFilePath, chmodFix(privValidatorStateFilePath, 0o777, 0o600))
+	}
+
+	pvs := &types.PrivateValidatorState{}
+	err = pvs.LoadFromJSONFile(privValidatorStateFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to load priv_validator_state.json file: %v", err)
+	}
+
+	if pvs.IsEmpty() {
+		if nodeType == types.ValidatorNode {
+			c.fatalRecord(rulePrivValStateEmpty, privValidatorStateFilePath, "priv_validator_state.json is empty", "can be ignored if this is a fresh validator node", nil)
+		}
+	} else {
+		if nodeType == types.ValidatorNode {
+			if pvs.Height == "0" {
+				return fmt.Errorf("priv_validator_state.json is not empty, but height is 0, trouble-shoot the issue")
+			}
+			if pvs.Signature == "" {
+				return fmt.Errorf("priv_validator_state.json is not empty, but signature is empty, trouble-shoot the issue")
+			}
+			if pvs.SignBytes == "" {
+				return fmt.Errorf("priv_validator_state.json is not empty, but signbytes is empty, trouble-shoot the issue")
+			}
+
+			for _, dbDirName := range []string{"application.db", "blockstore.db", "state.db"} {
+				dbDirPath := path.Join(dataPath, dbDirName)
+				perm, exists, isDir, err = utils.FileInfo(dbDirPath)
+				if err != nil {
+					return fmt.Errorf("failed to check %s directory at %s: %v", dbDirName, dbDirPath, err)
+				}
+				if !exists {
+					return fmt.Errorf("priv_validator_state.json is not empty but data dir seem empty, missing %s", dbDirPath)
+				}
+				if !isDir {
+					return fmt.Errorf("%s is not a directory: %s", dbDirName, dbDirPath)
+				}
+			}
+		} else {
+			return fmt.Errorf("priv_validator_state.json is not empty, it should be empty on non-validator nodes, trouble-shoot the issue")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package setup_check
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/pkg/errors"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+func (c *Checker) checkHomeKeyring(home string, isValidatorNode bool) error {
+	if err := c.checkHomeKeyringFile(home, isValidatorNode); err != nil {
+		return err
+	}
+	return c.checkHomeKeyringTest(home, isValidatorNode)
+}
+
+func (c *Checker) checkHomeKeyringFile(home string, isValidatorNode bool) error {
+	keyringFilePath := path.Join(home, "keyring-file")
+	perm, exists, isDir, err := utils.FileInfo(keyringFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to check keyring-file directory at %s: %v", keyringFilePath, err)
+	}
+
+	if !exists {
+		if isValidatorNode {
+			c.warnRecord(ruleKeyringFileMissing, keyringFilePath, fmt.Sprintf("keyring-file directory is missing on validator node: %s", keyringFilePath), "can be ignored if you are not using keyring-file", nil)
+		}
+		return nil
+	}
+
+	if !isDir {
+		return fmt.Errorf("keyring-file is not a directory: %s", keyringFilePath)
+	}
+
+	if !isValidatorNode {
+		isEmpty, err := utils.IsEmptyDir(keyringFilePath)
+		if err != nil {
+			return fmt.Errorf("failed to check emptiness of keyring-file directory at %s: %v", keyringFilePath, err)
+		}
+		if !isEmpty {
+			c.warnRecord(ruleKeyringOnNonValidator, keyringFilePath, fmt.Sprintf("should not store key on non-validator node, found at %s", keyringFilePath), "migrate/backup and remove usage of keyring-file", nil)
+		}
+	}
+
+	if perm != 0o700 {
+		c.fatalRecord(ruleKeyPermission, keyringFilePath, fmt.Sprintf("keyring-file directory has invalid permission %s", perm.String()), fmt.Sprintf("chmod -R 700 %s", keyringFilePath), keyFix(fmt.Sprintf("chmod -R 700 %s", keyringFilePath)))
+	}
+
+	// check file hash
+	fileHashPath := path.Join(keyringFilePath, "keyhash")
+	perm, exists, isDir, err = utils.FileInfo(fileHashPath)
+	if err != nil {
+		return fmt.Errorf("failed to check %s: %v", fileHashPath, err)
+	}
+	if exists {
+		if isDir {
+			return fmt.Errorf("%s is a directory, it should be a file", fileHashPath)
+		}
+
+		filePerm := types.FilePermFrom(perm)
+		if filePerm.Other.AnyPermission() {
+			c.fatalRecord(ruleKeyPermission, fileHashPath, "keyhash file should not be accessible by others", fmt.Sprintf("chmod 600 %s", fileHashPath), keyFix(fmt.Sprintf("chmod 600 %s", fileHashPath)))
+		}
+		if filePerm.Group.AnyPermission() {
+			c.fatalRecord(ruleKeyPermission, fileHashPath, "keyhash file should not be accessible by group", fmt.Sprintf("chmod 600 %s", fileHashPath), keyFix(fmt.Sprintf("chmod 600 %s", fileHashPath)))
+		}
+		if !filePerm.User.Read {
+			c.fatalRecord(ruleKeyPermission, fileHashPath, "keyhash file should be readable by owner", fmt.Sprintf("chmod 600 %s", fileHashPath), keyFix(fmt.Sprintf("chmod 600 %s", fileHashPath)))
+		}
+		if !filePerm.User.Write {
+			c.fatalRecord(ruleKeyPermission, fileHashPath, "keyhash file should be writable by owner", fmt.Sprintf("chmod 600 %s", fileHashPath), keyFix(fmt.Sprintf("chmod 600 %s", fileHashPath)))
+		}
+	} else if isValidatorNode {
+		c.warnRecord(ruleKeyhashMissing, fileHashPath, fmt.Sprintf("keyhash file is missing on validator node: %s", fileHashPath), "can be ignored if you are not using keyring-file", nil)
+	}
+
+	err = filepath.Walk(keyringFilePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == keyringFilePath {
+			return nil
+		}
+
+		perm, _, isDir, err = utils.FileInfo(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check keyring-file inner file %s", path)
+		}
+
+		if isDir && perm != 0o700 {
+			c.fatalRecord(ruleKeyPermission, path, fmt.Sprintf("keyring-file inner directory must have permission 700 but %s has invalid permission %s", path, perm.String()), fmt.Sprintf("chmod -R 700 %s", keyringFilePath), keyFix(fmt.Sprintf("chmod -R 700 %s", keyringFilePath)))
+		} else if !isDir && perm != 0o600 && perm != 0o700 {
+			c.fatalRecord(ruleKeyPermission, path, fmt.Sprintf("keyring-file inner file must have permission 600 or 700 but %s has invalid permission %s", path, perm.String()), fmt.Sprintf("chmod -R 600 %s", keyringFilePath), keyFix(fmt.Sprintf("chmod -R 600 %s", keyringFilePath)))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to walk on %s: %v", keyringFilePath, err)
+	}
+
+	return nil
+}
+
+func (c *Checker) checkHomeKeyringTest(home string, isValidatorNode bool) error {
+	keyringTestPath := path.Join(home, "keyring-test")
+	perm, exists, isDir, err := utils.FileInfo(keyringTestPath)
+	if err != nil {
+		return fmt.Errorf("failed to check keyring-test directory at %s: %v", keyringTestPath, err)
+	}
+
+	if !exists {
+		return nil
+	}
+
+	if !isDir {
+		return nil
+	}
+
+	if perm != 0o700 {
+		c.fatalRecord(ruleKeyPermission, keyringTestPath, fmt.Sprintf("keyring-test directory has invalid permission %s", perm.String()), fmt.Sprintf("chmod -R 700 %s", keyringTestPath), keyFix(fmt.Sprintf("chmod -R 700 %s", keyringTestPath)))
+	}
+
+	isEmpty, err := utils.IsEmptyDir(keyringTestPath)
+	if err != nil {
+		return fmt.Errorf("failed to check emptiness of keyring-test directory at %s: %v", keyringTestPath, err)
+	}
+
+	if !isEmpty {
+		if isValidatorNode {
+			return fmt.Errorf("keyring-test directory is found on validator node: %s ! Migrate/backup and remove usage of keyring-test\n> rm -rf %s", keyringTestPath, keyringTestPath)
+		}
+		c.fatalRecord(ruleKeyringTestUsed, keyringTestPath, "keyring-test should not be used, found at "+keyringTestPath, "migrate/backup and remove usage of keyring-test", nil)
+	}
+
+	err = filepath.Walk(keyringTestPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == keyringTestPath {
+			return nil
+		}
+
+		perm, _, isDir, err = utils.FileInfo(path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to check keyring-test inner file %s", path)
+		}
+
+		if isDir && perm != 0o700 {
+			c.fatalRecord(ruleKeyPermission, path, fmt.Sprintf("keyring-test inner directory must have permission 700 but %s has invalid permission %s", path, perm.String()), fmt.Sprintf("chmod -R 700 %s", keyringTestPath), keyFix(fmt.Sprintf("chmod -R 700 %s", keyringTestPath)))
+		} else if !isDir && perm != 0o600 && perm != 0o700 {
+			c.fatalRecord(ruleKeyPermission, path, fmt.Sprintf("keyring-test inner file must have permission 600 or 700 but %s has invalid permission %s", path, perm.String()), fmt.Sprintf("chmod -R 600 %s", keyringTestPath), keyFix(fmt.Sprintf("chmod -R 600 %s", keyringTestPath)))
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to walk on %s: %v", keyringTestPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package setup_check
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/bcdevtools/node-management/services/rpc_client"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/pelletier/go-toml/v2"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+	"time"
+)
+
+const liveNodeTimeout = 10 * time.Second
+
+// checkLiveNode verifies the running node, queried via RPC, matches the config files of the node home.
+func (c *Checker) checkLiveNode(home string, nodeType types.NodeType) error {
+	configPath := path.Join(home, "config")
+	configTomlFilePath := path.Join(configPath, "config.toml")
+	clientTomlFilePath := path.Join(configPath, "client.toml")
+
+	var config types.ConfigToml
+	if err := readTomlFile(configTomlFilePath, &config); err != nil {
+		return err
+	}
+	var client types.ClientToml
+	if err := readTomlFile(clientTomlFilePath, &client); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), liveNodeTimeout)
+	defer cancel()
+
+	rpcClient := rpc_client.NewRpcClient(c.opts.Rpc, liveNodeTimeout)
+	status, err := rpcClient.Status(ctx)
+	if err != nil {
+		c.fatalRecord(ruleLiveNodeRpc, "", fmt.Sprintf("failed to query status of the running node via RPC %s: %v", rpcClient.Endpoint(), err), "ensure the node is running and --rpc is correct", nil)
+		return nil
+	}
+
+	restartSuggest := "restart the node to apply config, or ensure --rpc is of this node"
+
+	if status.NodeInfo.Moniker != config.Moniker {
+		c.warnRecord(
+			ruleLiveNodeMoniker,
+			configTomlFilePath,
+			fmt.Sprintf("moniker of the running node \"%s\" differs from \"%s\" in config.toml file", status.NodeInfo.Moniker, config.Moniker),
+			restartSuggest,
+			nil,
+		)
+	}
+
+	if client.ChainId == "" {
+		c.warnRecord(ruleLiveNodeNetwork, clientTomlFilePath, "chain-id is empty in client.toml file", fmt.Sprintf("set chain-id to \"%s\"", status.NodeInfo.Network), nil)
+	} else if status.NodeInfo.Network != client.ChainId {
+		c.fatalRecord(
+			ruleLiveNodeNetwork,
+			clientTomlFilePath,
+			fmt.Sprintf("network of the running node \"%s\" differs from chain-id \"%s\" in client.toml file", status.NodeInfo.Network, client.ChainId),
+			"correct chain-id in client.toml, or ensure --rpc is of this node",
+			nil,
+		)
+	}
+
+	if config.TxIndex != nil {
+		expectedTxIndex := "on"
+		if config.TxIndex.Indexer == "null" {
+			expectedTxIndex = "off"
+		}
+		if status.NodeInfo.Other.TxIndex != expectedTxIndex {
+			c.warnRecord(
+				ruleLiveNodeTxIndex,
+				configTomlFilePath,
+				fmt.Sprintf("tx_index of the running node is \"%s\" while indexer is \"%s\" in config.toml file", status.NodeInfo.Other.TxIndex, config.TxIndex.Indexer),
+				restartSuggest,
+				nil,
+			)
+		}
+	}
+
+	if config.P2P != nil {
+		expectedListenAddr := config.P2P.Laddr
+		if config.P2P.ExternalAddress != "" {
+			expectedListenAddr = config.P2P.ExternalAddress
+		}
+		if trimTcpScheme(status.NodeInfo.ListenAddr) != trimTcpScheme(expectedListenAddr) {
+			c.warnRecord(
+				ruleLiveNodeListenAddr,
+				configTomlFilePath,
+				fmt.Sprintf("P2P listen address of the running node \"%s\" differs from \"%s\" in config.toml file", status.NodeInfo.ListenAddr, expectedListenAddr),
+				restartSuggest,
+				nil,
+			)
+		}
+	}
+	if config.RPC != nil && trimTcpScheme(status.NodeInfo.Other.RpcAddress) != trimTcpScheme(config.RPC.LAddr) {
+		c.warnRecord(
+			ruleLiveNodeListenAddr,
+			configTomlFilePath,
+			fmt.Sprintf("RPC listen address of the running node \"%s\" differs from \"%s\" in config.toml file", status.NodeInfo.Other.RpcAddress, config.RPC.LAddr),
+			restartSuggest,
+			nil,
+		)
+	}
+
+	if status.SyncInfo.CatchingUp {
+		message := fmt.Sprintf("running node is catching up, latest block height %d", status.SyncInfo.LatestBlockHeight)
+		if nodeType == types.ValidatorNode {
+			c.fatalRecord(ruleLiveNodeCatchingUp, "", message+", validator is missing blocks", "wait for the node to catch up", nil)
+		} else {
+			c.warnRecord(ruleLiveNodeCatchingUp, "", message, "wait for the node to catch up", nil)
+		}
+	}
+
+	if c.opts.Binary != "" {
+		if err := c.checkLiveNodeAppVersion(ctx, rpcClient); err != nil {
+			return err
+		}
+	}
+
+	if nodeType == types.ValidatorNode && !c.remoteSigner {
+		if err := c.checkLiveNodeValidatorPubKey(configPath, status.ValidatorInfo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Checker) checkLiveNodeAppVersion(ctx context.Context, rpcClient *rpc_client.RpcClient) error {
+	abciInfo, err := rpcClient.AbciInfo(ctx)
+	if err != nil {
+		c.fatalRecord(ruleLiveNodeRpc, "", fmt.Sprintf("failed to query abci_info of the running node via RPC %s: %v", rpcClient.Endpoint(), err), "ensure the node is running and --rpc is correct", nil)
+		return nil
+	}
+
+	// some versions of Cosmos-SDK print version to stderr
+	output, err := exec.CommandContext(ctx, c.opts.Binary, "version").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to get version of binary %s: %v", c.opts.Binary, err)
+	}
+	binaryVersion := strings.TrimSpace(string(output))
+
+	runningVersion := abciInfo.Response.Version
+	if strings.TrimPrefix(binaryVersion, "v") != strings.TrimPrefix(runningVersion, "v") {
+		c.warnRecord(
+			ruleLiveNodeAppVersion,
+			c.opts.Binary,
+			fmt.Sprintf("app version of the running node \"%s\" differs from \"%s\" reported by binary %s", runningVersion, binaryVersion, c.opts.Binary),
+			"restart the node to use the binary on disk, or ensure --binary is of this node",
+			nil,
+		)
+	}
+
+	return nil
+}
+
+func (c *Checker) checkLiveNodeValidatorPubKey(configPath string, validatorInfo rpc_client.ValidatorInfo) error {
+	privValidatorJsonFilePath := path.Join(configPath, "priv_validator_key.json")
+	bz, err := os.ReadFile(privValidatorJsonFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read priv_validator_key.json file at %s: %v", privValidatorJsonFilePath, err)
+	}
+
+	var privValidatorKey types.PrivValidatorKey
+	if err := json.Unmarshal(bz, &privValidatorKey); err != nil {
+		return fmt.Errorf("failed to unmarshal priv_validator_key.json file at %s: %v", privValidatorJsonFilePath, err)
+	}
+	if privValidatorKey.PubKey == nil {
+		return fmt.Errorf("pub_key is missing in priv_validator_key.json file at %s", privValidatorJsonFilePath)
+	}
+
+	if privValidatorKey.PubKey.Value != validatorInfo.PubKey.Value {
+		c.fatalRecord(
+			ruleLiveNodeValidatorPubKey,
+			privValidatorJsonFilePath,
+			fmt.Sprintf("validator pubkey of the running node \"%s\" differs from \"%s\" in priv_validator_key.json file", validatorInfo.PubKey.Value, privValidatorKey.PubKey.Value),
+			"restart the node to use the key file, or ensure --rpc is of this node",
+			nil,
+		)
+	}
+
+	return nil
+}
+
+func readTomlFile(filePath string, v any) error {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", filePath, err)
+	}
+	if err := toml.Unmarshal(bz, v); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %v", filePath, err)
+	}
+	return nil
+}
+
+func trimTcpScheme(addr string) string {
+	return strings.TrimPrefix(strings.TrimSpace(addr), "tcp://")
+}
@@ -0,0 +1,84 @@
+package setup_check
+
+import (
+	"github.com/bcdevtools/node-management/types"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestChecker_checkLiveNode(t *testing.T) {
+	home := t.TempDir()
+	configPath := path.Join(home, "config")
+	require.NoError(t, os.Mkdir(configPath, 0o700))
+	require.NoError(t, os.WriteFile(path.Join(configPath, "config.toml"), []byte(`moniker = "val-1"
+
+[rpc]
+laddr = "tcp://127.0.0.1:26657"
+
+[p2p]
+laddr = "tcp://0.0.0.0:26656"
+
+[tx_index]
+indexer = "null"
+`), 0o644))
+	require.NoError(t, os.WriteFile(path.Join(configPath, "client.toml"), []byte(`chain-id = "chain-1"`), 0o600))
+	require.NoError(t, os.WriteFile(path.Join(configPath, "priv_validator_key.json"), []byte(`{"pub_key": {"type": "tendermint/PubKeyEd25519", "value": "key-1"}}`), 0o600))
+
+	var status string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc": "2.0", "id": -1, "result": ` + status + `}`))
+	}))
+	defer server.Close()
+
+	checkLiveNode := func(t *testing.T) []Finding {
+		checker, err := NewChecker(Options{
+			Home:     home,
+			NodeType: types.ValidatorNode,
+			Rpc:      server.URL,
+		})
+		require.NoError(t, err)
+		require.NoError(t, checker.checkLiveNode(home, types.ValidatorNode))
+		return checker.report(nil).Findings
+	}
+
+	t.Run("matches", func(t *testing.T) {
+		status = `{
+  "node_info": {"listen_addr": "tcp://0.0.0.0:26656", "network": "chain-1", "moniker": "val-1", "other": {"tx_index": "off", "rpc_address": "tcp://127.0.0.1:26657"}},
+  "sync_info": {"latest_block_height": "100", "catching_up": false},
+  "validator_info": {"pub_key": {"type": "tendermint/PubKeyEd25519", "value": "key-1"}}
+}`
+		require.Empty(t, checkLiveNode(t))
+	})
+
+	t.Run("differs", func(t *testing.T) {
+		status = `{
+  "node_info": {"listen_addr": "tcp://0.0.0.0:36656", "network": "chain-2", "moniker": "val-2", "other": {"tx_index": "on", "rpc_address": "tcp://127.0.0.1:26657"}},
+  "sync_info": {"latest_block_height": "100", "catching_up": true},
+  "validator_info": {"pub_key": {"type": "tendermint/PubKeyEd25519", "value": "key-2"}}
+}`
+		var ruleIds []string
+		for _, finding := range checkLiveNode(t) {
+			ruleIds = append(ruleIds, finding.RuleId)
+		}
+		require.Equal(t, []string{
+			ruleLiveNodeNetwork.Id,
+			ruleLiveNodeCatchingUp.Id,
+			ruleLiveNodeValidatorPubKey.Id,
+			ruleLiveNodeMoniker.Id,
+			ruleLiveNodeTxIndex.Id,
+			ruleLiveNodeListenAddr.Id,
+		}, ruleIds)
+	})
+
+	t.Run("node is not reachable", func(t *testing.T) {
+		server.Close()
+		findings := checkLiveNode(t)
+		require.Len(t, findings, 1)
+		require.Equal(t, ruleLiveNodeRpc.Id, findings[0].RuleId)
+		require.True(t, findings[0].Fatal())
+	})
+}
@@ -0,0 +1,180 @@
+package setup_check
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/services/genesis"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+	"os"
+	"path"
+	"strings"
+)
+
+// PolicyFileName is the default policy file, placed in the node home directory.
+const PolicyFileName = ".nmngd-setup-check.toml"
+
+// Thresholds are the thresholds used by checks, can be overridden by the policy file, globally or per chain.
+type Thresholds struct {
+	// PruningKeepRecent is the recommended pruning-keep-recent for custom pruning
+	PruningKeepRecent    int64
+	MaxPruningKeepRecent int64
+	// MinInboundPeers and MinOutboundPeers warn when max_num_inbound_peers/max_num_outbound_peers is lower
+	MinInboundPeers     int64
+	MinOutboundPeers    int64
+	MinSnapshotInterval int64
+	// MinSeedInboundPeers is the max_num_inbound_peers at least of seed node, which serves addresses to many peers
+	MinSeedInboundPeers int64
+}
+
+func DefaultThresholds() Thresholds {
+	return Thresholds{
+		PruningKeepRecent:    362880,
+		MaxPruningKeepRecent: 500_000,
+		MinInboundPeers:      60,
+		MinOutboundPeers:     31,
+		MinSnapshotInterval:  1000,
+		MinSeedInboundPeers:  200,
+	}
+}
+
+// Policy is the thresholds and the suppressed rules applied to a check.
+type Policy struct {
+	Thresholds Thresholds
+	// Suppressions maps rule ID to the reason, records of suppressed rules are reported separately and do not affect the result.
+	Suppressions map[string]string
+}
+
+func DefaultPolicy() Policy {
+	return Policy{
+		Thresholds:   DefaultThresholds(),
+		Suppressions: make(map[string]string),
+	}
+}
+
+// setupCheckPolicy is the content of the policy file.
+//
+//	[thresholds]
+//	pruning-keep-recent = 100000
+//
+//	[[suppress]]
+//	rule = "config-p2p-laddr"
+//	reason = "sentry node uses the default P2P port"
+//
+//	[chains.evmos_9001-2.thresholds]
+//	min-inbound-peers = 40
+//
+//	[[chains.evmos_9001-2.suppress]]
+//	rule = "service-file-user-name"
+//	reason = "username convention of the team"
+type setupCheckPolicy struct {
+	Thresholds policyThresholds                   `toml:"thresholds"`
+	Suppress   []policySuppression                `toml:"suppress"`
+	Chains     map[string]setupCheckPolicyOfChain `toml:"chains"`
+}
+
+type setupCheckPolicyOfChain struct {
+	Thresholds policyThresholds    `toml:"thresholds"`
+	Suppress   []policySuppression `toml:"suppress"`
+}
+
+type policyThresholds struct {
+	PruningKeepRecent    *int64 `toml:"pruning-keep-recent"`
+	MaxPruningKeepRecent *int64 `toml:"max-pruning-keep-recent"`
+	MinInboundPeers      *int64 `toml:"min-inbound-peers"`
+	MinOutboundPeers     *int64 `toml:"min-outbound-peers"`
+	MinSnapshotInterval  *int64 `toml:"min-snapshot-interval"`
+	MinSeedInboundPeers  *int64 `toml:"min-seed-inbound-peers"`
+}
+
+type policySuppression struct {
+	Rule   string `toml:"rule"`
+	Reason string `toml:"reason"`
+}
+
+// LoadPolicy loads the policy file on top of the default policy, global first then of the chain.
+// The chain-id is read from the genesis file, only when the policy file contains chain profiles.
+// Returns the default policy and false if the policy file does not exist and is not required.
+func LoadPolicy(policyFilePath string, required bool, genesisFilePath string) (Policy, bool, error) {
+	policy := DefaultPolicy()
+
+	_, exists, _, err := utils.FileInfo(policyFilePath)
+	if err != nil {
+		return policy, false, errors.Wrapf(err, "failed to check policy file %s", policyFilePath)
+	}
+	if !exists {
+		if required {
+			return policy, false, fmt.Errorf("policy file does not exist: %s", policyFilePath)
+		}
+		return policy, false, nil
+	}
+
+	file, err := os.Open(policyFilePath)
+	if err != nil {
+		return policy, false, errors.Wrapf(err, "failed to open policy file %s", policyFilePath)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	var policyFile setupCheckPolicy
+	if err := toml.NewDecoder(file).DisallowUnknownFields().Decode(&policyFile); err != nil {
+		return policy, false, errors.Wrapf(err, "failed to parse policy file %s", policyFilePath)
+	}
+
+	if err := policy.apply(policyFile.Thresholds, policyFile.Suppress); err != nil {
+		return policy, false, errors.Wrapf(err, "invalid policy file %s", policyFilePath)
+	}
+	if len(policyFile.Chains) > 0 {
+		chainId, err := genesis.ReadChainId(genesisFilePath)
+		if err != nil {
+			return policy, false, errors.Wrapf(err, "failed to read chain-id from %s to apply chain profiles of policy file", genesisFilePath)
+		}
+		if chainPolicy, found := policyFile.Chains[chainId]; found {
+			if err := policy.apply(chainPolicy.Thresholds, chainPolicy.Suppress); err != nil {
+				return policy, false, errors.Wrapf(err, "invalid policy of chain %s in policy file %s", chainId, policyFilePath)
+			}
+		}
+	}
+
+	return policy, true, nil
+}
+
+func (p *Policy) apply(overrides policyThresholds, suppress []policySuppression) error {
+	for _, override := range []struct {
+		name   string
+		value  *int64
+		target *int64
+	}{
+		{"pruning-keep-recent", overrides.PruningKeepRecent, &p.Thresholds.PruningKeepRecent},
+		{"max-pruning-keep-recent", overrides.MaxPruningKeepRecent, &p.Thresholds.MaxPruningKeepRecent},
+		{"min-inbound-peers", overrides.MinInboundPeers, &p.Thresholds.MinInboundPeers},
+		{"min-outbound-peers", overrides.MinOutboundPeers, &p.Thresholds.MinOutboundPeers},
+		{"min-snapshot-interval", overrides.MinSnapshotInterval, &p.Thresholds.MinSnapshotInterval},
+		{"min-seed-inbound-peers", overrides.MinSeedInboundPeers, &p.Thresholds.MinSeedInboundPeers},
+	} {
+		if override.value == nil {
+			continue
+		}
+		if *override.value < 0 {
+			return fmt.Errorf("threshold %s must not be negative", override.name)
+		}
+		*override.target = *override.value
+	}
+
+	for _, suppression := range suppress {
+		if FindRule(suppression.Rule) == nil {
+			return fmt.Errorf("unknown rule \"%s\" to suppress", suppression.Rule)
+		}
+		if strings.TrimSpace(suppression.Reason) == "" {
+			return fmt.Errorf("reason is required to suppress rule \"%s\"", suppression.Rule)
+		}
+		p.Suppressions[suppression.Rule] = strings.TrimSpace(suppression.Reason)
+	}
+
+	return nil
+}
+
+func DefaultPolicyFilePath(home string) string {
+	return path.Join(home, PolicyFileName)
+}
@@ -0,0 +1,74 @@
+package setup_check
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path"
+	"testing"
+)
+
+func Test_loadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	genesisFilePath := path.Join(dir, "genesis.json")
+	require.NoError(t, os.WriteFile(genesisFilePath, []byte(`{"app_state":{"bank":{"balances":[{"coins":[]}]}},"chain_id":"evmos_9001-2"}`), 0o600))
+
+	writePolicy := func(content string) string {
+		policyFilePath := path.Join(dir, PolicyFileName)
+		require.NoError(t, os.WriteFile(policyFilePath, []byte(content), 0o600))
+		return policyFilePath
+	}
+
+	t.Run("not exists", func(t *testing.T) {
+		policy, loaded, err := LoadPolicy(path.Join(dir, "missing.toml"), false, genesisFilePath)
+		require.NoError(t, err)
+		require.False(t, loaded)
+		require.Equal(t, DefaultPolicy(), policy)
+
+		_, _, err = LoadPolicy(path.Join(dir, "missing.toml"), true, genesisFilePath)
+		require.Error(t, err)
+	})
+
+	t.Run("global and chain", func(t *testing.T) {
+		policy, loaded, err := LoadPolicy(writePolicy(`
+[thresholds]
+pruning-keep-recent = 100000
+min-inbound-peers = 40
+
+[[suppress]]
+rule = "config-p2p-laddr"
+reason = "sentry node uses the default P2P port"
+
+[chains.evmos_9001-2.thresholds]
+min-inbound-peers = 20
+
+[[chains.evmos_9001-2.suppress]]
+rule = "service-file-user-name"
+reason = "team convention"
+
+[chains.cosmoshub-4.thresholds]
+min-outbound-peers = 1
+`), false, genesisFilePath)
+		require.NoError(t, err)
+		require.True(t, loaded)
+
+		require.Equal(t, int64(100000), policy.Thresholds.PruningKeepRecent)
+		require.Equal(t, int64(20), policy.Thresholds.MinInboundPeers)
+		require.Equal(t, DefaultThresholds().MinOutboundPeers, policy.Thresholds.MinOutboundPeers)
+		require.Equal(t, map[string]string{
+			"config-p2p-laddr":       "sentry node uses the default P2P port",
+			"service-file-user-name": "team convention",
+		}, policy.Suppressions)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, content := range map[string]string{
+			"unknown rule":       "[[suppress]]\nrule = \"not-a-rule\"\nreason = \"x\"\n",
+			"missing reason":     "[[suppress]]\nrule = \"config-p2p-laddr\"\n",
+			"negative threshold": "[thresholds]\nmin-inbound-peers = -1\n",
+			"unknown field":      "[thresholds]\nmin-peers = 1\n",
+		} {
+			_, _, err := LoadPolicy(writePolicy(content), false, genesisFilePath)
+			require.Error(t, err, name)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package setup_check
+
+// Rule is a kind of check, the ID is stable and used by machine-readable outputs and the policy file.
+type Rule struct {
+	Id string
+	// Key is the config key the rule concerns, `<section>.<key>` for TOML files, empty if not about a key
+	Key         string
+	Description string
+}
+
+var allRules []Rule
+
+func newCheckRule(id, key, description string) Rule {
+	rule := Rule{
+		Id:          id,
+		Key:         key,
+		Description: description,
+	}
+	allRules = append(allRules, rule)
+	return rule
+}
+
+// AllRules returns all the registered rules, in the order of registration.
+func AllRules() []Rule {
+	return append([]Rule{}, allRules...)
+}
+
+// FindRule returns the rule with the given ID, nil if not found.
+func FindRule(id string) *Rule {
+	for _, rule := range allRules {
+		if rule.Id == id {
+			return &rule
+		}
+	}
+	return nil
+}
+
+var (
+	ruleLatestRelease = newCheckRule("latest-release", "", "Tool must be the latest release")
+	rulePermission    = newCheckRule("permission", "", "File or directory permission must be safe")
+	ruleKeyPermission = newCheckRule("key-permission", "", "Key file or directory permission must be safe")
+
+	ruleAppMinimumGasPrices     = newCheckRule("app-minimum-gas-prices", "minimum-gas-prices", "minimum-gas-prices must be set")
+	ruleAppPruning              = newCheckRule("app-pruning", "pruning", "Pruning strategy must match the node type")
+	ruleAppPruningKeepRecent    = newCheckRule("app-pruning-keep-recent", "pruning-keep-recent", "pruning-keep-recent must match the node type")
+	ruleAppPruningInterval      = newCheckRule("app-pruning-interval", "pruning-interval", "pruning-interval must be reasonable")
+	ruleAppHaltHeight           = newCheckRule("app-halt-height", "halt-height", "halt-height should not be set")
+	ruleAppHaltTime             = newCheckRule("app-halt-time", "halt-time", "halt-time should not be set")
+	ruleAppMinRetainBlocks      = newCheckRule("app-min-retain-blocks", "min-retain-blocks", "min-retain-blocks must match the pruning strategy")
+	ruleAppApiEnable            = newCheckRule("app-api-enable", "api.enable", "Rest-API must be enabled/disabled based on the node type")
+	ruleAppApiSwagger           = newCheckRule("app-api-swagger", "api.swagger", "Swagger should be enabled on RPC and archival nodes")
+	ruleAppJsonRpcEnable        = newCheckRule("app-json-rpc-enable", "json-rpc.enable", "Json-RPC must be enabled/disabled based on the node type")
+	ruleAppJsonRpcEnableIndexer = newCheckRule("app-json-rpc-enable-indexer", "json-rpc.enable-indexer", "EVM-indexer must be enabled/disabled based on the node type")
+	ruleAppSnapshotInterval     = newCheckRule("app-snapshot-interval", "state-sync.snapshot-interval", "State-sync snapshot interval must match the node type")
+	ruleAppSnapshotKeepRecent   = newCheckRule("app-snapshot-keep-recent", "state-sync.snapshot-keep-recent", "State-sync snapshot keep recent should be 2")
+	ruleAppGrpcEnable           = newCheckRule("app-grpc-enable", "grpc.enable", "gRPC must be enabled/disabled based on the node type")
+	ruleAppGrpcMaxSendMsgSize   = newCheckRule("app-grpc-max-send-msg-size", "grpc.max-send-msg-size", "gRPC max-send-msg-size should be 100 MB")
+	ruleAppGrpcAddress          = newCheckRule("app-grpc-address", "grpc.address", "gRPC port should not be the default one")
+
+	ruleConfigMoniker               = newCheckRule("config-moniker", "moniker", "Moniker must be set")
+	ruleConfigSeeds                 = newCheckRule("config-p2p-seeds", "p2p.seeds", "Seeds must be set in a valid format")
+	ruleConfigP2pLaddr              = newCheckRule("config-p2p-laddr", "p2p.laddr", "P2P port should not be the default one")
+	ruleConfigPersistentPeers       = newCheckRule("config-p2p-persistent-peers", "p2p.persistent_peers", "Persistent peers must be set in a valid format")
+	ruleConfigMaxNumInboundPeers    = newCheckRule("config-p2p-max-num-inbound-peers", "p2p.max_num_inbound_peers", "max_num_inbound_peers should not be too low")
+	ruleConfigMaxNumOutboundPeers   = newCheckRule("config-p2p-max-num-outbound-peers", "p2p.max_num_outbound_peers", "max_num_outbound_peers should not be too low")
+	ruleConfigSeedMode              = newCheckRule("config-p2p-seed-mode", "p2p.seed_mode", "seed_mode must be enabled on seed node only")
+	ruleConfigPex                   = newCheckRule("config-p2p-pex", "p2p.pex", "Peer exchange must be enabled on sentry/seed node and disabled on validator behind sentries")
+	ruleConfigPrivatePeerIds        = newCheckRule("config-p2p-private-peer-ids", "p2p.private_peer_ids", "Sentry node must keep the validator node ID private")
+	ruleConfigUnconditionalPeerIds  = newCheckRule("config-p2p-unconditional-peer-ids", "p2p.unconditional_peer_ids", "Sentry node must always accept the validator node")
+	ruleConfigAddrBookStrict        = newCheckRule("config-p2p-addr-book-strict", "p2p.addr_book_strict", "addr_book_strict should be disabled on sentry node to reach the validator at private address")
+	ruleConfigSeedAddrBookStrict    = newCheckRule("config-p2p-seed-addr-book-strict", "p2p.addr_book_strict", "addr_book_strict should be enabled on seed node")
+	ruleConfigSentryPeers           = newCheckRule("config-p2p-sentry-peers", "p2p.persistent_peers", "Validator behind sentries must only peer with the sentries")
+	ruleConfigStateSyncEnable       = newCheckRule("config-statesync-enable", "statesync.enable", "State-sync should be disabled")
+	ruleConfigDoubleSignCheckHeight = newCheckRule("config-double-sign-check-height", "consensus.double_sign_check_height", "double_sign_check_height must be set on validator")
+	ruleConfigSkipTimeoutCommit     = newCheckRule("config-skip-timeout-commit", "consensus.skip_timeout_commit", "skip_timeout_commit should be disabled")
+	ruleConfigPrivValidatorLaddr    = newCheckRule("config-priv-validator-laddr", "priv_validator_laddr", "priv_validator_laddr must be set and firewalled when using remote signer")
+	ruleConfigTxIndexIndexer        = newCheckRule("config-tx-index-indexer", "tx_index.indexer", "Tx indexer must match the node type")
+
+	ruleRemoteSignerKeyFile     = newCheckRule("remote-signer-key-file", "", "priv_validator_key.json must be absent when using remote signer")
+	rulePrivValidatorKeyMissing = newCheckRule("priv-validator-key-missing", "", "priv_validator_key.json must exist unless using remote signer")
+	rulePrivValStateEmpty       = newCheckRule("priv-validator-state-empty", "", "priv_validator_state.json should not be empty on validator")
+	ruleKeyringFileMissing      = newCheckRule("keyring-file-missing", "", "keyring-file should exist on validator")
+	ruleKeyringOnNonValidator   = newCheckRule("keyring-on-non-validator", "", "Keys should not be stored on non-validator node")
+	ruleKeyhashMissing          = newCheckRule("keyhash-missing", "", "keyhash file should exist on validator")
+	ruleKeyringTestUsed         = newCheckRule("keyring-test-used", "", "keyring-test must not be used")
+
+	ruleLiveNodeRpc             = newCheckRule("live-node-rpc", "", "Node RPC must be reachable to verify the running node")
+	ruleLiveNodeMoniker         = newCheckRule("live-node-moniker", "moniker", "Moniker of the running node must match config.toml")
+	ruleLiveNodeNetwork         = newCheckRule("live-node-network", "chain-id", "Network of the running node must match chain-id of client.toml")
+	ruleLiveNodeTxIndex         = newCheckRule("live-node-tx-index", "tx_index.indexer", "Tx indexer of the running node must match config.toml")
+	ruleLiveNodeListenAddr      = newCheckRule("live-node-listen-addr", "p2p.laddr", "Listen addresses of the running node must match config.toml")
+	ruleLiveNodeCatchingUp      = newCheckRule("live-node-catching-up", "", "Running node must not be catching up")
+	ruleLiveNodeAppVersion      = newCheckRule("live-node-app-version", "", "App version of the running node should match the binary on disk")
+	ruleLiveNodeValidatorPubKey = newCheckRule("live-node-validator-pub-key", "", "Validator pubkey of the running node must match priv_validator_key.json")
+
+	ruleServiceFileLocation    = newCheckRule("service-file-location", "", "Service file must be a systemd service file")
+	ruleServiceFileReload      = newCheckRule("service-file-reload", "", "Service must be reloaded after updating the service file")
+	ruleServiceFileDescription = newCheckRule("service-file-description", "Unit.Description", "Service file must have Description")
+	ruleServiceFileAfter       = newCheckRule("service-file-after", "Unit.After", "Service file must start after network-online.target")
+	ruleServiceFileUser        = newCheckRule("service-file-user", "Service.User", "Service must run under a dedicated non-root user")
+	ruleServiceFileUserName    = newCheckRule("service-file-user-name", "Service.User", "Service user should be a memorable name with hyphen")
+	ruleServiceFileExecStart   = newCheckRule("service-file-exec-start", "Service.ExecStart", "Service ExecStart must use the node home")
+	ruleServiceFileRestart     = newCheckRule("service-file-restart", "Service.Restart", "Service must not restart automatically")
+	ruleServiceFileRestartSec  = newCheckRule("service-file-restart-sec", "Service.RestartSec", "Service must not have RestartSec")
+	ruleServiceFileWantedBy    = newCheckRule("service-file-wanted-by", "Install.WantedBy", "Service file must be wanted by multi-user.target")
+	ruleServiceEnabled         = newCheckRule("service-enabled", "", "Validator service must not be enabled to start at boot")
+)
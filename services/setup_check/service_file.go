@@ -10,122 +10,147 @@ import (
 	"strings"
 )
 
-func checkServiceFileForValidatorOnLinux(home string, serviceFilePath string) {
+func (c *Checker) checkServiceFileForValidatorOnLinux(home string, serviceFilePath string) error {
 	perm, exists, isDir, err := utils.FileInfo(serviceFilePath)
 	if err != nil {
-		exitWithErrorMsgf("ERR: failed to check service file at %s: %v\n", serviceFilePath, err)
-		return
+		return fmt.Errorf("failed to check service file at %s: %v", serviceFilePath, err)
 	}
 	if !exists {
-		exitWithErrorMsgf("ERR: service file does not exist: %s\n", serviceFilePath)
-		return
+		return fmt.Errorf("service file does not exist: %s", serviceFilePath)
 	}
 	if isDir {
-		exitWithErrorMsgf("ERR: service file is a directory, it should be a file: %s\n", serviceFilePath)
-		return
+		return fmt.Errorf("service file is a directory, it should be a file: %s", serviceFilePath)
 	}
 	if perm != 0o644 {
-		fatalRecord("service file has invalid permission", "sudo chmod 644 "+serviceFilePath)
+		c.fatalRecord(rulePermission, serviceFilePath, "service file has invalid permission", "sudo chmod 644 "+serviceFilePath, chmodFix(serviceFilePath, 0o777, 0o644))
 	}
 	if !strings.HasSuffix(serviceFilePath, ".service") {
-		fatalRecord("service file is not a systemd service file", "use .service file extension")
+		c.fatalRecord(ruleServiceFileLocation, serviceFilePath, "service file is not a systemd service file", "use .service file extension", nil)
 	}
 	if !strings.HasPrefix(serviceFilePath, "/etc/systemd/system") {
-		warnRecord("service file is not in /etc/systemd/system directory", "use systemd")
+		c.warnRecord(ruleServiceFileLocation, serviceFilePath, "service file is not in /etc/systemd/system directory", "use systemd", nil)
 	}
 
 	// check service file content
 
 	bz, err := os.ReadFile(serviceFilePath)
 	if err != nil {
-		exitWithErrorMsgf("ERR: failed to read service file: %v\n", err)
-		return
+		return fmt.Errorf("failed to read service file: %v", err)
 	}
 
 	var sf unit.ServiceFile
 	err = systemdconf.Unmarshal(bz, &sf)
 	if err != nil {
-		exitWithErrorMsgf("ERR: failed to unmarshal service file: %v\n", err)
-		return
+		return fmt.Errorf("failed to unmarshal service file: %v", err)
 	}
 
-	originalRecordsCount := len(checkRecords)
+	originalRecordsCount := len(c.records)
 	defer func() {
-		if len(checkRecords) > originalRecordsCount {
-			warnRecord("remember to reload service after updated service file", "sudo systemctl daemon-reload")
+		if len(c.records) > originalRecordsCount {
+			c.warnRecord(ruleServiceFileReload, serviceFilePath, "remember to reload service after updated service file", "sudo systemctl daemon-reload", nil)
 		}
 	}()
 
 	if sf.Unit.Description.String() == "" {
-		fatalRecord("service file is missing Description in [Unit] section", "add Description to [Unit] section")
+		c.fatalRecord(ruleServiceFileDescription, serviceFilePath, "service file is missing Description in [Unit] section", "add Description to [Unit] section", nil)
 	}
 	if sf.Unit.After.String() == "" {
-		fatalRecord("service file is missing After in [Unit] section", "add After to [Unit] section")
+		c.fatalRecord(ruleServiceFileAfter, serviceFilePath, "service file is missing After in [Unit] section", "add After to [Unit] section", serviceFileFix(serviceFilePath, "Unit", "After", "network-online.target"))
 	} else if sf.Unit.After.String() != "network-online.target" {
-		fatalRecord("service file is using invalid After in [Unit] section", "change After to network-online.target")
+		c.fatalRecord(ruleServiceFileAfter, serviceFilePath, "service file is using invalid After in [Unit] section", "change After to network-online.target", serviceFileFix(serviceFilePath, "Unit", "After", "network-online.target"))
 	}
 
 	if sf.Service.User.String() == "" {
-		fatalRecord("service file is missing User in [Service] section", "add User to [Service] section")
+		c.fatalRecord(ruleServiceFileUser, serviceFilePath, "service file is missing User in [Service] section", "add User to [Service] section", nil)
 	} else {
 		user := strings.TrimSpace(strings.ToLower(sf.Service.User.String()))
 		if user == "root" || user == "ubuntu" {
-			fatalRecord(
+			c.fatalRecord(
+				ruleServiceFileUser,
+				serviceFilePath,
 				"service file is using invalid User in [Service] section",
 				"change User to a non-root user",
+				nil,
 			)
 		} else if !strings.Contains(user, "-") {
-			warnRecord(
+			c.warnRecord(
+				ruleServiceFileUserName,
+				serviceFilePath,
 				"service file is using invalid User in [Service] section",
 				"use memorable username with hyphen, e.g. \"val-x-testnet\"",
+				nil,
 			)
 		}
 	}
 	if sf.Service.ExecStart.String() == "" {
-		fatalRecord(
+		c.fatalRecord(
+			ruleServiceFileExecStart,
+			serviceFilePath,
 			"service file is missing ExecStart in [Service] section", "add ExecStart to [Service] section",
+			nil,
 		)
 	} else if !strings.Contains(sf.Service.ExecStart.String(), "--home") {
-		fatalRecord(
+		c.fatalRecord(
+			ruleServiceFileExecStart,
+			serviceFilePath,
 			"service file is missing --home in ExecStart in [Service] section",
 			"add --home to ExecStart in [Service] section",
+			nil,
 		)
 	} else {
 		_, homeName := filepath.Split(home)
 		if !strings.Contains(sf.Service.ExecStart.String(), homeName) {
-			fatalRecord(
+			c.fatalRecord(
+				ruleServiceFileExecStart,
+				serviceFilePath,
 				fmt.Sprintf("--home in ExecStart in [Service] section might not pointing to the correct home dir \"%s\"", homeName),
 				"change --home to --home="+homeName,
+				nil,
 			)
 		}
 	}
 	if sf.Service.Restart.String() == "" {
-		fatalRecord(
+		c.fatalRecord(
+			ruleServiceFileRestart,
+			serviceFilePath,
 			"service file is missing Restart in [Service] section",
 			"add Restart=no to [Service] section",
+			serviceFileFix(serviceFilePath, "Service", "Restart", "no"),
 		)
 	} else if sf.Service.Restart.String() != "no" {
-		fatalRecord(
+		c.fatalRecord(
+			ruleServiceFileRestart,
+			serviceFilePath,
 			"service file is using invalid Restart in [Service] section, must using 'no' to prevent incident restart",
 			"change Restart=no",
+			serviceFileFix(serviceFilePath, "Service", "Restart", "no"),
 		)
 	}
 	if sf.Service.RestartSec.String() != "" {
-		fatalRecord(
+		c.fatalRecord(
+			ruleServiceFileRestartSec,
+			serviceFilePath,
 			"service file contains RestartSec in [Service] section",
 			"remove RestartSec from [Service] section",
+			serviceFileFix(serviceFilePath, "Service", "RestartSec", ""),
 		)
 	}
 
 	if sf.Install.WantedBy.String() == "" {
-		fatalRecord(
+		c.fatalRecord(
+			ruleServiceFileWantedBy,
+			serviceFilePath,
 			"service file is missing WantedBy in [Install] section",
 			"add WantedBy=multi-user.target in [Install] section",
+			serviceFileFix(serviceFilePath, "Install", "WantedBy", "multi-user.target"),
 		)
 	} else if sf.Install.WantedBy.String() != "multi-user.target" {
-		fatalRecord(
+		c.fatalRecord(
+			ruleServiceFileWantedBy,
+			serviceFilePath,
 			"service file is using invalid WantedBy in [Install] section",
 			"change WantedBy to multi-user.target in [Install] section",
+			serviceFileFix(serviceFilePath, "Install", "WantedBy", "multi-user.target"),
 		)
 	}
 
@@ -133,13 +158,17 @@ func checkServiceFileForValidatorOnLinux(home string, serviceFilePath string) {
 	multiUserTargetWantsServiceFilePath := filepath.Join("/etc/systemd/system/multi-user.target.wants", serviceFileName)
 	_, exists, _, err = utils.FileInfo(multiUserTargetWantsServiceFilePath)
 	if err != nil {
-		exitWithErrorMsgf("ERR: failed to check if service file is enabled: %v\n", err)
-		return
+		return fmt.Errorf("failed to check if service file is enabled: %v", err)
 	}
 	if exists {
-		fatalRecord(
+		c.fatalRecord(
+			ruleServiceEnabled,
+			serviceFilePath,
 			"service file is already enabled, validator must disable service automatically run at startup",
 			"sudo systemctl disable "+serviceFileName,
+			skippedFix("sudo systemctl disable "+serviceFileName, "requires sudo"),
 		)
 	}
+
+	return nil
 }
@@ -0,0 +1,179 @@
+package setup_check
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// serviceFileDocument is a systemd unit file which can be edited while keeping comments and ordering of other lines.
+type serviceFileDocument struct {
+	lines []string
+}
+
+// serviceFileEntry is a key-value line in the unit file, the value might be continued on next lines by a trailing backslash.
+type serviceFileEntry struct {
+	section   string
+	key       string
+	startLine int
+	endLine   int
+}
+
+func parseServiceFile(bz []byte) *serviceFileDocument {
+	return &serviceFileDocument{
+		lines: strings.Split(string(bz), "\n"),
+	}
+}
+
+func (d *serviceFileDocument) bytes() []byte {
+	return []byte(strings.Join(d.lines, "\n"))
+}
+
+func (d *serviceFileDocument) entries() []serviceFileEntry {
+	var entries []serviceFileEntry
+	var section string
+
+	for l := 0; l < len(d.lines); l++ {
+		trimmed := strings.TrimSpace(d.lines[l])
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			continue
+		}
+
+		key, _, found := strings.Cut(trimmed, "=")
+		if !found {
+			continue
+		}
+
+		endLine := l
+		for endLine < len(d.lines)-1 && strings.HasSuffix(strings.TrimRight(d.lines[endLine], " \t\r"), `\`) {
+			endLine++
+		}
+
+		entries = append(entries, serviceFileEntry{
+			section:   section,
+			key:       strings.TrimSpace(key),
+			startLine: l,
+			endLine:   endLine,
+		})
+		l = endLine
+	}
+
+	return entries
+}
+
+// set replaces the first occurrence of the key in the section and removes the others,
+// the key is appended to the end of the section if not exists, the section is appended if not exists.
+func (d *serviceFileDocument) set(section, key, value string) {
+	newLine := key + "=" + value
+
+	var found bool
+	entries := d.entries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.section != section || e.key != key {
+			continue
+		}
+
+		isFirst := true
+		for _, other := range entries[:i] {
+			if other.section == section && other.key == key {
+				isFirst = false
+				break
+			}
+		}
+
+		if isFirst {
+			d.replaceLines(e.startLine, e.endLine, []string{newLine})
+			found = true
+		} else {
+			d.replaceLines(e.startLine, e.endLine, nil)
+		}
+	}
+	if found {
+		return
+	}
+
+	insertAt := -1
+	var currentSection string
+	for l, line := range d.lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			currentSection = strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			continue
+		}
+		if currentSection == section && trimmed != "" {
+			insertAt = l + 1
+		}
+	}
+	if insertAt < 0 {
+		for l, line := range d.lines {
+			if strings.TrimSpace(line) == "["+section+"]" {
+				insertAt = l + 1
+				break
+			}
+		}
+	}
+
+	if insertAt < 0 {
+		for len(d.lines) > 0 && strings.TrimSpace(d.lines[len(d.lines)-1]) == "" {
+			d.lines = d.lines[:len(d.lines)-1]
+		}
+		d.lines = append(d.lines, "", "["+section+"]", newLine, "")
+		return
+	}
+
+	d.replaceLines(insertAt, insertAt-1, []string{newLine})
+}
+
+// remove removes all occurrences of the key in the section.
+func (d *serviceFileDocument) remove(section, key string) {
+	entries := d.entries()
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.section == section && e.key == key {
+			d.replaceLines(e.startLine, e.endLine, nil)
+		}
+	}
+}
+
+// replaceLines replaces lines from startLine to endLine (inclusive) with the new lines,
+// endLine = startLine - 1 means inserting at startLine.
+func (d *serviceFileDocument) replaceLines(startLine, endLine int, newLines []string) {
+	lines := make([]string, 0, len(d.lines)+len(newLines))
+	lines = append(lines, d.lines[:startLine]...)
+	lines = append(lines, newLines...)
+	lines = append(lines, d.lines[endLine+1:]...)
+	d.lines = lines
+}
+
+// saveWithBackup writes the document into the file, after copying the original file into a timestamped backup.
+func (d *serviceFileDocument) saveWithBackup(filePath string) (string, error) {
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to check %s", filePath)
+	}
+	original, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read %s", filePath)
+	}
+
+	dir, fileName := filepath.Split(filePath)
+	backupFilePath := filepath.Join(dir, fmt.Sprintf("%s.%s.%s.bak", fileName, utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime), constants.BINARY_NAME))
+	if err := os.WriteFile(backupFilePath, original, fi.Mode().Perm()); err != nil {
+		return "", errors.Wrap(err, "failed to write backup file")
+	}
+
+	if err := os.WriteFile(filePath, d.bytes(), fi.Mode().Perm()); err != nil {
+		return backupFilePath, errors.Wrapf(err, "failed to write %s", filePath)
+	}
+	return backupFilePath, nil
+}
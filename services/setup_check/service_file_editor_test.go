@@ -0,0 +1,43 @@
+package setup_check
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func Test_serviceFileDocument(t *testing.T) {
+	const serviceFile = `[Unit]
+Description=node
+After=network.target
+
+[Service]
+User=val-x
+ExecStart=/usr/bin/noded start \
+  --home /home/val-x/.node
+Restart=always
+RestartSec=3
+Restart=on-failure
+`
+
+	doc := parseServiceFile([]byte(serviceFile))
+	doc.set("Unit", "After", "network-online.target")
+	doc.set("Service", "Restart", "no")
+	doc.remove("Service", "RestartSec")
+	doc.set("Service", "LimitNOFILE", "65535")
+	doc.set("Install", "WantedBy", "multi-user.target")
+
+	require.Equal(t, `[Unit]
+Description=node
+After=network-online.target
+
+[Service]
+User=val-x
+ExecStart=/usr/bin/noded start \
+  --home /home/val-x/.node
+Restart=no
+LimitNOFILE=65535
+
+[Install]
+WantedBy=multi-user.target
+`, string(doc.bytes()))
+}
@@ -0,0 +1,57 @@
+package setup_check
+
+type checkRecord struct {
+	rule  Rule
+	fatal bool
+	// file is the file or directory the record concerns, empty if not about a file
+	file    string
+	message string
+	suggest string
+	addedNo int
+	// fix is the automatic remediation, applied by ApplyFixes
+	fix *checkFix
+	// suppressReason is the reason from the policy, when the rule is suppressed
+	suppressReason string
+}
+
+func (r checkRecord) severity() string {
+	if r.fatal {
+		return SeverityFatal
+	}
+	return SeverityWarn
+}
+
+func (r checkRecord) finding() Finding {
+	finding := Finding{
+		RuleId:         r.rule.Id,
+		Severity:       r.severity(),
+		File:           r.file,
+		Key:            r.rule.Key,
+		Message:        r.message,
+		Suggest:        r.suggest,
+		SuppressReason: r.suppressReason,
+	}
+	if r.fix != nil {
+		finding.Fix = r.fix.description
+	}
+	return finding
+}
+
+func (c *Checker) putCheckRecord(record checkRecord) {
+	if reason, suppressed := c.policy.Suppressions[record.rule.Id]; suppressed {
+		record.suppressReason = reason
+		c.suppressedRecords = append(c.suppressedRecords, record)
+		return
+	}
+
+	record.addedNo = len(c.records) + 1
+	c.records = append(c.records, record)
+}
+
+func (c *Checker) fatalRecord(rule Rule, file string, message string, suggest string, fix *checkFix) {
+	c.putCheckRecord(checkRecord{rule: rule, fatal: true, file: file, message: message, suggest: suggest, fix: fix})
+}
+
+func (c *Checker) warnRecord(rule Rule, file string, message string, suggest string, fix *checkFix) {
+	c.putCheckRecord(checkRecord{rule: rule, fatal: false, file: file, message: message, suggest: suggest, fix: fix})
+}
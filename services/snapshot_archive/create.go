@@ -0,0 +1,231 @@
+package snapshot_archive
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type CreateOptions struct {
+	// SourceDir is the directory, which the included paths are relative to, usually the node home
+	SourceDir string
+	// IncludePaths are relative to the source dir, eg: data
+	IncludePaths []string
+	// ExcludePaths are relative to the source dir, supports glob pattern, eg: data/snapshots, data/*.db
+	ExcludePaths []string
+
+	Format  Format
+	Level   int
+	Threads int
+
+	// Progress is called periodically with the number of uncompressed bytes processed and the total, optional.
+	Progress func(processed, total int64)
+}
+
+type CreateResult struct {
+	Files            int
+	UncompressedSize int64
+	CompressedSize   int64
+	Sha256           string // checksum of the compressed output
+}
+
+// Create writes the compressed tar archive of the included paths into the output.
+// Entries are named `./<path>`, same as `tar -cf - ./data`.
+func Create(output io.Writer, opts CreateOptions) (*CreateResult, error) {
+	excludes := make([]string, len(opts.ExcludePaths))
+	for i, excludePath := range opts.ExcludePaths {
+		excludes[i] = normalizeRelativePath(excludePath)
+	}
+	isExcluded := func(relPath string) bool {
+		for _, exclude := range excludes {
+			if relPath == exclude || strings.HasPrefix(relPath, exclude+"/") {
+				return true
+			}
+			if matched, _ := filepath.Match(exclude, relPath); matched {
+				return true
+			}
+		}
+		return false
+	}
+
+	var total int64
+	if opts.Progress != nil {
+		var err error
+		total, err = calculateTotalSize(opts.SourceDir, opts.IncludePaths, isExcluded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CreateResult{}
+
+	hasher := sha256.New()
+	compressedCounter := &countingWriter{}
+	compressWriter, err := newCompressWriter(io.MultiWriter(output, hasher, compressedCounter), opts.Format, opts.Level, opts.Threads)
+	if err != nil {
+		return nil, err
+	}
+
+	uncompressedCounter := &countingWriter{
+		onWrite: func(n int64) {
+			if opts.Progress != nil {
+				opts.Progress(n, total)
+			}
+		},
+	}
+	tarWriter := tar.NewWriter(io.MultiWriter(compressWriter, uncompressedCounter))
+
+	for _, includePath := range opts.IncludePaths {
+		root := filepath.Join(opts.SourceDir, normalizeRelativePath(includePath))
+		err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(opts.SourceDir, filePath)
+			if err != nil {
+				return err
+			}
+			relPath = filepath.ToSlash(relPath)
+
+			if isExcluded(relPath) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if err := writeTarEntry(tarWriter, filePath, "./"+relPath, d); err != nil {
+				return errors.Wrapf(err, "failed to archive %s", filePath)
+			}
+			if !d.IsDir() {
+				result.Files++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to finalize tar")
+	}
+	if err := compressWriter.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to finalize compression")
+	}
+
+	result.UncompressedSize = uncompressedCounter.n
+	result.CompressedSize = compressedCounter.n
+	result.Sha256 = hex.EncodeToString(hasher.Sum(nil))
+	return result, nil
+}
+
+func writeTarEntry(tarWriter *tar.Writer, filePath, name string, d fs.DirEntry) error {
+	fi, err := d.Info()
+	if err != nil {
+		return err
+	}
+
+	var link string
+	if fi.Mode()&os.ModeSymlink != 0 {
+		if link, err = os.Readlink(filePath); err != nil {
+			return err
+		}
+	} else if !fi.Mode().IsRegular() && !fi.IsDir() {
+		return fmt.Errorf("unsupported file type %s", fi.Mode().Type())
+	}
+
+	header, err := tar.FileInfoHeader(fi, link)
+	if err != nil {
+		return err
+	}
+	header.Name = name
+	if fi.IsDir() {
+		header.Name += "/"
+	}
+	// ownership is not portable across machines
+	header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if !fi.Mode().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	// the file might grow while archiving, only write the size declared in the header
+	_, err = io.CopyN(tarWriter, file, header.Size)
+	return err
+}
+
+func calculateTotalSize(sourceDir string, includePaths []string, isExcluded func(string) bool) (int64, error) {
+	var total int64
+	for _, includePath := range includePaths {
+		root := filepath.Join(sourceDir, normalizeRelativePath(includePath))
+		err := filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			relPath, err := filepath.Rel(sourceDir, filePath)
+			if err != nil {
+				return err
+			}
+			if isExcluded(filepath.ToSlash(relPath)) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+
+			if d.Type().IsRegular() {
+				fi, err := d.Info()
+				if err != nil {
+					return err
+				}
+				total += fi.Size()
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to calculate total size")
+		}
+	}
+	return total, nil
+}
+
+// normalizeRelativePath converts `./data/snapshots/` into `data/snapshots`.
+func normalizeRelativePath(relPath string) string {
+	relPath = filepath.ToSlash(filepath.Clean(strings.TrimSpace(relPath)))
+	return strings.TrimPrefix(relPath, "./")
+}
+
+type countingWriter struct {
+	n       int64
+	onWrite func(n int64)
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	if w.onWrite != nil {
+		w.onWrite(w.n)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,116 @@
+package snapshot_archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/stretchr/testify/require"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreate(t *testing.T) {
+	sourceDir := t.TempDir()
+	files := map[string]string{
+		"data/application.db/000001.log":   "application",
+		"data/blockstore.db/000001.ldb":    "blockstore",
+		"data/priv_validator_state.json":   `{"height":"0","round":0,"step":0}`,
+		"data/snapshots/metadata.db/x.log": "excluded",
+		"data/tx_index.db/000001.log":      "excluded",
+		"data/cs.wal/wal":                  "excluded by glob",
+		"config/config.toml":               "not included",
+	}
+	for name, content := range files {
+		filePath := filepath.Join(sourceDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+	}
+
+	for _, format := range AllFormats {
+		t.Run(string(format), func(t *testing.T) {
+			var output bytes.Buffer
+			var lastProcessed, lastTotal int64
+			result, err := Create(&output, CreateOptions{
+				SourceDir:    sourceDir,
+				IncludePaths: []string{"./data"},
+				ExcludePaths: []string{"./data/snapshots", "data/tx_index.db/", "data/cs.*"},
+				Format:       format,
+				Level:        format.DefaultLevel(),
+				Threads:      2,
+				Progress: func(processed, total int64) {
+					lastProcessed, lastTotal = processed, total
+				},
+			})
+			require.NoError(t, err)
+			require.Equal(t, 3, result.Files)
+			require.Equal(t, int64(output.Len()), result.CompressedSize)
+			require.Equal(t, result.UncompressedSize, lastProcessed)
+			require.Equal(t, int64(len("application")+len("blockstore")+len(files["data/priv_validator_state.json"])), lastTotal)
+
+			checksum := sha256.Sum256(output.Bytes())
+			require.Equal(t, hex.EncodeToString(checksum[:]), result.Sha256)
+
+			detectedFormat, err := DetectFormat(output.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, format, detectedFormat)
+
+			decompressReader, err := NewDecompressReader(&output, format)
+			require.NoError(t, err)
+			defer func() {
+				_ = decompressReader.Close()
+			}()
+
+			extracted := make(map[string]string)
+			tarReader := tar.NewReader(decompressReader)
+			for {
+				header, err := tarReader.Next()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				if header.Typeflag != tar.TypeReg {
+					continue
+				}
+				bz, err := io.ReadAll(tarReader)
+				require.NoError(t, err)
+				extracted[header.Name] = string(bz)
+			}
+
+			require.Equal(t, map[string]string{
+				"./data/application.db/000001.log": "application",
+				"./data/blockstore.db/000001.ldb":  "blockstore",
+				"./data/priv_validator_state.json": files["data/priv_validator_state.json"],
+			}, extracted)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		want    Format
+		wantErr bool
+	}{
+		{format: "lz4", want: FormatLz4},
+		{format: "ZSTD", want: FormatZstd},
+		{format: "zst", want: FormatZstd},
+		{format: "gz", want: FormatGzip},
+		{format: " gzip ", want: FormatGzip},
+		{format: "xz", wantErr: true},
+		{format: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := ParseFormat(tt.format)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
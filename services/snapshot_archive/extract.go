@@ -0,0 +1,252 @@
+package snapshot_archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type ExtractOptions struct {
+	// DestDir is the directory, which the entries are extracted into, usually the node home
+	DestDir string
+	// IncludePaths are relative to the dest dir, eg: data. Entries outside are skipped. Empty means all.
+	IncludePaths []string
+
+	// Format is the compression format, detected by magic bytes if empty.
+	Format Format
+
+	// Progress is called periodically with the number of compressed bytes read and the total, optional.
+	Progress func(processed, total int64)
+	// TotalSize is the size of the compressed archive, passed to Progress.
+	TotalSize int64
+}
+
+type ExtractResult struct {
+	Format  Format
+	Files   int
+	Skipped []string // entries outside the included paths
+}
+
+// Extract reads the compressed tar archive and writes the entries into the dest dir.
+// Entries are accepted with or without the `./` prefix, eg: `./data/x` and `data/x`.
+// Entries escaping the dest dir, via absolute path, `..` or links, are rejected.
+func Extract(input io.Reader, opts ExtractOptions) (*ExtractResult, error) {
+	destDir, err := filepath.Abs(opts.DestDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get absolute path of dest dir")
+	}
+
+	includes := make([]string, len(opts.IncludePaths))
+	for i, includePath := range opts.IncludePaths {
+		includes[i] = normalizeRelativePath(includePath)
+	}
+	isIncluded := func(relPath string) bool {
+		if len(includes) == 0 {
+			return true
+		}
+		for _, include := range includes {
+			if relPath == include || strings.HasPrefix(relPath, include+"/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	compressedCounter := &countingReader{
+		r: input,
+		onRead: func(n int64) {
+			if opts.Progress != nil {
+				opts.Progress(n, opts.TotalSize)
+			}
+		},
+	}
+	bufferedInput := bufio.NewReaderSize(compressedCounter, 4*1024*1024)
+
+	format := opts.Format
+	if format == "" {
+		header, err := bufferedInput.Peek(4)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read archive header")
+		}
+		if format, err = DetectFormat(header); err != nil {
+			return nil, err
+		}
+	}
+
+	decompressReader, err := NewDecompressReader(bufferedInput, format)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = decompressReader.Close()
+	}()
+
+	result := &ExtractResult{
+		Format: format,
+	}
+
+	extractor := &tarExtractor{
+		destDir:         destDir,
+		createdSymlinks: make(map[string]struct{}),
+	}
+
+	tarReader := tar.NewReader(decompressReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read tar entry")
+		}
+
+		relPath, err := sanitizeEntryName(header.Name)
+		if err != nil {
+			return nil, err
+		}
+		if relPath == "" {
+			continue // the root `./` entry
+		}
+		if !isIncluded(relPath) {
+			result.Skipped = append(result.Skipped, relPath)
+			continue
+		}
+
+		if err := extractor.extract(tarReader, header, relPath); err != nil {
+			return nil, errors.Wrapf(err, "failed to extract %s", header.Name)
+		}
+		if header.Typeflag == tar.TypeReg {
+			result.Files++
+		}
+	}
+
+	return result, nil
+}
+
+// sanitizeEntryName converts `./data/x` into `data/x`, rejects names escaping the dest dir.
+func sanitizeEntryName(name string) (string, error) {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") {
+		return "", fmt.Errorf("illegal absolute path in archive: %s", name)
+	}
+	relPath := normalizeRelativePath(name)
+	if relPath == "." {
+		return "", nil
+	}
+	if relPath == ".." || strings.HasPrefix(relPath, "../") {
+		return "", fmt.Errorf("illegal path escaping destination in archive: %s", name)
+	}
+	return relPath, nil
+}
+
+type tarExtractor struct {
+	destDir string
+	// createdSymlinks are the symlinks extracted from the archive, writing through them is prohibited,
+	// while symlinks existing before, eg: data dir mounted from another disk, are trusted.
+	createdSymlinks map[string]struct{}
+}
+
+func (e *tarExtractor) extract(tarReader *tar.Reader, header *tar.Header, relPath string) error {
+	destDir := e.destDir
+	target := filepath.Join(destDir, filepath.FromSlash(relPath))
+	if err := e.ensureNotThroughCreatedSymlink(filepath.Dir(target)); err != nil {
+		return err
+	}
+
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, 0o755)
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		// replace instead of writing through an existing file, which might be a symlink
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_EXCL, header.FileInfo().Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(file, tarReader); err != nil {
+			_ = file.Close()
+			return err
+		}
+		return file.Close()
+	case tar.TypeSymlink:
+		linkTarget := header.Linkname
+		if !filepath.IsAbs(linkTarget) {
+			linkTarget = filepath.Join(filepath.Dir(target), linkTarget)
+		}
+		if !isWithinDir(destDir, linkTarget) {
+			return fmt.Errorf("illegal symlink target escaping destination: %s", header.Linkname)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		if err := os.Symlink(header.Linkname, target); err != nil {
+			return err
+		}
+		e.createdSymlinks[target] = struct{}{}
+		return nil
+	case tar.TypeLink:
+		linkRelPath, err := sanitizeEntryName(header.Linkname)
+		if err != nil || linkRelPath == "" {
+			return fmt.Errorf("illegal hard link target: %s", header.Linkname)
+		}
+		linkTarget := filepath.Join(destDir, filepath.FromSlash(linkRelPath))
+		if err := e.ensureNotThroughCreatedSymlink(linkTarget); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(target); err != nil {
+			return err
+		}
+		return os.Link(linkTarget, target)
+	default:
+		return fmt.Errorf("unsupported tar entry type %c", header.Typeflag)
+	}
+}
+
+// ensureNotThroughCreatedSymlink returns error if the path or any of its parents is a symlink extracted from the archive,
+// so an archive can not write outside the dest dir via its own symlink.
+func (e *tarExtractor) ensureNotThroughCreatedSymlink(fullPath string) error {
+	for current := fullPath; isWithinDir(e.destDir, current) && current != e.destDir; current = filepath.Dir(current) {
+		if _, found := e.createdSymlinks[current]; found {
+			return fmt.Errorf("illegal path via symlink: %s", current)
+		}
+	}
+	return nil
+}
+
+func isWithinDir(dir, target string) bool {
+	rel, err := filepath.Rel(dir, filepath.Clean(target))
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+type countingReader struct {
+	r      io.Reader
+	n      int64
+	onRead func(n int64)
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	if r.onRead != nil {
+		r.onRead(r.n)
+	}
+	return n, err
+}
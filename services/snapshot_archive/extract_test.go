@@ -0,0 +1,157 @@
+package snapshot_archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	sourceDir := t.TempDir()
+	files := map[string]string{
+		"data/application.db/000001.log": "application",
+		"data/blockstore.db/000001.ldb":  "blockstore",
+		"data/priv_validator_state.json": `{"height":"0","round":0,"step":0}`,
+	}
+	for name, content := range files {
+		filePath := filepath.Join(sourceDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+	}
+
+	for _, format := range AllFormats {
+		t.Run(string(format), func(t *testing.T) {
+			var archive bytes.Buffer
+			_, err := Create(&archive, CreateOptions{
+				SourceDir:    sourceDir,
+				IncludePaths: []string{"./data"},
+				Format:       format,
+				Level:        format.DefaultLevel(),
+				Threads:      1,
+			})
+			require.NoError(t, err)
+
+			destDir := t.TempDir()
+			totalSize := int64(archive.Len())
+			var lastProcessed int64
+			result, err := Extract(&archive, ExtractOptions{
+				DestDir:      destDir,
+				IncludePaths: []string{"data"},
+				Progress: func(processed, total int64) {
+					require.Equal(t, totalSize, total)
+					lastProcessed = processed
+				},
+				TotalSize: totalSize,
+			})
+			require.NoError(t, err)
+			require.Equal(t, format, result.Format)
+			require.Equal(t, len(files), result.Files)
+			require.Empty(t, result.Skipped)
+			require.Equal(t, totalSize, lastProcessed)
+
+			for name, content := range files {
+				bz, err := os.ReadFile(filepath.Join(destDir, name))
+				require.NoError(t, err)
+				require.Equal(t, content, string(bz))
+			}
+		})
+	}
+}
+
+func TestExtract_Entries(t *testing.T) {
+	tests := []struct {
+		name        string
+		entries     []tar.Header
+		wantErr     bool
+		wantSkipped []string
+		wantFiles   []string
+	}{
+		{
+			name: "accept entries without ./ prefix",
+			entries: []tar.Header{
+				{Name: "data/", Typeflag: tar.TypeDir, Mode: 0o755},
+				{Name: "data/a", Typeflag: tar.TypeReg, Mode: 0o644},
+			},
+			wantFiles: []string{"data/a"},
+		},
+		{
+			name: "skip entries outside included paths",
+			entries: []tar.Header{
+				{Name: "./data/a", Typeflag: tar.TypeReg, Mode: 0o644},
+				{Name: "./config/config.toml", Typeflag: tar.TypeReg, Mode: 0o644},
+			},
+			wantSkipped: []string{"config/config.toml"},
+			wantFiles:   []string{"data/a"},
+		},
+		{
+			name: "reject absolute path",
+			entries: []tar.Header{
+				{Name: "/etc/passwd", Typeflag: tar.TypeReg, Mode: 0o644},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reject path traversal",
+			entries: []tar.Header{
+				{Name: "./data/../../a", Typeflag: tar.TypeReg, Mode: 0o644},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reject symlink escaping destination",
+			entries: []tar.Header{
+				{Name: "./data/link", Typeflag: tar.TypeSymlink, Linkname: "../../outside"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "reject writing through extracted symlink",
+			entries: []tar.Header{
+				{Name: "./data/link", Typeflag: tar.TypeSymlink, Linkname: "."},
+				{Name: "./data/link/a", Typeflag: tar.TypeReg, Mode: 0o644},
+			},
+			wantErr: true,
+		},
+		{
+			name: "accept symlink inside destination",
+			entries: []tar.Header{
+				{Name: "./data/a", Typeflag: tar.TypeReg, Mode: 0o644},
+				{Name: "./data/link", Typeflag: tar.TypeSymlink, Linkname: "a"},
+			},
+			wantFiles: []string{"data/a", "data/link"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var archive bytes.Buffer
+			compressWriter, err := newCompressWriter(&archive, FormatGzip, FormatGzip.DefaultLevel(), 1)
+			require.NoError(t, err)
+			tarWriter := tar.NewWriter(compressWriter)
+			for _, entry := range tt.entries {
+				entry := entry
+				require.NoError(t, tarWriter.WriteHeader(&entry))
+			}
+			require.NoError(t, tarWriter.Close())
+			require.NoError(t, compressWriter.Close())
+
+			destDir := t.TempDir()
+			result, err := Extract(&archive, ExtractOptions{
+				DestDir:      destDir,
+				IncludePaths: []string{"./data"},
+			})
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.wantSkipped, result.Skipped)
+			for _, file := range tt.wantFiles {
+				_, err := os.Lstat(filepath.Join(destDir, file))
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package snapshot_archive
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/pkg/errors"
+	"io"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatLz4  Format = "lz4"
+	FormatZstd Format = "zstd"
+	FormatGzip Format = "gzip"
+)
+
+var AllFormats = []Format{FormatLz4, FormatZstd, FormatGzip}
+
+func ParseFormat(format string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(format))) {
+	case FormatLz4:
+		return FormatLz4, nil
+	case FormatZstd, "zst":
+		return FormatZstd, nil
+	case FormatGzip, "gz":
+		return FormatGzip, nil
+	default:
+		return "", fmt.Errorf("unsupported format %s, must be one of %v", format, AllFormats)
+	}
+}
+
+// FileExtension returns the extension of the archive file, including the tar part, eg: .tar.lz4
+func (f Format) FileExtension() string {
+	switch f {
+	case FormatLz4:
+		return ".tar.lz4"
+	case FormatZstd:
+		return ".tar.zst"
+	case FormatGzip:
+		return ".tar.gz"
+	default:
+		panic(fmt.Sprintf("unsupported format %s", f))
+	}
+}
+
+// FormatFromFileName returns the compression format by the extension of the archive file, eg: .tar.lz4, .tar.zst, .tgz.
+// isTar is true for any tar archive, including the uncompressed .tar which has no format.
+func FormatFromFileName(fileName string) (format Format, isTar bool) {
+	switch {
+	case strings.HasSuffix(fileName, FormatLz4.FileExtension()):
+		return FormatLz4, true
+	case strings.HasSuffix(fileName, FormatZstd.FileExtension()), strings.HasSuffix(fileName, ".tar.zstd"):
+		return FormatZstd, true
+	case strings.HasSuffix(fileName, FormatGzip.FileExtension()), strings.HasSuffix(fileName, ".tgz"):
+		return FormatGzip, true
+	case strings.HasSuffix(fileName, ".tar"):
+		return "", true
+	default:
+		return "", false
+	}
+}
+
+// DefaultLevel returns the default compression level of the format.
+func (f Format) DefaultLevel() int {
+	switch f {
+	case FormatLz4:
+		return 1
+	case FormatZstd:
+		return 3
+	case FormatGzip:
+		return 6
+	default:
+		panic(fmt.Sprintf("unsupported format %s", f))
+	}
+}
+
+// ValidateLevel returns error if the compression level is not supported by the format.
+func (f Format) ValidateLevel(level int) error {
+	var minLevel, maxLevel int
+	switch f {
+	case FormatLz4:
+		minLevel, maxLevel = 1, 9
+	case FormatZstd:
+		minLevel, maxLevel = 1, 22
+	case FormatGzip:
+		minLevel, maxLevel = 1, 9
+	default:
+		return fmt.Errorf("unsupported format %s", f)
+	}
+	if level < minLevel || level > maxLevel {
+		return fmt.Errorf("compression level of %s must be in range [%d, %d]", f, minLevel, maxLevel)
+	}
+	return nil
+}
+
+// newCompressWriter wraps the writer with the compressor of the format.
+// Closing the returned writer flushes the compressor, but does not close the underlying writer.
+func newCompressWriter(w io.Writer, format Format, level, threads int) (io.WriteCloser, error) {
+	if err := format.ValidateLevel(level); err != nil {
+		return nil, err
+	}
+	if threads < 1 {
+		threads = 1
+	}
+
+	switch format {
+	case FormatLz4:
+		lw := lz4.NewWriter(w)
+		lz4Level := lz4.Fast // level 1 is the fast mode, same as default of the lz4 CLI
+		if level > 1 {
+			lz4Level = lz4.CompressionLevel(1 << (8 + level))
+		}
+		if err := lw.Apply(lz4.CompressionLevelOption(lz4Level), lz4.ConcurrencyOption(threads)); err != nil {
+			return nil, errors.Wrap(err, "failed to setup lz4 compressor")
+		}
+		return lw, nil
+	case FormatZstd:
+		zw, err := zstd.NewWriter(
+			w,
+			zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)),
+			zstd.WithEncoderConcurrency(threads),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to setup zstd compressor")
+		}
+		return zw, nil
+	case FormatGzip:
+		gw, err := gzip.NewWriterLevel(w, level)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to setup gzip compressor")
+		}
+		return gw, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+}
+
+// NewDecompressReader wraps the reader with the decompressor of the format.
+func NewDecompressReader(r io.Reader, format Format) (io.ReadCloser, error) {
+	switch format {
+	case FormatLz4:
+		return io.NopCloser(lz4.NewReader(r)), nil
+	case FormatZstd:
+		zr, err := zstd.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to setup zstd decompressor")
+		}
+		return zr.IOReadCloser(), nil
+	case FormatGzip:
+		gr, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to setup gzip decompressor")
+		}
+		return gr, nil
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+}
+
+var (
+	magicLz4  = []byte{0x04, 0x22, 0x4d, 0x18}
+	magicZstd = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	magicGzip = []byte{0x1f, 0x8b}
+)
+
+// DetectFormat detects the compression format by the magic bytes at the beginning of the archive.
+func DetectFormat(header []byte) (Format, error) {
+	switch {
+	case bytes.HasPrefix(header, magicLz4):
+		return FormatLz4, nil
+	case bytes.HasPrefix(header, magicZstd):
+		return FormatZstd, nil
+	case bytes.HasPrefix(header, magicGzip):
+		return FormatGzip, nil
+	default:
+		return "", fmt.Errorf("unknown compression format, supported formats: %v", AllFormats)
+	}
+}
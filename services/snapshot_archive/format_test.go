@@ -0,0 +1,37 @@
+package snapshot_archive
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestFormatFromFileName(t *testing.T) {
+	tests := []struct {
+		fileName   string
+		wantFormat Format
+		wantIsTar  bool
+	}{
+		{fileName: "snapshot.tar.lz4", wantFormat: FormatLz4, wantIsTar: true},
+		{fileName: "snapshot.tar.zst", wantFormat: FormatZstd, wantIsTar: true},
+		{fileName: "snapshot.tar.zstd", wantFormat: FormatZstd, wantIsTar: true},
+		{fileName: "snapshot.tar.gz", wantFormat: FormatGzip, wantIsTar: true},
+		{fileName: "snapshot.tgz", wantFormat: FormatGzip, wantIsTar: true},
+		{fileName: "snapshot.tar", wantIsTar: true},
+		{fileName: "snapshot.tar.lz4.json"},
+		{fileName: "snapshot.tar.lz4.partial"},
+		{fileName: "snapshot.zip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fileName, func(t *testing.T) {
+			format, isTar := FormatFromFileName(tt.fileName)
+			require.Equal(t, tt.wantFormat, format)
+			require.Equal(t, tt.wantIsTar, isTar)
+		})
+	}
+
+	for _, format := range AllFormats {
+		gotFormat, isTar := FormatFromFileName("snapshot" + format.FileExtension())
+		require.True(t, isTar)
+		require.Equal(t, format, gotFormat)
+	}
+}
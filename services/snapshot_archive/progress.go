@@ -0,0 +1,109 @@
+package snapshot_archive
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ProgressPrinter prints a progress bar with ETA periodically.
+// Update is cheap, safe to be called on every write.
+type ProgressPrinter struct {
+	out       io.Writer
+	startedAt time.Time
+	processed atomic.Int64
+	total     atomic.Int64
+	stop      chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewProgressPrinter(out io.Writer) *ProgressPrinter {
+	p := &ProgressPrinter{
+		out:       out,
+		startedAt: time.Now(),
+		stop:      make(chan struct{}),
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				p.print()
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+
+	return p
+}
+
+func (p *ProgressPrinter) Update(processed, total int64) {
+	p.processed.Store(processed)
+	p.total.Store(total)
+}
+
+// Finish prints the final progress and stops printing.
+func (p *ProgressPrinter) Finish() {
+	close(p.stop)
+	p.wg.Wait()
+	p.print()
+	_, _ = fmt.Fprintln(p.out)
+}
+
+func (p *ProgressPrinter) print() {
+	processed := p.processed.Load()
+	total := p.total.Load()
+	elapsed := time.Since(p.startedAt)
+
+	var speed float64
+	if elapsed > 0 {
+		speed = float64(processed) / elapsed.Seconds()
+	}
+
+	const barWidth = 30
+	var percent float64
+	if total > 0 {
+		percent = float64(processed) / float64(total) * 100
+		if percent > 100 {
+			percent = 100
+		}
+	}
+	filled := int(percent / 100 * barWidth)
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled)
+
+	eta := "-"
+	if speed > 0 && total > processed {
+		eta = time.Duration(float64(total-processed) / speed * float64(time.Second)).Round(time.Second).String()
+	}
+
+	_, _ = fmt.Fprintf(
+		p.out,
+		"\r[%s] %5.1f%% %s/%s %s/s elapsed %s ETA %s    ",
+		bar, percent,
+		formatBytes(processed), formatBytes(total), formatBytes(int64(speed)),
+		elapsed.Round(time.Second), eta,
+	)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
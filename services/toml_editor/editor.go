@@ -0,0 +1,379 @@
+package toml_editor
+
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/constants"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Document is a TOML document which can be edited while keeping comments, ordering and formatting of other lines.
+// Only keys of regular tables can be edited, keys inside array of tables (`[[x]]`) and inline tables are not supported.
+type Document struct {
+	lines []string
+}
+
+var (
+	regexTableHeader      = regexp.MustCompile(`^\s*\[\s*([^\[\]]+?)\s*]\s*(#.*)?$`)
+	regexArrayTableHeader = regexp.MustCompile(`^\s*\[\[\s*([^\[\]]+?)\s*]]\s*(#.*)?$`)
+	regexKey              = regexp.MustCompile(`^\s*([A-Za-z0-9_-]+|"[^"]*")\s*=\s*`)
+)
+
+const arrayTablePrefix = "[["
+
+// entry is a key-value pair in the document.
+type entry struct {
+	section   string
+	key       string
+	startLine int
+	// valueCol is the column of the value in the start line
+	valueCol int
+	endLine  int
+	// endCol is the column right after the value in the end line, before trailing spaces and comment
+	endCol int
+}
+
+// Parse parses the TOML content, returns error if the content is not a valid TOML.
+func Parse(bz []byte) (*Document, error) {
+	var validate map[string]any
+	if err := toml.Unmarshal(bz, &validate); err != nil {
+		return nil, errors.Wrap(err, "invalid TOML")
+	}
+
+	doc := &Document{
+		lines: strings.Split(string(bz), "\n"),
+	}
+	if _, err := doc.entries(); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func Load(filePath string) (*Document, error) {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", filePath)
+	}
+	doc, err := Parse(bz)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %s", filePath)
+	}
+	return doc, nil
+}
+
+func (d *Document) Bytes() []byte {
+	return []byte(strings.Join(d.lines, "\n"))
+}
+
+// Has returns true if the key exists in the section, use empty section for top-level keys.
+func (d *Document) Has(section, key string) bool {
+	_, found := d.find(section, key)
+	return found
+}
+
+// Get returns the raw TOML value of the key in the section, eg: `"abc"`, `true`, `["a", "b"]`.
+func (d *Document) Get(section, key string) (string, bool) {
+	e, found := d.find(section, key)
+	if !found {
+		return "", false
+	}
+	if e.startLine == e.endLine {
+		return d.lines[e.startLine][e.valueCol:e.endCol], true
+	}
+
+	var sb strings.Builder
+	sb.WriteString(d.lines[e.startLine][e.valueCol:])
+	for l := e.startLine + 1; l < e.endLine; l++ {
+		sb.WriteString("\n")
+		sb.WriteString(d.lines[l])
+	}
+	sb.WriteString("\n")
+	sb.WriteString(d.lines[e.endLine][:e.endCol])
+	return sb.String(), true
+}
+
+// Set replaces the value of an existing key with the raw TOML value, trailing comment of the line is kept.
+func (d *Document) Set(section, key, rawValue string) error {
+	var validate map[string]any
+	if err := toml.Unmarshal([]byte("v = "+rawValue), &validate); err != nil {
+		return errors.Wrapf(err, "invalid TOML value %s", rawValue)
+	}
+
+	e, found := d.find(section, key)
+	if !found {
+		return fmt.Errorf("key %s not found", joinSectionKey(section, key))
+	}
+
+	newLine := d.lines[e.startLine][:e.valueCol] + rawValue + d.lines[e.endLine][e.endCol:]
+	lines := make([]string, 0, len(d.lines)-(e.endLine-e.startLine))
+	lines = append(lines, d.lines[:e.startLine]...)
+	lines = append(lines, newLine)
+	lines = append(lines, d.lines[e.endLine+1:]...)
+	d.lines = lines
+	return nil
+}
+
+func (d *Document) SetString(section, key, value string) error {
+	return d.Set(section, key, FormatString(value))
+}
+
+func (d *Document) SetBool(section, key string, value bool) error {
+	return d.Set(section, key, strconv.FormatBool(value))
+}
+
+func (d *Document) SetInt64(section, key string, value int64) error {
+	return d.Set(section, key, strconv.FormatInt(value, 10))
+}
+
+// IsString returns true if the existing value of the key is a string.
+func (d *Document) IsString(section, key string) bool {
+	rawValue, found := d.Get(section, key)
+	if !found {
+		return false
+	}
+	var decoded struct {
+		V any `toml:"v"`
+	}
+	if err := toml.Unmarshal([]byte("v = "+rawValue), &decoded); err != nil {
+		return false
+	}
+	_, isString := decoded.V.(string)
+	return isString
+}
+
+func (d *Document) find(section, key string) (entry, bool) {
+	entries, err := d.entries()
+	if err != nil {
+		return entry{}, false
+	}
+	for _, e := range entries {
+		if e.section == section && e.key == key {
+			return e, true
+		}
+	}
+	return entry{}, false
+}
+
+func (d *Document) entries() ([]entry, error) {
+	var entries []entry
+	var section string
+
+	for l := 0; l < len(d.lines); l++ {
+		line := d.lines[l]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if matches := regexArrayTableHeader.FindStringSubmatch(line); matches != nil {
+			section = arrayTablePrefix + normalizeSection(matches[1])
+			continue
+		}
+		if matches := regexTableHeader.FindStringSubmatch(line); matches != nil {
+			section = normalizeSection(matches[1])
+			continue
+		}
+
+		loc := regexKey.FindStringSubmatchIndex(line)
+		if loc == nil {
+			return nil, fmt.Errorf("unsupported TOML line %d: %s", l+1, line)
+		}
+		key := strings.Trim(line[loc[2]:loc[3]], `"`)
+
+		endLine, endCol, err := scanValue(d.lines, l, loc[1])
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry{
+			section:   section,
+			key:       key,
+			startLine: l,
+			valueCol:  loc[1],
+			endLine:   endLine,
+			endCol:    endCol,
+		})
+		l = endLine
+	}
+
+	return entries, nil
+}
+
+// scanValue finds the end of the value started at the line and column,
+// the value might span multiple lines: multi-line strings and arrays.
+func scanValue(lines []string, startLine, startCol int) (endLine, endCol int, err error) {
+	const (
+		noString = iota
+		basicString
+		literalString
+		multiLineBasicString
+		multiLineLiteralString
+	)
+
+	var depth int
+	inString := noString
+	endLine, endCol = startLine, startCol
+
+	for l := startLine; l < len(lines); l++ {
+		line := lines[l]
+		i := 0
+		if l == startLine {
+			i = startCol
+		}
+
+	scanLine:
+		for i < len(line) {
+			c := line[i]
+			next := i + 1
+
+			switch inString {
+			case basicString, multiLineBasicString:
+				if c == '\\' {
+					next = i + 2
+				} else if inString == basicString && c == '"' {
+					inString = noString
+				} else if inString == multiLineBasicString && strings.HasPrefix(line[i:], `"""`) {
+					next = i + 3
+					for next < len(line) && line[next] == '"' {
+						next++
+					}
+					inString = noString
+				}
+			case literalString, multiLineLiteralString:
+				if inString == literalString && c == '\'' {
+					inString = noString
+				} else if inString == multiLineLiteralString && strings.HasPrefix(line[i:], `'''`) {
+					next = i + 3
+					for next < len(line) && line[next] == '\'' {
+						next++
+					}
+					inString = noString
+				}
+			default:
+				switch {
+				case c == '#':
+					break scanLine
+				case strings.HasPrefix(line[i:], `"""`):
+					inString = multiLineBasicString
+					next = i + 3
+				case strings.HasPrefix(line[i:], `'''`):
+					inString = multiLineLiteralString
+					next = i + 3
+				case c == '"':
+					inString = basicString
+				case c == '\'':
+					inString = literalString
+				case c == '[' || c == '{':
+					depth++
+				case c == ']' || c == '}':
+					depth--
+				}
+			}
+
+			if next > len(line) {
+				next = len(line)
+			}
+			if c != ' ' && c != '\t' && c != '\r' {
+				endLine, endCol = l, next
+			}
+			i = next
+		}
+
+		if inString == basicString || inString == literalString {
+			return 0, 0, fmt.Errorf("unterminated string at line %d", l+1)
+		}
+		if inString == noString && depth == 0 {
+			return endLine, endCol, nil
+		}
+	}
+
+	return 0, 0, fmt.Errorf("unterminated value started at line %d", startLine+1)
+}
+
+func normalizeSection(section string) string {
+	parts := strings.Split(section, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"`)
+	}
+	return strings.Join(parts, ".")
+}
+
+// SplitSectionKey splits `statesync.enable` into section `statesync` and key `enable`, top-level key has no section.
+func SplitSectionKey(sectionKey string) (section, key string) {
+	sectionKey = strings.TrimSpace(sectionKey)
+	if i := strings.LastIndex(sectionKey, "."); i >= 0 {
+		return sectionKey[:i], sectionKey[i+1:]
+	}
+	return "", sectionKey
+}
+
+func joinSectionKey(section, key string) string {
+	if section == "" {
+		return key
+	}
+	return section + "." + key
+}
+
+// FormatString formats the string as a TOML basic string.
+func FormatString(value string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range value {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\u%04X`, r))
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
+
+// SaveWithBackup writes the document into the file, after copying the original file into a timestamped backup.
+// Returns the path of the backup file.
+func (d *Document) SaveWithBackup(filePath string) (string, error) {
+	bz := d.Bytes()
+	if _, err := Parse(bz); err != nil {
+		return "", errors.Wrap(err, "edited document is invalid")
+	}
+
+	fi, err := os.Stat(filePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to check %s", filePath)
+	}
+	original, err := os.ReadFile(filePath)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read %s", filePath)
+	}
+
+	dir, fileName := filepath.Split(filePath)
+	backupFilePath := filepath.Join(dir, fmt.Sprintf("%s.%s.%s.bak", fileName, utils.GetDateTimeStringCompatibleWithFileName(time.Now().UTC(), time.DateTime), constants.BINARY_NAME))
+	if err := os.WriteFile(backupFilePath, original, fi.Mode().Perm()); err != nil {
+		return "", errors.Wrap(err, "failed to write backup file")
+	}
+
+	if err := os.WriteFile(filePath, bz, fi.Mode().Perm()); err != nil {
+		return backupFilePath, errors.Wrapf(err, "failed to write %s", filePath)
+	}
+	return backupFilePath, nil
+}
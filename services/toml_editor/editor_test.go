@@ -0,0 +1,136 @@
+package toml_editor
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleConfigToml = `# This is a TOML config file.
+proxy_app = "tcp://127.0.0.1:26658"
+moniker = "node" # inline comment
+
+[rpc]
+laddr = "tcp://127.0.0.1:26657"
+cors_allowed_origins = [
+  "https://a.com", # first
+  "https://b.com",
+]
+
+[p2p]
+# Comma separated list of seed nodes to connect to
+seeds = ""
+persistent_peers = ""
+
+#######################################################
+###         State Sync Configuration Options        ###
+#######################################################
+[statesync]
+# State sync rapidly bootstraps a new node
+enable = false
+
+rpc_servers = ""
+trust_height = 0
+trust_hash = ""
+description = """
+multi-line [ not an array
+"""
+
+[instrumentation]
+enable = false # prometheus
+`
+
+func TestDocument_Set(t *testing.T) {
+	doc, err := Parse([]byte(sampleConfigToml))
+	require.NoError(t, err)
+
+	require.NoError(t, doc.SetBool("statesync", "enable", true))
+	require.NoError(t, doc.SetString("statesync", "rpc_servers", "https://rpc1:443,https://rpc2:443"))
+	require.NoError(t, doc.SetInt64("statesync", "trust_height", 1000))
+	require.NoError(t, doc.SetString("statesync", "trust_hash", "ABCD"))
+	require.NoError(t, doc.SetString("p2p", "seeds", `id@1.1.1.1:26656`))
+	require.NoError(t, doc.SetString("", "moniker", `my "node"`))
+	require.NoError(t, doc.Set("rpc", "cors_allowed_origins", `["*"]`))
+
+	want := strings.NewReplacer(
+		"[statesync]\n# State sync rapidly bootstraps a new node\nenable = false", "[statesync]\n# State sync rapidly bootstraps a new node\nenable = true",
+		`rpc_servers = ""`, `rpc_servers = "https://rpc1:443,https://rpc2:443"`,
+		`trust_height = 0`, `trust_height = 1000`,
+		`trust_hash = ""`, `trust_hash = "ABCD"`,
+		`seeds = ""`, `seeds = "id@1.1.1.1:26656"`,
+		`moniker = "node" # inline comment`, `moniker = "my \"node\"" # inline comment`,
+		"cors_allowed_origins = [\n  \"https://a.com\", # first\n  \"https://b.com\",\n]", `cors_allowed_origins = ["*"]`,
+	).Replace(sampleConfigToml)
+	require.Equal(t, want, string(doc.Bytes()))
+
+	// other section with the same key is not touched
+	value, found := doc.Get("instrumentation", "enable")
+	require.True(t, found)
+	require.Equal(t, "false", value)
+
+	_, err = Parse(doc.Bytes())
+	require.NoError(t, err)
+}
+
+func TestDocument_Get(t *testing.T) {
+	doc, err := Parse([]byte(sampleConfigToml))
+	require.NoError(t, err)
+
+	tests := []struct {
+		sectionKey string
+		want       string
+		wantFound  bool
+		wantString bool
+	}{
+		{sectionKey: "moniker", want: `"node"`, wantFound: true, wantString: true},
+		{sectionKey: "statesync.enable", want: "false", wantFound: true},
+		{sectionKey: "statesync.description", want: "\"\"\"\nmulti-line [ not an array\n\"\"\"", wantFound: true, wantString: true},
+		{sectionKey: "rpc.cors_allowed_origins", want: "[\n  \"https://a.com\", # first\n  \"https://b.com\",\n]", wantFound: true},
+		{sectionKey: "p2p.enable"},
+		{sectionKey: "statesync.not_exists"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sectionKey, func(t *testing.T) {
+			section, key := SplitSectionKey(tt.sectionKey)
+			got, found := doc.Get(section, key)
+			require.Equal(t, tt.wantFound, found)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantString, doc.IsString(section, key))
+		})
+	}
+}
+
+func TestDocument_SetInvalid(t *testing.T) {
+	doc, err := Parse([]byte(sampleConfigToml))
+	require.NoError(t, err)
+
+	require.Error(t, doc.Set("statesync", "not_exists", "1"))
+	require.Error(t, doc.Set("statesync", "enable", "yes"))
+	require.Equal(t, sampleConfigToml, string(doc.Bytes()))
+}
+
+func TestDocument_SaveWithBackup(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(filePath, []byte(sampleConfigToml), 0o600))
+
+	doc, err := Load(filePath)
+	require.NoError(t, err)
+	require.NoError(t, doc.SetBool("statesync", "enable", true))
+
+	backupFilePath, err := doc.SaveWithBackup(filePath)
+	require.NoError(t, err)
+
+	bz, err := os.ReadFile(backupFilePath)
+	require.NoError(t, err)
+	require.Equal(t, sampleConfigToml, string(bz))
+
+	bz, err = os.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, string(doc.Bytes()), string(bz))
+
+	fi, err := os.Stat(filePath)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+}
@@ -0,0 +1,82 @@
+package web_server
+
+import (
+	"context"
+	"github.com/bcdevtools/node-management/constants"
+	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// chainState holds the configuration and the caches of a chain served by the Web service.
+type chainState struct {
+	cfg   webtypes.ChainConfig
+	debug bool
+
+	cacheAddrBook        *types.TimeBasedCache
+	cacheNodePeers       *types.TimeBasedCache
+	cacheSnapshotData    *types.TimeBasedCache
+	cacheSnapshotCatalog *types.TimeBasedCache
+	cacheNodeStatus      *types.TimeBasedCache
+}
+
+func newChainState(cfg webtypes.ChainConfig, debug bool) *chainState {
+	return &chainState{
+		cfg:   cfg,
+		debug: debug,
+
+		cacheAddrBook:        types.NewTimeBasedCache(60 * time.Second),
+		cacheNodePeers:       types.NewTimeBasedCache(60 * time.Second),
+		cacheSnapshotData:    types.NewTimeBasedCache(60 * time.Second),
+		cacheSnapshotCatalog: types.NewTimeBasedCache(60 * time.Second),
+		cacheNodeStatus:      types.NewTimeBasedCache(6 * time.Second),
+	}
+}
+
+// chainRoute is the chain resolved for a request.
+type chainRoute struct {
+	chain *chainState
+	// basePath is the path prefix of the chain, eg: /cosmoshub, empty when routed by Host header.
+	basePath string
+}
+
+type chainRouteCtxKey struct{}
+
+type configCtxKey struct{}
+
+func withConfig(ctx context.Context, cfg webtypes.Config) context.Context {
+	return context.WithValue(ctx, configCtxKey{}, cfg)
+}
+
+func configFromContext(ctx context.Context) webtypes.Config {
+	return ctx.Value(configCtxKey{}).(webtypes.Config)
+}
+
+func withChainRoute(ctx context.Context, route *chainRoute) context.Context {
+	return context.WithValue(ctx, chainRouteCtxKey{}, route)
+}
+
+func chainRouteFromContext(ctx context.Context) *chainRoute {
+	route, _ := ctx.Value(chainRouteCtxKey{}).(*chainRoute)
+	return route
+}
+
+// getChainRoute returns the chain resolved for the request, or nil if the request is not bound to any chain.
+func getChainRoute(c *gin.Context) *chainRoute {
+	route, found := c.Get(constants.GinChainRoute)
+	if !found {
+		return nil
+	}
+	return route.(*chainRoute)
+}
+
+// requireChain aborts the request with 404 if the request is not bound to any chain.
+func requireChain(c *gin.Context) {
+	if getChainRoute(c) == nil {
+		c.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+	c.Next()
+}
@@ -1,7 +1,6 @@
 package web_server
 
 import (
-	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
 	"github.com/gin-gonic/gin"
@@ -10,12 +9,10 @@ import (
 	"time"
 )
 
-var cacheAddrBook *types.TimeBasedCache
-
 func HandleDownloadAddrBook(c *gin.Context) {
 	w := wrapGin(c)
 
-	addrBook, err := getAddrbook(w.Config())
+	addrBook, err := getAddrbook(getChainRoute(c).chain)
 	if err != nil {
 		utils.PrintlnStdErr("ERR: failed to get addrbook.json:", err)
 		w.PrepareDefaultErrorResponse().
@@ -35,20 +32,20 @@ func HandleDownloadAddrBook(c *gin.Context) {
 	c.JSON(http.StatusOK, addrBook)
 }
 
-func getAddrbook(cfg webtypes.Config) (*types.AddrBook, error) {
-	if addrBook := cacheAddrBook.GetRL(); addrBook != nil {
+func getAddrbook(chain *chainState) (*types.AddrBook, error) {
+	if addrBook := chain.cacheAddrBook.GetRL(); addrBook != nil {
 		return addrBook.(*types.AddrBook), nil
 	}
 
-	addrBook, err := cacheAddrBook.UpdateWL(func() (any, error) {
+	addrBook, err := chain.cacheAddrBook.UpdateWL(func() (any, error) {
 		addrBook := &types.AddrBook{}
-		if err := addrBook.ReadAddrBook(cfg.GetAddrBookFilePath()); err != nil {
+		if err := addrBook.ReadAddrBook(chain.cfg.GetAddrBookFilePath()); err != nil {
 			return nil, errors.Wrap(err, "failed to read addrbook")
 		}
 
 		livePeers := addrBook.GetLivePeers(48*time.Hour, false)
 
-		if len(livePeers) == 0 && cfg.Debug {
+		if len(livePeers) == 0 && chain.debug {
 			// load random, include dead peers, on debug mode
 			livePeers = addrBook.Addrs
 			if len(livePeers) > 10 {
@@ -67,7 +64,3 @@ func getAddrbook(cfg webtypes.Config) (*types.AddrBook, error) {
 
 	return addrBook.(*types.AddrBook), nil
 }
-
-func init() {
-	cacheAddrBook = types.NewTimeBasedCache(60 * time.Second)
-}
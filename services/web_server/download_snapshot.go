@@ -0,0 +1,196 @@
+package web_server
+
+import (
+	"context"
+	"fmt"
+	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/time/rate"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// builtInSnapshotPathPrefix is the path, relative to the chain base path, snapshot files are served at, when built-in serving is enabled.
+const builtInSnapshotPathPrefix = "/snapshots/"
+
+// newDownloadSnapshotHandler returns the handler serving snapshot files in the catalog,
+// supports Range/If-Range requests so multi-connection downloads and resumes work.
+func newDownloadSnapshotHandler(server *webServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		chain := getChainRoute(c).chain
+		if !chain.cfg.SnapshotServe {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		fileName := c.Param("file")
+		catalog, err := getSnapshotCatalog(chain)
+		if err != nil {
+			utils.PrintlnStdErr("ERR: failed to get snapshot catalog:", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		idx := slices.IndexFunc(catalog, func(entry webtypes.SnapshotCatalogEntry) bool {
+			return entry.FileName == fileName
+		})
+		if idx < 0 {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		filePath := chain.cfg.SnapshotFilePath
+		if filepath.Base(filePath) != fileName {
+			filePath = filepath.Join(chain.cfg.SnapshotDirPath, fileName)
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+			utils.PrintlnStdErr("ERR: failed to open snapshot file:", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+
+		fi, err := file.Stat()
+		if err != nil {
+			utils.PrintlnStdErr("ERR: failed to get snapshot file info:", err)
+			c.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+
+		// strong ETag, changed when the file is re-created, used by http.ServeContent to evaluate If-Range
+		c.Header("ETag", fmt.Sprintf(`"%x-%x"`, fi.Size(), fi.ModTime().UnixNano()))
+		c.Header("Content-Type", "application/octet-stream")
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+
+		var content io.ReadSeeker = file
+		if limiter, release := server.bandwidthLimiter().acquire(c.ClientIP()); limiter != nil {
+			defer release()
+			content = &throttledReadSeeker{
+				ReadSeeker: file,
+				ctx:        c.Request.Context(),
+				limiter:    limiter,
+			}
+		}
+
+		http.ServeContent(c.Writer, c.Request, fileName, fi.ModTime(), content)
+	}
+}
+
+// builtInSnapshotDownloadURL returns the download URL of the snapshot file, relative to the chain base path.
+func builtInSnapshotDownloadURL(fileName string) string {
+	return builtInSnapshotPathPrefix + url.PathEscape(fileName)
+}
+
+// absoluteDownloadURL converts the download URL of built-in served snapshot into absolute URL, other URLs are kept as is.
+func absoluteDownloadURL(c *gin.Context, downloadURL string) string {
+	if !strings.HasPrefix(downloadURL, builtInSnapshotPathPrefix) {
+		return downloadURL
+	}
+
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	// only trusted reverse proxies can tell the scheme used by the client
+	if proto := strings.ToLower(c.GetHeader("X-Forwarded-Proto")); proto == "http" || proto == "https" {
+		if configFromContext(c.Request.Context()).IsTrustedProxy(c.RemoteIP()) {
+			scheme = proto
+		}
+	}
+
+	return fmt.Sprintf("%s://%s%s%s", scheme, c.Request.Host, getChainRoute(c).basePath, downloadURL)
+}
+
+// bandwidthLimiter limits the download bandwidth per client IP, shared across all connections of the client.
+type bandwidthLimiter struct {
+	sync.Mutex
+	bytesPerSecond int
+	clients        map[string]*clientBandwidth
+}
+
+type clientBandwidth struct {
+	limiter  *rate.Limiter
+	active   int // number of downloads in progress
+	lastUsed time.Time
+}
+
+func newBandwidthLimiter(bytesPerSecond int) *bandwidthLimiter {
+	return &bandwidthLimiter{
+		bytesPerSecond: bytesPerSecond,
+		clients:        make(map[string]*clientBandwidth),
+	}
+}
+
+// acquire returns the limiter of the client, nil if bandwidth is unlimited.
+// The release function must be called when the download completed.
+func (l *bandwidthLimiter) acquire(clientIP string) (limiter *rate.Limiter, release func()) {
+	if l.bytesPerSecond < 1 {
+		return nil, func() {}
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	now := time.Now()
+
+	// prune idle clients
+	for ip, client := range l.clients {
+		if client.active < 1 && now.Sub(client.lastUsed) > 10*time.Minute {
+			delete(l.clients, ip)
+		}
+	}
+
+	client, found := l.clients[clientIP]
+	if !found {
+		client = &clientBandwidth{
+			limiter: rate.NewLimiter(rate.Limit(l.bytesPerSecond), l.bytesPerSecond),
+		}
+		l.clients[clientIP] = client
+	}
+	client.active++
+	client.lastUsed = now
+
+	return client.limiter, func() {
+		l.Lock()
+		defer l.Unlock()
+		client.active--
+		client.lastUsed = time.Now()
+	}
+}
+
+// throttledReadSeeker waits for the limiter before returning the data read.
+type throttledReadSeeker struct {
+	io.ReadSeeker
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func (t *throttledReadSeeker) Read(p []byte) (int, error) {
+	if burst := t.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+
+	n, err := t.ReadSeeker.Read(p)
+	if n > 0 {
+		if errWait := t.limiter.WaitN(t.ctx, n); errWait != nil {
+			return n, errWait
+		}
+	}
+
+	return n, err
+}
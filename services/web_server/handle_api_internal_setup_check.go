@@ -0,0 +1,58 @@
+package web_server
+
+import (
+	"fmt"
+	setupcheck "github.com/bcdevtools/node-management/services/setup_check"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"path"
+	"strings"
+)
+
+type setupCheckRequest struct {
+	Type string `form:"type" binding:"required"`
+}
+
+// HandleApiInternalSetupCheck checks setup of the node home of the chain, using the policy file in the node home if any.
+// The service file is not checked and no fix is applied.
+func HandleApiInternalSetupCheck(c *gin.Context) {
+	w := wrapGin(c)
+
+	var req setupCheckRequest
+	if err := w.Binder().Query(&req).Error(); err != nil {
+		w.PrepareResponseBadBinding(err).SendResponse()
+		return
+	}
+
+	nodeType := types.NodeTypeFromString(req.Type)
+	if nodeType == types.UnspecifiedNodeType {
+		w.PrepareDefaultErrorResponse().
+			WithHttpStatusCode(http.StatusBadRequest).
+			WithResult(fmt.Sprintf("invalid node type, can be either %s", strings.Join(types.AllNodeTypeNames(), "/"))).
+			SendResponse()
+		return
+	}
+
+	home := getChainRoute(c).chain.cfg.NodeHome
+	policy, _, err := setupcheck.LoadPolicy(setupcheck.DefaultPolicyFilePath(home), false, path.Join(home, "config", "genesis.json"))
+	if err != nil {
+		utils.PrintlnStdErr("ERR: failed to load setup-check policy:", err)
+		w.PrepareDefaultErrorResponse().WithResult("failed to load setup-check policy").SendResponse()
+		return
+	}
+
+	checker, err := setupcheck.NewChecker(setupcheck.Options{
+		Home:     home,
+		NodeType: nodeType,
+		Policy:   &policy,
+	})
+	if err != nil {
+		utils.PrintlnStdErr("ERR: failed to create setup checker:", err)
+		w.PrepareDefaultErrorResponse().SendResponse()
+		return
+	}
+
+	w.PrepareDefaultSuccessResponse(checker.Run()).SendResponse()
+}
@@ -2,7 +2,6 @@ package web_server
 
 import (
 	"fmt"
-	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
 	"github.com/bcdevtools/node-management/types"
 	"github.com/bcdevtools/node-management/utils"
 	"github.com/gin-gonic/gin"
@@ -11,12 +10,10 @@ import (
 	"time"
 )
 
-var cacheNodePeers *types.TimeBasedCache
-
 func HandleApiNodeLivePeers(c *gin.Context) {
 	w := wrapGin(c)
 
-	peers, err := getLivePeers(w.Config())
+	peers, err := getLivePeers(getChainRoute(c).chain)
 	if err != nil {
 		utils.PrintlnStdErr("ERR: failed to get live peers:", err)
 		w.PrepareDefaultErrorResponse().WithResult("failed to get live peers").SendResponse()
@@ -26,20 +23,20 @@ func HandleApiNodeLivePeers(c *gin.Context) {
 	w.PrepareDefaultSuccessResponse(peers).SendResponse()
 }
 
-func getLivePeers(cfg webtypes.Config) ([]string, error) {
-	if peers := cacheNodePeers.GetRL(); peers != nil {
+func getLivePeers(chain *chainState) ([]string, error) {
+	if peers := chain.cacheNodePeers.GetRL(); peers != nil {
 		return peers.([]string), nil
 	}
 
-	peers, err := cacheNodePeers.UpdateWL(func() (any, error) {
+	peers, err := chain.cacheNodePeers.UpdateWL(func() (any, error) {
 		addrBook := &types.AddrBook{}
-		if err := addrBook.ReadAddrBook(cfg.GetAddrBookFilePath()); err != nil {
+		if err := addrBook.ReadAddrBook(chain.cfg.GetAddrBookFilePath()); err != nil {
 			return nil, errors.Wrap(err, "failed to read addrbook")
 		}
 
 		livePeers := addrBook.GetLivePeers(1*time.Hour, true)
 
-		if len(livePeers) == 0 && cfg.Debug {
+		if len(livePeers) == 0 && chain.debug {
 			// load random, include dead peers, on debug mode
 			livePeers = addrBook.Addrs
 			if len(livePeers) > 10 {
@@ -75,7 +72,3 @@ func getLivePeers(cfg webtypes.Config) ([]string, error) {
 
 	return peers.([]string), nil
 }
-
-func init() {
-	cacheNodePeers = types.NewTimeBasedCache(60 * time.Second)
-}
@@ -0,0 +1,74 @@
+package web_server
+
+import (
+	"context"
+	"github.com/bcdevtools/node-management/services/rpc_client"
+	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/http"
+	"time"
+)
+
+func HandleApiNodeStatus(c *gin.Context) {
+	w := wrapGin(c)
+
+	status, err := getNodeStatus(getChainRoute(c).chain)
+	if err != nil {
+		utils.PrintlnStdErr("ERR: failed to get node status:", err)
+		w.PrepareDefaultErrorResponse().
+			WithHttpStatusCode(http.StatusServiceUnavailable).
+			WithResult("failed to get node status").
+			SendResponse()
+		return
+	}
+
+	w.PrepareDefaultSuccessResponse(status).SendResponse()
+}
+
+func getNodeStatus(chain *chainState) (*webtypes.NodeStatus, error) {
+	if status := chain.cacheNodeStatus.GetRL(); status != nil {
+		return status.(*webtypes.NodeStatus), nil
+	}
+
+	status, err := chain.cacheNodeStatus.UpdateWL(func() (any, error) {
+		rpc, err := types.ReadNodeRpcFromConfigToml(chain.cfg.GetConfigTomlFilePath())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read node rpc from config.toml")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		rpcClient := rpc_client.NewRpcClient(rpc, 5*time.Second)
+
+		resultStatus, err := rpcClient.Status(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query node status")
+		}
+
+		resultAbciInfo, err := rpcClient.AbciInfo(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to query node abci info")
+		}
+
+		return &webtypes.NodeStatus{
+			LatestHeight:    int64(resultStatus.SyncInfo.LatestBlockHeight),
+			LatestBlockTime: resultStatus.SyncInfo.LatestBlockTime,
+			CatchingUp:      resultStatus.SyncInfo.CatchingUp,
+			Moniker:         resultStatus.NodeInfo.Moniker,
+			Network:         resultStatus.NodeInfo.Network,
+			NodeVersion:     resultStatus.NodeInfo.Version,
+			AppName:         resultAbciInfo.Response.Data,
+			AppVersion:      resultAbciInfo.Response.Version,
+		}, nil
+	}, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return status.(*webtypes.NodeStatus), nil
+}
@@ -0,0 +1,183 @@
+package web_server
+
+import (
+	"github.com/bcdevtools/node-management/services/snapshot_archive"
+	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
+	"github.com/bcdevtools/node-management/types"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+func HandleApiSnapshots(c *gin.Context) {
+	w := wrapGin(c)
+
+	catalog, err := getSnapshotCatalog(getChainRoute(c).chain)
+	if err != nil {
+		utils.PrintlnStdErr("ERR: failed to get snapshot catalog:", err)
+		w.PrepareDefaultErrorResponse().WithResult("failed to get snapshot catalog").SendResponse()
+		return
+	}
+
+	w.PrepareDefaultSuccessResponse(withAbsoluteDownloadURLs(c, catalog)).SendResponse()
+}
+
+// getSnapshotCatalog returns the snapshots available for download, newest first.
+// The catalog includes the snapshot files in the snapshot directory and the single snapshot file, if configured.
+func getSnapshotCatalog(chain *chainState) ([]webtypes.SnapshotCatalogEntry, error) {
+	if catalog := chain.cacheSnapshotCatalog.GetRL(); catalog != nil {
+		return catalog.([]webtypes.SnapshotCatalogEntry), nil
+	}
+
+	catalog, err := chain.cacheSnapshotCatalog.UpdateWL(func() (any, error) {
+		cfg := chain.cfg
+		catalog := make([]webtypes.SnapshotCatalogEntry, 0)
+
+		if cfg.SnapshotDirPath != "" {
+			dirEntries, err := os.ReadDir(cfg.SnapshotDirPath)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read snapshot directory")
+			}
+
+			for _, dirEntry := range dirEntries {
+				if !dirEntry.Type().IsRegular() || snapshotCompressionFromFileName(dirEntry.Name()) == "" {
+					continue
+				}
+				if strings.HasSuffix(dirEntry.Name(), types.SnapshotPartialFileSuffix) {
+					// being written, or left over by a crash
+					continue
+				}
+
+				fi, err := dirEntry.Info()
+				if err != nil {
+					continue // file removed while scanning
+				}
+
+				var downloadURL string
+				if cfg.SnapshotServe {
+					downloadURL = builtInSnapshotDownloadURL(dirEntry.Name())
+				} else {
+					downloadURL = cfg.SnapshotDownloadBaseURL + "/" + url.PathEscape(dirEntry.Name())
+				}
+
+				catalog = append(catalog, newSnapshotCatalogEntry(
+					chain,
+					filepath.Join(cfg.SnapshotDirPath, dirEntry.Name()),
+					fi,
+					downloadURL,
+				))
+			}
+		}
+
+		if cfg.SnapshotFilePath != "" {
+			alreadyIncluded := slices.ContainsFunc(catalog, func(entry webtypes.SnapshotCatalogEntry) bool {
+				return filepath.Join(cfg.SnapshotDirPath, entry.FileName) == filepath.Clean(cfg.SnapshotFilePath)
+			})
+			if !alreadyIncluded {
+				if fi, err := os.Stat(cfg.SnapshotFilePath); err == nil && fi.Mode().IsRegular() {
+					downloadURL := cfg.SnapshotDownloadURL
+					if cfg.SnapshotServe {
+						downloadURL = builtInSnapshotDownloadURL(fi.Name())
+					}
+
+					catalog = append(catalog, newSnapshotCatalogEntry(chain, cfg.SnapshotFilePath, fi, downloadURL))
+				}
+			}
+		}
+
+		slices.SortFunc(catalog, func(left, right webtypes.SnapshotCatalogEntry) int {
+			return right.CreatedAt.Compare(left.CreatedAt)
+		})
+
+		return catalog, nil
+	}, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog.([]webtypes.SnapshotCatalogEntry), nil
+}
+
+// newSnapshotCatalogEntry builds the catalog entry of the snapshot file,
+// using the sidecar manifest if any, otherwise information are guessed from the file.
+func newSnapshotCatalogEntry(chain *chainState, filePath string, fi os.FileInfo, downloadURL string) webtypes.SnapshotCatalogEntry {
+	entry := webtypes.SnapshotCatalogEntry{
+		FileName:    fi.Name(),
+		Kind:        snapshotKindFromFileName(fi.Name()),
+		ChainID:     chain.cfg.ChainID,
+		Compression: snapshotCompressionFromFileName(fi.Name()),
+		SizeBytes:   fi.Size(),
+		CreatedAt:   fi.ModTime().UTC(),
+		DownloadURL: downloadURL,
+	}
+
+	manifestFilePath := types.GetSnapshotManifestFilePath(filePath)
+	if _, err := os.Stat(manifestFilePath); err == nil {
+		manifest := &types.SnapshotManifest{}
+		if err := manifest.LoadFromJSONFile(manifestFilePath); err != nil {
+			if chain.debug {
+				utils.PrintlnStdErr("ERR: failed to load snapshot manifest:", err)
+			}
+		} else {
+			if manifest.ChainID != "" {
+				entry.ChainID = manifest.ChainID
+			}
+			if manifest.Kind != "" {
+				entry.Kind = manifest.Kind
+			}
+			if manifest.Compression != "" {
+				entry.Compression = manifest.Compression
+			}
+			if !manifest.CreatedAt.IsZero() {
+				entry.CreatedAt = manifest.CreatedAt.UTC()
+			}
+			entry.Height = manifest.Height
+			entry.Sha256 = manifest.Sha256
+			entry.UncompressedSizeBytes = manifest.UncompressedSize
+		}
+	}
+
+	entry.Size = formatFileSize(entry.SizeBytes)
+	entry.Age = formatAge(time.Since(entry.CreatedAt))
+
+	return entry
+}
+
+// withAbsoluteDownloadURLs returns a copy of the catalog with download URLs of built-in served snapshots converted into absolute URLs.
+func withAbsoluteDownloadURLs(c *gin.Context, catalog []webtypes.SnapshotCatalogEntry) []webtypes.SnapshotCatalogEntry {
+	result := make([]webtypes.SnapshotCatalogEntry, len(catalog))
+	for i, entry := range catalog {
+		entry.DownloadURL = absoluteDownloadURL(c, entry.DownloadURL)
+		result[i] = entry
+	}
+	return result
+}
+
+// snapshotCompressionFromFileName returns the compression of the snapshot file, "none" for uncompressed tar, empty if not a snapshot.
+func snapshotCompressionFromFileName(fileName string) string {
+	format, isTar := snapshot_archive.FormatFromFileName(fileName)
+	if !isTar {
+		return ""
+	}
+	if format == "" {
+		return "none"
+	}
+	return string(format)
+}
+
+func snapshotKindFromFileName(fileName string) string {
+	fileName = strings.ToLower(fileName)
+	for _, kind := range []string{"archive", "pruned", "default"} {
+		if strings.Contains(fileName, kind) {
+			return kind
+		}
+	}
+	return ""
+}
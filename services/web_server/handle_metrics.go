@@ -0,0 +1,190 @@
+package web_server
+
+import (
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const metricsNamespace = "nmngd"
+
+var _ prometheus.Collector = &metricsCollector{}
+
+// metricsCollector collects the node box metrics on every scrape,
+// re-using the same caches as the Web page and APIs.
+type metricsCollector struct {
+	server *webServer
+
+	cpuUsedPercent   *prometheus.Desc
+	cpuLogicalCores  *prometheus.Desc
+	ramTotalBytes    *prometheus.Desc
+	ramUsedBytes     *prometheus.Desc
+	ramUsedPercent   *prometheus.Desc
+	diskTotalBytes   *prometheus.Desc
+	diskUsedBytes    *prometheus.Desc
+	diskUsedPercent  *prometheus.Desc
+	livePeers        *prometheus.Desc
+	addrBookSize     *prometheus.Desc
+	snapshotUp       *prometheus.Desc
+	snapshotSize     *prometheus.Desc
+	snapshotAge      *prometheus.Desc
+	scrapeErrorCount *prometheus.Desc
+}
+
+func newMetricsCollector(server *webServer) *metricsCollector {
+	chainLabels := []string{"chain_id"}
+	newDesc := func(name, help string, variableLabels []string, constLabels prometheus.Labels) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(metricsNamespace, "", name), help, variableLabels, constLabels)
+	}
+
+	return &metricsCollector{
+		server: server,
+
+		cpuUsedPercent:   newDesc("cpu_used_percent", "CPU usage in percent", nil, nil),
+		cpuLogicalCores:  newDesc("cpu_logical_cores", "Number of logical CPU cores", nil, nil),
+		ramTotalBytes:    newDesc("ram_total_bytes", "Total RAM in bytes", nil, nil),
+		ramUsedBytes:     newDesc("ram_used_bytes", "Used RAM in bytes", nil, nil),
+		ramUsedPercent:   newDesc("ram_used_percent", "RAM usage in percent", nil, nil),
+		diskTotalBytes:   newDesc("disk_total_bytes", "Total size of the monitored disk in bytes", []string{"mount"}, nil),
+		diskUsedBytes:    newDesc("disk_used_bytes", "Used size of the monitored disk in bytes", []string{"mount"}, nil),
+		diskUsedPercent:  newDesc("disk_used_percent", "Usage of the monitored disk in percent", []string{"mount"}, nil),
+		livePeers:        newDesc("live_peers", "Number of live peers in addrbook.json", chainLabels, nil),
+		addrBookSize:     newDesc("addrbook_addresses", "Number of addresses served in the downloadable addrbook.json", chainLabels, nil),
+		snapshotUp:       newDesc("snapshot_available", "Whether the snapshot file is available (1) or not (0)", chainLabels, nil),
+		snapshotSize:     newDesc("snapshot_size_bytes", "Size of the snapshot file in bytes", chainLabels, nil),
+		snapshotAge:      newDesc("snapshot_age_seconds", "Seconds since the snapshot file was last modified", chainLabels, nil),
+		scrapeErrorCount: newDesc("scrape_errors", "Number of metrics failed to collect in this scrape", nil, nil),
+	}
+}
+
+func (m *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.cpuUsedPercent
+	ch <- m.cpuLogicalCores
+	ch <- m.ramTotalBytes
+	ch <- m.ramUsedBytes
+	ch <- m.ramUsedPercent
+	ch <- m.diskTotalBytes
+	ch <- m.diskUsedBytes
+	ch <- m.diskUsedPercent
+	ch <- m.livePeers
+	ch <- m.addrBookSize
+	ch <- m.snapshotUp
+	ch <- m.snapshotSize
+	ch <- m.snapshotAge
+	ch <- m.scrapeErrorCount
+}
+
+func (m *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	var scrapeErrors int
+
+	gauge := func(desc *prometheus.Desc, value float64, labelValues ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labelValues...)
+	}
+
+	if cpusPercent, err := cpu.Percent(0, false); err == nil && len(cpusPercent) > 0 {
+		gauge(m.cpuUsedPercent, cpusPercent[0])
+	} else {
+		scrapeErrors++
+	}
+
+	if lCore, err := cpu.Counts(true); err == nil {
+		gauge(m.cpuLogicalCores, float64(lCore))
+	} else {
+		scrapeErrors++
+	}
+
+	if vm, err := mem.VirtualMemory(); err == nil {
+		gauge(m.ramTotalBytes, float64(vm.Total))
+		gauge(m.ramUsedBytes, float64(vm.Used))
+		gauge(m.ramUsedPercent, vm.UsedPercent)
+	} else {
+		scrapeErrors++
+	}
+
+	cfg, chains := m.server.current()
+
+	for _, monitorDisk := range cfg.MonitorDisks {
+		du, err := disk.Usage(monitorDisk)
+		if err != nil {
+			utils.PrintlnStdErr("ERR: failed to get disk spec", "disk", monitorDisk, "error", err.Error())
+			scrapeErrors++
+			continue
+		}
+
+		gauge(m.diskTotalBytes, float64(du.Total), monitorDisk)
+		gauge(m.diskUsedBytes, float64(du.Used), monitorDisk)
+		gauge(m.diskUsedPercent, du.UsedPercent, monitorDisk)
+	}
+
+	for _, chain := range chains {
+		chainId := chain.cfg.ChainID
+
+		if peers, err := getLivePeers(chain); err == nil {
+			gauge(m.livePeers, float64(len(peers)), chainId)
+		} else {
+			scrapeErrors++
+		}
+
+		if addrBook, err := getAddrbook(chain); err == nil {
+			gauge(m.addrBookSize, float64(len(addrBook.Addrs)), chainId)
+		} else {
+			scrapeErrors++
+		}
+
+		if snapshotInfo := getSnapshotInfo(chain); snapshotInfo.Error == nil {
+			gauge(m.snapshotUp, 1, chainId)
+			gauge(m.snapshotSize, float64(snapshotInfo.SizeBytes), chainId)
+			gauge(m.snapshotAge, time.Since(snapshotInfo.ModifiedAt).Seconds(), chainId)
+		} else {
+			gauge(m.snapshotUp, 0, chainId)
+		}
+	}
+
+	gauge(m.scrapeErrorCount, float64(scrapeErrors))
+}
+
+// newMetricsHandler returns the Prometheus handler, which exposes the box & chain metrics.
+func newMetricsHandler(server *webServer) gin.HandlerFunc {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(newMetricsCollector(server))
+
+	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+		Timeout:       30 * time.Second,
+	})
+
+	return func(c *gin.Context) {
+		w := wrapGin(c)
+		if !w.IsAuthorizedRequest() && !isAuthorizedBearerRequest(c, w.Config().AuthorizeToken) {
+			w.PrepareDefaultErrorResponse().
+				WithHttpStatusCode(http.StatusForbidden).
+				WithResult("invalid authentication token").
+				SendResponse()
+			return
+		}
+
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// isAuthorizedBearerRequest accepts the authorization token as bearer token, supported by scrapers like Prometheus.
+// Only the metrics endpoint accepts it, other internal APIs require the VN-Authorization header.
+func isAuthorizedBearerRequest(c *gin.Context, authorizeToken string) bool {
+	if authorizeToken == "" {
+		return false
+	}
+
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !found || token == "" {
+		return false
+	}
+
+	return token == authorizeToken
+}
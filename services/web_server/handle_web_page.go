@@ -15,16 +15,20 @@ import (
 	"time"
 )
 
-var cacheSnapshotData *types.TimeBasedCache
-
 func HandleWebIndex(c *gin.Context) {
-	w := wrapGin(c)
-	cfg := w.Config()
+	route := getChainRoute(c)
+	if route == nil {
+		handleWebChains(c)
+		return
+	}
+
+	chain := route.chain
+	cfg := chain.cfg
 
 	var livePeers string
 	var livePeersCount int
 
-	peers, err := getLivePeers(cfg)
+	peers, err := getLivePeers(chain)
 	if err != nil {
 		utils.PrintlnStdErr("ERR: failed to get live peers:", err)
 	} else {
@@ -36,12 +40,25 @@ func HandleWebIndex(c *gin.Context) {
 		livePeersCount = len(peers)
 	}
 
-	snapshotInfo := getSnapshotInfo(cfg)
+	snapshotInfo := getSnapshotInfo(chain)
+	snapshotInfo.DownloadFilePath = absoluteDownloadURL(c, snapshotInfo.DownloadFilePath)
 
-	if snapshotInfo.Error != nil && cfg.Debug {
+	snapshotCatalog, err := getSnapshotCatalog(chain)
+	if err != nil && chain.debug {
+		utils.PrintlnStdErr("ERR: failed to get snapshot catalog:", err)
+	}
+
+	nodeStatus, err := getNodeStatus(chain)
+	if err != nil && chain.debug {
+		utils.PrintlnStdErr("ERR: failed to get node status:", err)
+	}
+
+	if snapshotInfo.Error != nil && chain.debug {
 		utils.PrintlnStdErr("ERR: failed to get snapshot info:", snapshotInfo.Error)
 	}
 
+	installDecompressTool, decompressCmd := getDecompressGuide(snapshotInfo.Compression)
+
 	var chainDescriptionLines []string
 	if cfg.ChainDescription != "" {
 		chainDescriptionLines = strings.Split(cfg.ChainDescription, "\\n")
@@ -52,6 +69,7 @@ func HandleWebIndex(c *gin.Context) {
 
 	c.HTML(http.StatusOK, "index.tmpl", gin.H{
 		"host":                c.Request.Host,
+		"basePath":            route.basePath,
 		"brand":               cfg.Brand,
 		"brandLink":           fmt.Sprintf("https://%s", strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(cfg.Brand, "://"), "http://"), "https://")),
 		"title":               fmt.Sprintf("%s snapshot by %s", cfg.ChainName, cfg.Brand),
@@ -70,20 +88,74 @@ func HandleWebIndex(c *gin.Context) {
 		"generalNodeHomeName": cfg.GeneralNodeHomeName,
 		"generalBinaryName":   cfg.GeneralBinaryName,
 		"snapshot":            snapshotInfo,
+		"snapshotCatalog":     withAbsoluteDownloadURLs(c, snapshotCatalog),
+		"installDecompress":   installDecompressTool,
+		"decompressCmd":       decompressCmd,
+		"nodeStatus":          nodeStatus,
 		"binaryVersion":       constants.VERSION,
 	})
 }
 
-func getSnapshotInfo(cfg webtypes.Config) webtypes.SnapshotInfo {
-	if ss := cacheSnapshotData.GetRL(); ss != nil {
+// handleWebChains renders the list of chains served by this Web service,
+// for requests those are not bound to any chain.
+func handleWebChains(c *gin.Context) {
+	w := wrapGin(c)
+	cfg := w.Config()
+
+	chains := make([]gin.H, len(cfg.Chains))
+	for i, chain := range cfg.Chains {
+		chains[i] = gin.H{
+			"chainName": chain.ChainName,
+			"chainId":   chain.ChainID,
+			"logo":      chain.ExternalResourceLogoUrl,
+			"path":      "/" + chain.Slug,
+		}
+	}
+
+	var brand string
+	if len(cfg.Chains) > 0 {
+		brand = cfg.Chains[0].Brand
+	}
+
+	c.HTML(http.StatusOK, "chains.tmpl", gin.H{
+		"brand":         brand,
+		"title":         fmt.Sprintf("Snapshots by %s", brand),
+		"chains":        chains,
+		"binaryVersion": constants.VERSION,
+	})
+}
+
+func getSnapshotInfo(chain *chainState) webtypes.SnapshotInfo {
+	if ss := chain.cacheSnapshotData.GetRL(); ss != nil {
 		return ss.(webtypes.SnapshotInfo)
 	}
 
-	ss, _ := cacheSnapshotData.UpdateWL(func() (any, error) {
+	cfg := chain.cfg
+	ss, _ := chain.cacheSnapshotData.UpdateWL(func() (any, error) {
 		ss, err := func() (*webtypes.SnapshotInfo, error) {
 			filePath := cfg.SnapshotFilePath
 			if filePath == "" {
-				return nil, fmt.Errorf("snapshot file path is empty")
+				// fallback to the latest snapshot in the catalog
+				catalog, err := getSnapshotCatalog(chain)
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to get snapshot catalog")
+				}
+				if len(catalog) == 0 {
+					return nil, fmt.Errorf("no snapshot available")
+				}
+				latest := catalog[0]
+				return &webtypes.SnapshotInfo{
+					FileName:         latest.FileName,
+					Size:             formatFileSize(latest.SizeBytes),
+					SizeBytes:        latest.SizeBytes,
+					ModTime:          formatAge(time.Since(latest.CreatedAt)),
+					ModifiedAt:       latest.CreatedAt,
+					DownloadFilePath: latest.DownloadURL,
+					Compression:      latest.Compression,
+					Height:           latest.Height,
+					Sha256:           latest.Sha256,
+					Error:            nil,
+				}, nil
 			}
 
 			fi, err := os.Stat(filePath)
@@ -96,35 +168,39 @@ func getSnapshotInfo(cfg webtypes.Config) webtypes.SnapshotInfo {
 				return nil, fmt.Errorf("snapshot file is empty")
 			}
 
-			var strFileSize string
-			if fileSize > 1024*1024*1024 {
-				strFileSize = fmt.Sprintf("%.2f GB", float64(fileSize)/1024/1024/1024)
-			} else if fileSize > 1024*1024 {
-				strFileSize = fmt.Sprintf("%.2f MB", float64(fileSize)/1024/1024)
-			} else {
-				strFileSize = fmt.Sprintf("%.2f KB", float64(fileSize)/1024)
+			_, fileName := filepath.Split(filePath)
+			downloadURL := cfg.SnapshotDownloadURL
+			if cfg.SnapshotServe {
+				downloadURL = builtInSnapshotDownloadURL(fileName)
+			}
+			snapshotInfo := &webtypes.SnapshotInfo{
+				FileName:         fileName,
+				Size:             formatFileSize(fileSize),
+				SizeBytes:        fileSize,
+				ModTime:          formatAge(time.Since(fi.ModTime())),
+				ModifiedAt:       fi.ModTime(),
+				DownloadFilePath: downloadURL,
+				Compression:      snapshotCompressionFromFileName(fileName),
+				Error:            nil,
 			}
 
-			var strModTime string
-			modTime := time.Since(fi.ModTime())
-			if modTime >= 2*24*time.Hour {
-				strModTime = fmt.Sprintf("%d days", int(modTime.Hours()/24))
-			} else if modTime >= 2*time.Hour {
-				strModTime = fmt.Sprintf("%d hours", int(modTime.Hours()))
-			} else if modTime >= 2*time.Minute {
-				strModTime = fmt.Sprintf("%d minutes", int(modTime.Minutes()))
-			} else {
-				strModTime = fmt.Sprintf("%d seconds", int(modTime.Seconds()))
+			manifestFilePath := types.GetSnapshotManifestFilePath(filePath)
+			if _, err := os.Stat(manifestFilePath); err == nil {
+				manifest := &types.SnapshotManifest{}
+				if err := manifest.LoadFromJSONFile(manifestFilePath); err != nil {
+					if chain.debug {
+						utils.PrintlnStdErr("ERR: failed to load snapshot manifest:", err)
+					}
+				} else {
+					snapshotInfo.Height = manifest.Height
+					snapshotInfo.Sha256 = manifest.Sha256
+					if manifest.Compression != "" {
+						snapshotInfo.Compression = manifest.Compression
+					}
+				}
 			}
 
-			_, fileName := filepath.Split(filePath)
-			return &webtypes.SnapshotInfo{
-				FileName:         fileName,
-				Size:             strFileSize,
-				ModTime:          strModTime,
-				DownloadFilePath: cfg.SnapshotDownloadURL,
-				Error:            nil,
-			}, nil
+			return snapshotInfo, nil
 		}()
 		if err != nil {
 			return webtypes.SnapshotInfo{
@@ -137,6 +213,36 @@ func getSnapshotInfo(cfg webtypes.Config) webtypes.SnapshotInfo {
 	return ss.(webtypes.SnapshotInfo)
 }
 
-func init() {
-	cacheSnapshotData = types.NewTimeBasedCache(60 * time.Second)
+// getDecompressGuide returns the command to install the decompression tool, if required, and the command to decompress to stdout.
+func getDecompressGuide(compression string) (installCmd, decompressCmd string) {
+	switch compression {
+	case "zstd":
+		return "sudo apt update && sudo apt install -y zstd", "zstd -c -d"
+	case "gzip":
+		return "", "gzip -c -d"
+	default:
+		return "sudo apt update && sudo apt install snapd -y && sudo snap install lz4", "lz4 -c -d"
+	}
+}
+
+func formatFileSize(fileSize int64) string {
+	if fileSize > 1024*1024*1024 {
+		return fmt.Sprintf("%.2f GB", float64(fileSize)/1024/1024/1024)
+	} else if fileSize > 1024*1024 {
+		return fmt.Sprintf("%.2f MB", float64(fileSize)/1024/1024)
+	} else {
+		return fmt.Sprintf("%.2f KB", float64(fileSize)/1024)
+	}
+}
+
+func formatAge(age time.Duration) string {
+	if age >= 2*24*time.Hour {
+		return fmt.Sprintf("%d days", int(age.Hours()/24))
+	} else if age >= 2*time.Hour {
+		return fmt.Sprintf("%d hours", int(age.Hours()))
+	} else if age >= 2*time.Minute {
+		return fmt.Sprintf("%d minutes", int(age.Minutes()))
+	} else {
+		return fmt.Sprintf("%d seconds", int(age.Seconds()))
+	}
 }
@@ -1,35 +1,366 @@
 package types
 
-import "path"
+import (
+	"fmt"
+	"github.com/bcdevtools/node-management/utils"
+	"github.com/bcdevtools/node-management/validation"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
 
 type Config struct {
-	Port           uint16
-	AuthorizeToken string
-	MonitorDisks   []string
-	NodeHome       string
-	Debug          bool
+	Port           uint16   `toml:"port" yaml:"port"`
+	AuthorizeToken string   `toml:"authorization_token" yaml:"authorization_token"`
+	MonitorDisks   []string `toml:"monitor_disks" yaml:"monitor_disks"`
+	Debug          bool     `toml:"debug" yaml:"debug"`
 
-	Brand string
+	// Download bandwidth per client IP of the built-in snapshot serving, in MB/s, 0 means unlimited
+	SnapshotBandwidthPerClient uint `toml:"snapshot_bandwidth_per_client" yaml:"snapshot_bandwidth_per_client"`
+
+	// Reverse proxies (IP or CIDR) trusted to set X-Forwarded-For and X-Forwarded-Proto, default none
+	TrustedProxies []string `toml:"trusted_proxies" yaml:"trusted_proxies"`
+
+	// Chains served by this Web service, routed by path prefix or Host header
+	Chains []ChainConfig `toml:"chains" yaml:"chains"`
+}
+
+type ChainConfig struct {
+	// Routing
+	Slug  string   `toml:"slug" yaml:"slug"`   // path prefix, eg: cosmoshub → /cosmoshub/...
+	Hosts []string `toml:"hosts" yaml:"hosts"` // Host headers to match, eg: cosmos.example.com
+
+	NodeHome string `toml:"node_home" yaml:"node_home"`
+
+	Brand string `toml:"brand" yaml:"brand"`
 
 	// General chain-based configuration
-	ChainName           string // Evmos Dymension etc
-	ChainID             string // evmos_9001-2 dymension_1100-1 etc
-	ChainDescription    string
-	GeneralBinaryName   string // evmosd dymd etc
-	GeneralNodeHomeName string // .evmosd .dymension etc
+	ChainName           string `toml:"chain_name" yaml:"chain_name"` // Evmos Dymension etc
+	ChainID             string `toml:"chain_id" yaml:"chain_id"`     // evmos_9001-2 dymension_1100-1 etc
+	ChainDescription    string `toml:"chain_description" yaml:"chain_description"`
+	GeneralBinaryName   string `toml:"general_binary_name" yaml:"general_binary_name"`       // evmosd dymd etc
+	GeneralNodeHomeName string `toml:"general_node_home_name" yaml:"general_node_home_name"` // .evmosd .dymension etc
 
 	// External web resources
-	ExternalResourceLogoUrl    string
-	ExternalResourceFaviconUrl string
-	ExternalResourceRpcUrl     string
-	ExternalResourceRestUrl    string
-	ExternalResourceGrpcUrl    string
+	ExternalResourceLogoUrl    string `toml:"logo_url" yaml:"logo_url"`
+	ExternalResourceFaviconUrl string `toml:"favicon_url" yaml:"favicon_url"`
+	ExternalResourceRpcUrl     string `toml:"rpc_url" yaml:"rpc_url"`
+	ExternalResourceRestUrl    string `toml:"rest_url" yaml:"rest_url"`
+	ExternalResourceGrpcUrl    string `toml:"grpc_url" yaml:"grpc_url"`
 
 	// Snapshot information
-	SnapshotFilePath    string
-	SnapshotDownloadURL string
+	SnapshotFilePath    string `toml:"snapshot_file" yaml:"snapshot_file"`
+	SnapshotDownloadURL string `toml:"snapshot_download_url" yaml:"snapshot_download_url"`
+
+	// Snapshot catalog, snapshot files in the directory are served at <base URL>/<file name>
+	SnapshotDirPath         string `toml:"snapshot_dir" yaml:"snapshot_dir"`
+	SnapshotDownloadBaseURL string `toml:"snapshot_download_base_url" yaml:"snapshot_download_base_url"`
+
+	// Serve the snapshot files by this Web service at /snapshots/<file name>, instead of the download URLs
+	SnapshotServe bool `toml:"snapshot_serve" yaml:"snapshot_serve"`
+}
+
+// reservedSlugs are the first path segments used by the chain-agnostic routes.
+var reservedSlugs = []string{"api", "resources", "download", "metrics", "snapshots"}
+
+var regexpSlug = regexp.MustCompile(`^[a-z\d][a-z\d_-]*$`)
+
+func (c *Config) Normalize() {
+	c.AuthorizeToken = strings.TrimSpace(c.AuthorizeToken)
+	for i, disk := range c.MonitorDisks {
+		c.MonitorDisks[i] = strings.TrimSpace(disk)
+	}
+	for i, trustedProxy := range c.TrustedProxies {
+		c.TrustedProxies[i] = strings.TrimSpace(trustedProxy)
+	}
+	for i := range c.Chains {
+		c.Chains[i].Normalize()
+	}
+}
+
+func (c Config) Validate() error {
+	if c.AuthorizeToken == "" {
+		return fmt.Errorf("authorization token is required")
+	}
+
+	if len(c.MonitorDisks) == 0 {
+		return fmt.Errorf("disks are required")
+	}
+	for _, monitorDisk := range c.MonitorDisks {
+		if !strings.HasPrefix(monitorDisk, "/") {
+			return fmt.Errorf("disk must be absolute path: %s", monitorDisk)
+		}
+		if strings.HasPrefix(monitorDisk, "/dev") {
+			return fmt.Errorf("disk must be filesystem path, not device: %s", monitorDisk)
+		}
+		_, exists, isDir, err := utils.FileInfo(monitorDisk)
+		if err != nil {
+			return errors.Wrapf(err, "disk %s is invalid", monitorDisk)
+		}
+		if !exists {
+			return fmt.Errorf("disk %s does not exists", monitorDisk)
+		}
+		if !isDir {
+			return fmt.Errorf("disk %s is not a directory, must be filesystem path", monitorDisk)
+		}
+	}
+
+	if _, err := c.trustedProxyNets(); err != nil {
+		return err
+	}
+
+	if len(c.Chains) == 0 {
+		return fmt.Errorf("at least one chain is required")
+	}
+
+	var chainIds, slugs, hosts []string
+	for _, chain := range c.Chains {
+		if err := chain.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid chain %s", chain.ChainID)
+		}
+
+		if slices.Contains(chainIds, chain.ChainID) {
+			return fmt.Errorf("duplicated chain ID %s", chain.ChainID)
+		}
+		chainIds = append(chainIds, chain.ChainID)
+
+		if chain.Slug == "" {
+			if len(c.Chains) > 1 {
+				return fmt.Errorf("slug is required for chain %s when serving multiple chains", chain.ChainID)
+			}
+		} else if slices.Contains(slugs, chain.Slug) {
+			return fmt.Errorf("duplicated slug %s", chain.Slug)
+		} else {
+			slugs = append(slugs, chain.Slug)
+		}
+
+		for _, host := range chain.Hosts {
+			if slices.Contains(hosts, host) {
+				return fmt.Errorf("duplicated host %s", host)
+			}
+			hosts = append(hosts, host)
+		}
+	}
+
+	return nil
+}
+
+// IsTrustedProxy returns true if the IP is one of the trusted reverse proxies.
+func (c Config) IsTrustedProxy(ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	nets, err := c.trustedProxyNets()
+	if err != nil {
+		return false
+	}
+	for _, ipNet := range nets {
+		if ipNet.Contains(parsedIP) {
+			return true
+		}
+	}
+	return false
+}
+
+// trustedProxyNets parses the trusted proxies, an IP is a single-IP network.
+func (c Config) trustedProxyNets() ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, trustedProxy := range c.TrustedProxies {
+		cidr := trustedProxy
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy, must be IP or CIDR: %s", trustedProxy)
+			}
+			if ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy, must be IP or CIDR: %s", trustedProxy)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+func (c *ChainConfig) Normalize() {
+	c.Slug = strings.ToLower(strings.Trim(strings.TrimSpace(c.Slug), "/"))
+	for i, host := range c.Hosts {
+		c.Hosts[i] = strings.ToLower(strings.TrimSpace(host))
+	}
+	c.NodeHome = strings.TrimSpace(c.NodeHome)
+	c.Brand = strings.TrimSpace(c.Brand)
+	c.ChainName = strings.TrimSpace(c.ChainName)
+	c.ChainDescription = strings.TrimSpace(c.ChainDescription)
+	c.ChainID = strings.TrimSpace(c.ChainID)
+	c.GeneralBinaryName = strings.TrimSpace(c.GeneralBinaryName)
+	c.GeneralNodeHomeName = strings.TrimSpace(c.GeneralNodeHomeName)
+
+	c.ExternalResourceLogoUrl = strings.TrimSpace(c.ExternalResourceLogoUrl)
+	c.ExternalResourceFaviconUrl = strings.TrimSpace(c.ExternalResourceFaviconUrl)
+	c.ExternalResourceRpcUrl = strings.TrimSpace(c.ExternalResourceRpcUrl)
+	c.ExternalResourceRestUrl = strings.TrimSpace(c.ExternalResourceRestUrl)
+	c.ExternalResourceGrpcUrl = strings.TrimSpace(c.ExternalResourceGrpcUrl)
+	if c.ExternalResourceLogoUrl == "" && c.ExternalResourceFaviconUrl != "" {
+		c.ExternalResourceLogoUrl = c.ExternalResourceFaviconUrl
+	}
+
+	c.SnapshotFilePath = strings.TrimSpace(c.SnapshotFilePath)
+	c.SnapshotDownloadURL = strings.TrimSuffix(strings.TrimSpace(c.SnapshotDownloadURL), "/")
+	c.SnapshotDirPath = strings.TrimSpace(c.SnapshotDirPath)
+	c.SnapshotDownloadBaseURL = strings.TrimSuffix(strings.TrimSpace(c.SnapshotDownloadBaseURL), "/")
 }
 
-func (c Config) GetAddrBookFilePath() string {
+func (c ChainConfig) Validate() error {
+	if c.Slug != "" {
+		if !regexpSlug.MatchString(c.Slug) {
+			return fmt.Errorf("slug must contain only lowercase letters, digits, hyphens/dash, and underscores: %s", c.Slug)
+		}
+		if slices.Contains(reservedSlugs, c.Slug) {
+			return fmt.Errorf("slug %s is reserved", c.Slug)
+		}
+	}
+	for _, host := range c.Hosts {
+		if host == "" || strings.Contains(host, "/") || strings.Contains(host, ":") {
+			return fmt.Errorf("host must be domain only, without protocol and port: %s", host)
+		}
+	}
+
+	if err := validation.PossibleNodeHome(c.NodeHome); err != nil {
+		return errors.Wrap(err, "invalid node home directory")
+	}
+
+	if c.Brand == "" {
+		return fmt.Errorf("brand is required")
+	}
+
+	if c.ChainName == "" {
+		return fmt.Errorf("chain name is required")
+	}
+
+	if c.ChainID == "" {
+		return fmt.Errorf("chain ID is required")
+	}
+
+	if c.GeneralBinaryName == "" {
+		return fmt.Errorf("general binary name is required")
+	}
+
+	if c.GeneralNodeHomeName == "" {
+		return fmt.Errorf("general node home name is required")
+	}
+	if !strings.HasPrefix(c.GeneralNodeHomeName, ".") {
+		return fmt.Errorf("general node home name must starts with a dot")
+	}
+	if strings.Contains(c.GeneralNodeHomeName, "/") {
+		return fmt.Errorf("general node home name must be name only, not a path")
+	}
+
+	validateExternalUrl := func(name, url string, required bool) error {
+		if url == "" {
+			if required {
+				return fmt.Errorf("external resource %s URL is required", name)
+			}
+			return nil
+		}
+		if !strings.Contains(url, "://") || strings.Contains(url, "localhost") || strings.Contains(url, "127.0.0.1") {
+			return fmt.Errorf("external resource %s URL must contains protocol, not localhost", name)
+		}
+		return nil
+	}
+	if err := validateExternalUrl("RPC", c.ExternalResourceRpcUrl, true); err != nil {
+		return err
+	}
+	if err := validateExternalUrl("REST", c.ExternalResourceRestUrl, true); err != nil {
+		return err
+	}
+	if err := validateExternalUrl("gRPC", c.ExternalResourceGrpcUrl, false); err != nil {
+		return err
+	}
+
+	if c.SnapshotFilePath == "" && c.SnapshotDirPath == "" {
+		return fmt.Errorf("snapshot file path or snapshot directory is required")
+	}
+
+	if c.SnapshotFilePath != "" && c.SnapshotDownloadURL == "" && !c.SnapshotServe {
+		return fmt.Errorf("snapshot download URL is required")
+	}
+
+	if c.SnapshotDirPath != "" {
+		_, exists, isDir, err := utils.FileInfo(c.SnapshotDirPath)
+		if err != nil {
+			return errors.Wrapf(err, "snapshot directory %s is invalid", c.SnapshotDirPath)
+		}
+		if !exists {
+			return fmt.Errorf("snapshot directory %s does not exists", c.SnapshotDirPath)
+		}
+		if !isDir {
+			return fmt.Errorf("snapshot directory %s is not a directory", c.SnapshotDirPath)
+		}
+
+		if c.SnapshotDownloadBaseURL == "" && !c.SnapshotServe {
+			return fmt.Errorf("snapshot download base URL is required when snapshot directory is provided")
+		}
+	}
+
+	return nil
+}
+
+// LoadChainConfigFromTomlFile reads a chain profile from a TOML file, the result is not normalized nor validated.
+func LoadChainConfigFromTomlFile(filePath string) (*ChainConfig, error) {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read chain profile file")
+	}
+
+	var chainConfig ChainConfig
+	if err := toml.Unmarshal(bz, &chainConfig); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal chain profile file")
+	}
+
+	return &chainConfig, nil
+}
+
+// LoadConfigFromFile reads the Web service config from a TOML or YAML file, detected by file extension.
+// The result is not normalized nor validated.
+func LoadConfigFromFile(filePath string) (*Config, error) {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read config file")
+	}
+
+	var config Config
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(bz, &config)
+	case ".toml":
+		err = toml.Unmarshal(bz, &config)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension, must be .toml, .yaml or .yml: %s", filePath)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal config file")
+	}
+
+	return &config, nil
+}
+
+func (c ChainConfig) GetAddrBookFilePath() string {
 	return path.Join(c.NodeHome, "config", "addrbook.json")
 }
+
+func (c ChainConfig) GetConfigTomlFilePath() string {
+	return path.Join(c.NodeHome, "config", "config.toml")
+}
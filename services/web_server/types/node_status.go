@@ -0,0 +1,14 @@
+package types
+
+import "time"
+
+type NodeStatus struct {
+	LatestHeight    int64     `json:"latest_height"`
+	LatestBlockTime time.Time `json:"latest_block_time"`
+	CatchingUp      bool      `json:"catching_up"`
+	Moniker         string    `json:"moniker"`
+	Network         string    `json:"network"`
+	NodeVersion     string    `json:"node_version"`
+	AppName         string    `json:"app_name"`
+	AppVersion      string    `json:"app_version"`
+}
@@ -0,0 +1,20 @@
+package types
+
+import "time"
+
+// SnapshotCatalogEntry describes a snapshot file available for download.
+type SnapshotCatalogEntry struct {
+	FileName    string    `json:"file_name"`
+	Kind        string    `json:"kind,omitempty"` // pruned, default, archive
+	ChainID     string    `json:"chain_id"`
+	Height      int64     `json:"height,omitempty"`
+	Compression string    `json:"compression"`
+	SizeBytes   int64     `json:"size"`
+	Size        string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+	Age         string    `json:"-"`
+	Sha256      string    `json:"sha256,omitempty"`
+	DownloadURL string    `json:"download_url"`
+
+	UncompressedSizeBytes int64 `json:"uncompressed_size,omitempty"`
+}
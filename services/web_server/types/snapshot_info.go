@@ -1,9 +1,16 @@
 package types
 
+import "time"
+
 type SnapshotInfo struct {
 	FileName         string
 	Size             string
+	SizeBytes        int64
 	ModTime          string
+	ModifiedAt       time.Time
 	DownloadFilePath string
+	Compression      string // lz4, zstd, gzip
+	Height           int64  // from the sidecar manifest, zero if not available
+	Sha256           string // from the sidecar manifest, empty if not available
 	Error            error
 }
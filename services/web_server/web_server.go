@@ -6,18 +6,26 @@ import (
 	"github.com/bcdevtools/node-management/services/web_server/gin_wrapper"
 	webtypes "github.com/bcdevtools/node-management/services/web_server/types"
 	"github.com/bcdevtools/node-management/utils"
-	"github.com/bcdevtools/node-management/validation"
 	"github.com/gin-gonic/gin"
 	"github.com/pkg/errors"
 	statikfs "github.com/rakyll/statik/fs"
 	"html/template"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
+	"syscall"
 )
 
-func StartWebServer(cfg webtypes.Config) {
-	if err := validation.PossibleNodeHome(cfg.NodeHome); err != nil {
-		utils.PrintlnStdErr("ERR: invalid node home directory:", err)
+// StartWebServer starts the Web service.
+// If reloadConfig is provided, the config is reloaded on SIGHUP without restarting the service.
+func StartWebServer(cfg webtypes.Config, reloadConfig func() (*webtypes.Config, error)) {
+	if err := cfg.Validate(); err != nil {
+		utils.PrintlnStdErr("ERR: invalid Web service config:", err)
 		return
 	}
 
@@ -28,9 +36,18 @@ func StartWebServer(cfg webtypes.Config) {
 		panic(errors.Wrap(err, "failed to create statik FS"))
 	}
 
+	server := newWebServer(cfg)
+
 	r := gin.Default()
+	// client IP, used by the per-client bandwidth limit, is taken from X-Forwarded-For only when set by trusted proxies
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		panic(errors.Wrap(err, "failed to set trusted proxies"))
+	}
 	r.Use(func(c *gin.Context) {
-		c.Set(constants.GinConfig, cfg)
+		c.Set(constants.GinConfig, configFromContext(c.Request.Context()))
+		if route := chainRouteFromContext(c.Request.Context()); route != nil {
+			c.Set(constants.GinChainRoute, route)
+		}
 	})
 	r.Use(func(c *gin.Context) {
 		if strings.HasPrefix(strings.TrimPrefix(c.Request.URL.Path, "/"), "api/internal") {
@@ -62,6 +79,7 @@ func StartWebServer(cfg webtypes.Config) {
 				ParseFS(
 					webtypes.WrapHttpFsToOsFs(statikFS),
 					"/index.tmpl",
+					"/chains.tmpl",
 				),
 		),
 	)
@@ -72,21 +90,198 @@ func StartWebServer(cfg webtypes.Config) {
 	})
 
 	// API
-	r.GET("/api/node/live-peers", HandleApiNodeLivePeers)
+	r.GET("/api/node/live-peers", requireChain, HandleApiNodeLivePeers)
+	r.GET("/api/node/status", requireChain, HandleApiNodeStatus)
+	r.GET("/api/snapshots", requireChain, HandleApiSnapshots)
 	r.GET("/api/internal/monitoring/stats", HandleApiInternalMonitoringStats)
+	r.GET("/api/internal/setup-check", requireChain, HandleApiInternalSetupCheck)
+
+	// Metrics
+	r.GET("/metrics", newMetricsHandler(server))
 
 	// Web
 	r.GET("/", HandleWebIndex)
-	r.GET("/download/addrbook.json", HandleDownloadAddrBook)
+	r.GET("/download/addrbook.json", requireChain, HandleDownloadAddrBook)
+	r.GET(builtInSnapshotPathPrefix+":file", requireChain, newDownloadSnapshotHandler(server))
+	r.HEAD(builtInSnapshotPathPrefix+":file", requireChain, newDownloadSnapshotHandler(server))
+
+	server.engine = r
 
 	fmt.Println("INF: starting Web service at", binding)
+	server.printChains()
+
+	if reloadConfig != nil {
+		go server.reloadOnSignal(reloadConfig)
+	}
 
-	if err := r.Run(binding); err != nil {
+	httpServer := &http.Server{
+		Addr:    binding,
+		Handler: server,
+	}
+	if err := httpServer.ListenAndServe(); err != nil {
 		utils.PrintlnStdErr("ERR: failed to start Web service")
 		panic(err)
 	}
 }
 
+// webServer resolves the chain of each request, by path prefix or Host header,
+// then forwards the request to the gin engine.
+type webServer struct {
+	engine *gin.Engine
+
+	mu      sync.RWMutex
+	cfg     webtypes.Config
+	chains  []*chainState
+	limiter *bandwidthLimiter
+}
+
+func newWebServer(cfg webtypes.Config) *webServer {
+	s := &webServer{}
+	s.applyConfig(cfg)
+	return s
+}
+
+// current returns the config and the chains currently served.
+func (s *webServer) current() (webtypes.Config, []*chainState) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cfg, s.chains
+}
+
+// applyConfig swaps the config and the chains served.
+// Caches of the chains those config did not change are kept.
+func (s *webServer) applyConfig(cfg webtypes.Config) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	chains := make([]*chainState, len(cfg.Chains))
+	for i, chainConfig := range cfg.Chains {
+		idx := slices.IndexFunc(s.chains, func(existing *chainState) bool {
+			return existing.debug == cfg.Debug && reflect.DeepEqual(existing.cfg, chainConfig)
+		})
+		if idx >= 0 {
+			chains[i] = s.chains[idx]
+		} else {
+			chains[i] = newChainState(chainConfig, cfg.Debug)
+		}
+	}
+
+	bytesPerSecond := int(cfg.SnapshotBandwidthPerClient) * 1024 * 1024
+	if s.limiter == nil || s.limiter.bytesPerSecond != bytesPerSecond {
+		s.limiter = newBandwidthLimiter(bytesPerSecond)
+	}
+
+	s.cfg = cfg
+	s.chains = chains
+}
+
+// bandwidthLimiter returns the limiter of the built-in snapshot serving.
+func (s *webServer) bandwidthLimiter() *bandwidthLimiter {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.limiter
+}
+
+// reloadOnSignal reloads the config on SIGHUP, the existing connections are not affected.
+func (s *webServer) reloadOnSignal(reloadConfig func() (*webtypes.Config, error)) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	for range sigCh {
+		fmt.Println("INF: received SIGHUP, reloading config")
+
+		cfg, err := reloadConfig()
+		if err != nil {
+			utils.PrintlnStdErr("ERR: failed to reload config, keep serving with the current config:", err)
+			continue
+		}
+
+		currentCfg, _ := s.current()
+		if cfg.Port != currentCfg.Port {
+			utils.PrintlnStdErr(fmt.Sprintf("WARN: port changed from %d to %d, restart the Web service to apply", currentCfg.Port, cfg.Port))
+			cfg.Port = currentCfg.Port
+		}
+		if !slices.Equal(cfg.TrustedProxies, currentCfg.TrustedProxies) {
+			utils.PrintlnStdErr("WARN: trusted proxies changed, restart the Web service to apply")
+			cfg.TrustedProxies = currentCfg.TrustedProxies
+		}
+
+		s.applyConfig(*cfg)
+
+		fmt.Println("INF: reloaded config")
+		s.printChains()
+	}
+}
+
+func (s *webServer) printChains() {
+	_, chains := s.current()
+	for _, chain := range chains {
+		if chain.cfg.Slug != "" {
+			fmt.Printf("INF: serving chain %s at path /%s\n", chain.cfg.ChainID, chain.cfg.Slug)
+		}
+		for _, host := range chain.cfg.Hosts {
+			fmt.Printf("INF: serving chain %s at host %s\n", chain.cfg.ChainID, host)
+		}
+	}
+}
+
+func (s *webServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cfg, chains := s.current()
+	r = r.WithContext(withConfig(r.Context(), cfg))
+
+	if route, strippedPath := resolveChainRoute(chains, r); route != nil {
+		r = r.WithContext(withChainRoute(r.Context(), route))
+		if route.basePath != "" {
+			u := *r.URL
+			u.Path = strippedPath
+			u.RawPath = ""
+			r.URL = &u
+		}
+	}
+
+	s.engine.ServeHTTP(w, r)
+}
+
+// resolveChainRoute finds the chain to serve the request, priority: path prefix, Host header, the only chain.
+// When resolved by path prefix, the request path without the prefix is returned.
+func resolveChainRoute(chains []*chainState, r *http.Request) (route *chainRoute, strippedPath string) {
+	firstSegment, remaining, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if firstSegment != "" {
+		for _, chain := range chains {
+			if chain.cfg.Slug == "" || chain.cfg.Slug != firstSegment {
+				continue
+			}
+
+			return &chainRoute{
+				chain:    chain,
+				basePath: "/" + chain.cfg.Slug,
+			}, "/" + remaining
+		}
+	}
+
+	host := strings.ToLower(r.Host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host != "" {
+		for _, chain := range chains {
+			if slices.Contains(chain.cfg.Hosts, host) {
+				return &chainRoute{
+					chain: chain,
+				}, r.URL.Path
+			}
+		}
+	}
+
+	if len(chains) == 1 {
+		return &chainRoute{
+			chain: chains[0],
+		}, r.URL.Path
+	}
+
+	return nil, r.URL.Path
+}
+
 // wrap and return gin Context as a GinWrapper class with enhanced utilities
 func wrapGin(c *gin.Context) gin_wrapper.GinWrapper {
 	return gin_wrapper.WrapGin(c)
@@ -0,0 +1,5 @@
+package types
+
+type ClientToml struct {
+	ChainId string `toml:"chain-id"`
+}
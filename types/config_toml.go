@@ -10,12 +10,29 @@ import (
 )
 
 type P2pConfigToml struct {
-	Seeds               string `toml:"seeds"`
-	Laddr               string `toml:"laddr"`
-	PersistentPeers     string `toml:"persistent_peers"`
-	MaxNumInboundPeers  int    `toml:"max_num_inbound_peers"`
-	MaxNumOutboundPeers int    `toml:"max_num_outbound_peers"`
-	SeedMode            bool   `toml:"seed_mode"`
+	Seeds                string `toml:"seeds"`
+	Laddr                string `toml:"laddr"`
+	ExternalAddress      string `toml:"external_address"`
+	PersistentPeers      string `toml:"persistent_peers"`
+	UnconditionalPeerIds string `toml:"unconditional_peer_ids"`
+	PrivatePeerIds       string `toml:"private_peer_ids"`
+	MaxNumInboundPeers   int    `toml:"max_num_inbound_peers"`
+	MaxNumOutboundPeers  int    `toml:"max_num_outbound_peers"`
+	// Pex is nil when absent, CometBFT default is enabled, use IsPexEnabled
+	Pex      *bool `toml:"pex"`
+	SeedMode bool  `toml:"seed_mode"`
+	// AddrBookStrict is nil when absent, CometBFT default is enabled, use IsAddrBookStrict
+	AddrBookStrict *bool `toml:"addr_book_strict"`
+}
+
+// IsPexEnabled returns the value of pex, CometBFT default is true.
+func (p P2pConfigToml) IsPexEnabled() bool {
+	return p.Pex == nil || *p.Pex
+}
+
+// IsAddrBookStrict returns the value of addr_book_strict, CometBFT default is true.
+func (p P2pConfigToml) IsAddrBookStrict() bool {
+	return p.AddrBookStrict == nil || *p.AddrBookStrict
 }
 
 type StateSyncConfigToml struct {
@@ -36,12 +53,14 @@ type RpcConfigToml struct {
 }
 
 type ConfigToml struct {
-	Moniker   string               `toml:"moniker"`
-	P2P       *P2pConfigToml       `toml:"p2p"`
-	StateSync *StateSyncConfigToml `toml:"statesync"`
-	Consensus *ConsensusConfigToml `toml:"consensus"`
-	TxIndex   *TxIndexConfigToml   `toml:"tx_index"`
-	RPC       *RpcConfigToml       `toml:"rpc"`
+	Moniker string `toml:"moniker"`
+	// PrivValidatorLaddr is the address the remote signer (eg: tmkms, horcrux) connects to, empty if the local key file is used
+	PrivValidatorLaddr string               `toml:"priv_validator_laddr"`
+	P2P                *P2pConfigToml       `toml:"p2p"`
+	StateSync          *StateSyncConfigToml `toml:"statesync"`
+	Consensus          *ConsensusConfigToml `toml:"consensus"`
+	TxIndex            *TxIndexConfigToml   `toml:"tx_index"`
+	RPC                *RpcConfigToml       `toml:"rpc"`
 }
 
 func ReadNodeRpcFromConfigToml(configFilePath string) (rpc string, err error) {
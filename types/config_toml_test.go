@@ -0,0 +1,44 @@
+package types
+
+import (
+	"github.com/pelletier/go-toml/v2"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestP2pConfigToml_defaults(t *testing.T) {
+	tests := []struct {
+		name               string
+		content            string
+		wantPex            bool
+		wantAddrBookStrict bool
+	}{
+		{
+			name:               "absent, CometBFT default",
+			content:            "[p2p]\nseeds = \"\"",
+			wantPex:            true,
+			wantAddrBookStrict: true,
+		},
+		{
+			name:               "disabled",
+			content:            "[p2p]\npex = false\naddr_book_strict = false",
+			wantPex:            false,
+			wantAddrBookStrict: false,
+		},
+		{
+			name:               "enabled",
+			content:            "[p2p]\npex = true\naddr_book_strict = true",
+			wantPex:            true,
+			wantAddrBookStrict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ConfigToml{}
+			require.NoError(t, toml.Unmarshal([]byte(tt.content), &config))
+			require.NotNil(t, config.P2P)
+			require.Equal(t, tt.wantPex, config.P2P.IsPexEnabled())
+			require.Equal(t, tt.wantAddrBookStrict, config.P2P.IsAddrBookStrict())
+		})
+	}
+}
@@ -8,6 +8,8 @@ const (
 	RpcNode
 	SnapshotNode
 	ArchivalNode
+	SentryNode
+	SeedNode
 )
 
 var nodeTypeNameToType = map[string]NodeType{
@@ -15,6 +17,8 @@ var nodeTypeNameToType = map[string]NodeType{
 	"rpc":       RpcNode,
 	"snapshot":  SnapshotNode,
 	"archival":  ArchivalNode,
+	"sentry":    SentryNode,
+	"seed":      SeedNode,
 }
 
 func (t NodeType) String() string {
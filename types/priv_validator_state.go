@@ -80,6 +80,57 @@ func (pvs PrivateValidatorState) CompareState(other PrivateValidatorState) (cmp
 	return 0, !sameSign
 }
 
+// privateValidatorStateJson tolerates height, round and step encoded as either number or string,
+// eg: horcrux sign-state encodes height and round as numbers, tmkms encodes round as string.
+type privateValidatorStateJson struct {
+	Height    json.Number `json:"height"`
+	Round     json.Number `json:"round"`
+	Step      json.Number `json:"step"`
+	Signature string      `json:"signature"`
+	SignBytes string      `json:"signbytes"`
+}
+
+func (pvs *PrivateValidatorState) UnmarshalJSON(bz []byte) error {
+	var state privateValidatorStateJson
+	if err := json.Unmarshal(bz, &state); err != nil {
+		return err
+	}
+
+	height, err := parseStateNumber(state.Height)
+	if err != nil {
+		return errors.Wrap(err, "invalid height")
+	}
+	round, err := parseStateNumber(state.Round)
+	if err != nil {
+		return errors.Wrap(err, "invalid round")
+	}
+	step, err := parseStateNumber(state.Step)
+	if err != nil {
+		return errors.Wrap(err, "invalid step")
+	}
+
+	pvs.Height = strconv.FormatInt(height, 10)
+	pvs.Round = int(round)
+	pvs.Step = int(step)
+	pvs.Signature = state.Signature
+	pvs.SignBytes = state.SignBytes
+	return nil
+}
+
+func parseStateNumber(number json.Number) (int64, error) {
+	if number == "" {
+		return 0, nil
+	}
+	value, err := number.Int64()
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, errors.Errorf("negative value %d", value)
+	}
+	return value, nil
+}
+
 func (pvs *PrivateValidatorState) LoadFromJSONFile(filePath string) error {
 	bz, err := os.ReadFile(filePath)
 	if err != nil {
@@ -0,0 +1,104 @@
+package types
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPrivateValidatorState_LoadFromJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		want    PrivateValidatorState
+		wantErr bool
+	}{
+		{
+			name: "priv_validator_state.json",
+			json: `{"height": "100", "round": 1, "step": 3, "signature": "sig", "signbytes": "AB"}`,
+			want: PrivateValidatorState{Height: "100", Round: 1, Step: 3, Signature: "sig", SignBytes: "AB"},
+		},
+		{
+			name: "empty priv_validator_state.json",
+			json: `{"height": "0", "round": 0, "step": 0}`,
+			want: NewEmptyPrivateValidatorState(),
+		},
+		{
+			name: "tmkms, round as string",
+			json: `{"height": "100", "round": "1", "step": 3, "block_id": null}`,
+			want: PrivateValidatorState{Height: "100", Round: 1, Step: 3},
+		},
+		{
+			name: "horcrux sign-state, height and round as number",
+			json: `{"height": 100, "round": 1, "step": 3, "nonce_public": "x", "signature": "sig", "signbytes": "AB"}`,
+			want: PrivateValidatorState{Height: "100", Round: 1, Step: 3, Signature: "sig", SignBytes: "AB"},
+		},
+		{
+			name:    "invalid height",
+			json:    `{"height": "x", "round": 0, "step": 0}`,
+			wantErr: true,
+		},
+		{
+			name:    "negative height",
+			json:    `{"height": -1, "round": 0, "step": 0}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pvs := &PrivateValidatorState{}
+			err := pvs.LoadFromJSON([]byte(tt.json))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, tt.want.Equals(*pvs))
+			require.Equal(t, tt.json, pvs.Json())
+		})
+	}
+}
+
+func TestPrivateValidatorState_CompareState(t *testing.T) {
+	pvs := PrivateValidatorState{Height: "100", Round: 1, Step: 3, Signature: "sig", SignBytes: "AB"}
+
+	tests := []struct {
+		name               string
+		other              PrivateValidatorState
+		wantCmp            int
+		wantDifferentSigns bool
+	}{
+		{
+			name:  "same",
+			other: pvs,
+		},
+		{
+			name:               "same height/round/step, different signature",
+			other:              PrivateValidatorState{Height: "100", Round: 1, Step: 3, Signature: "sig2", SignBytes: "AB"},
+			wantDifferentSigns: true,
+		},
+		{
+			name:               "same height/round/step, different signbytes",
+			other:              PrivateValidatorState{Height: "100", Round: 1, Step: 3, Signature: "sig", SignBytes: "CD"},
+			wantDifferentSigns: true,
+		},
+		{
+			name:               "increased",
+			other:              PrivateValidatorState{Height: "100", Round: 1, Step: 4, Signature: "sig2", SignBytes: "CD"},
+			wantCmp:            -1,
+			wantDifferentSigns: true,
+		},
+		{
+			name:               "decreased",
+			other:              PrivateValidatorState{Height: "99", Round: 1, Step: 3, Signature: "sig2", SignBytes: "CD"},
+			wantCmp:            1,
+			wantDifferentSigns: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmp, differentSigns := pvs.CompareState(tt.other)
+			require.Equal(t, tt.wantCmp, cmp)
+			require.Equal(t, tt.wantDifferentSigns, differentSigns)
+		})
+	}
+}
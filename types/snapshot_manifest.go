@@ -0,0 +1,57 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"os"
+	"time"
+)
+
+// SnapshotManifest is the sidecar `<snapshot file>.json`, describes the snapshot file next to it.
+type SnapshotManifest struct {
+	ChainID          string    `json:"chain_id"`
+	Height           int64     `json:"height"`
+	AppVersion       string    `json:"app_version,omitempty"`
+	NodeVersion      string    `json:"node_version,omitempty"`
+	Kind             string    `json:"kind,omitempty"` // pruned, default, archive
+	Compression      string    `json:"compression"`
+	Sha256           string    `json:"sha256"`
+	CompressedSize   int64     `json:"compressed_size"`
+	UncompressedSize int64     `json:"uncompressed_size"`
+	ExcludedPaths    []string  `json:"excluded_paths,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// SnapshotPartialFileSuffix is appended to the snapshot file while being written, such files must not be served.
+const SnapshotPartialFileSuffix = ".partial"
+
+// GetSnapshotManifestFilePath returns the path of the sidecar manifest of the snapshot file.
+func GetSnapshotManifestFilePath(snapshotFilePath string) string {
+	return snapshotFilePath + ".json"
+}
+
+func (m *SnapshotManifest) LoadFromJSONFile(filePath string) error {
+	bz, err := os.ReadFile(filePath)
+	if err != nil {
+		return errors.Wrap(err, "failed to read snapshot manifest file")
+	}
+
+	if err := json.Unmarshal(bz, m); err != nil {
+		return errors.Wrap(err, "failed to unmarshal snapshot manifest")
+	}
+
+	return nil
+}
+
+func (m SnapshotManifest) SaveToJSONFile(filePath string) error {
+	bz, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal snapshot manifest")
+	}
+
+	if err := os.WriteFile(filePath, bz, 0644); err != nil {
+		return errors.Wrap(err, "failed to write snapshot manifest file")
+	}
+
+	return nil
+}
@@ -14,6 +14,12 @@ func IsValidPeer(peer string) bool {
 	return regexPeerPlus.MatchString(peer)
 }
 
+var regexNodeId = regexp.MustCompile(`^[a-f\d]{40}$`)
+
+func IsValidNodeId(nodeId string) bool {
+	return regexNodeId.MatchString(nodeId)
+}
+
 func PossibleNodeHome(nodeHomeDirectory string) error {
 	if nodeHomeDirectory == "" {
 		return fmt.Errorf("node home directory cannot be empty")